@@ -0,0 +1,21 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package alertmuting
+
+// ToUpdateRequest returns the request updating the AlertMutingRule to its
+// current state, without the fields the request cannot set: Id, Created,
+// Creator, LastUpdated, LastUpdatedBy. The request shares the slices, maps and
+// pointers of o.
+func (o *AlertMutingRule) ToUpdateRequest() *CreateUpdateAlertMutingRuleRequest {
+	if o == nil {
+		return nil
+	}
+	return &CreateUpdateAlertMutingRuleRequest{
+		Description:                        o.Description,
+		Filters:                            o.Filters,
+		Recurrence:                         o.Recurrence,
+		SendAlertsOnceMutingPeriodHasEnded: o.SendAlertsOnceMutingPeriodHasEnded,
+		StartTime:                          o.StartTime,
+		StopTime:                           o.StopTime,
+	}
+}
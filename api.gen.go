@@ -0,0 +1,691 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package signalfx
+
+import (
+	"context"
+	"iter"
+
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/apm"
+	automated_archival "github.com/signalfx/signalfx-go/automated-archival"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/emailtemplate"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/organization"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// AlertMutingRuleAPI contains the methods of Client for alert muting rules.
+type AlertMutingRuleAPI interface {
+	// CreateAlertMutingRule creates an alert muting rule.
+	CreateAlertMutingRule(ctx context.Context, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error)
+
+	// DeleteAlertMutingRule deletes an alert muting rule.
+	DeleteAlertMutingRule(ctx context.Context, name string) error
+
+	// GetAlertMutingRule gets an alert muting rule.
+	GetAlertMutingRule(ctx context.Context, id string) (*alertmuting.AlertMutingRule, error)
+
+	// UpdateAlertMutingRule updates an alert muting rule.
+	UpdateAlertMutingRule(ctx context.Context, id string, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error)
+
+	// SearchAlertMutingRules searches for alert muting rules given a query string in `name`.
+	SearchAlertMutingRules(ctx context.Context, include string, limit int, query string, offset int) (*alertmuting.SearchResult, error)
+
+	// AllAlertMutingRules iterates over every alert muting rule matching the
+	// filter's Query and Include.
+	AllAlertMutingRules(ctx context.Context, filter SearchFilter) iter.Seq2[*alertmuting.AlertMutingRule, error]
+}
+
+// APMAPI contains the methods of Client for APM service topologies.
+type APMAPI interface {
+	ListTopology(ctx context.Context, req *apm.RetrieveServiceTopologyRequest) (*apm.RetrieveServiceTopologyResponse, error)
+}
+
+// AutomatedArchivalAPI contains the methods of Client for automated archival settings.
+type AutomatedArchivalAPI interface {
+	GetSettings(ctx context.Context) (*automated_archival.AutomatedArchivalSettings, error)
+
+	CreateSettings(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error)
+
+	UpdateSettings(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error)
+
+	DeleteSettings(ctx context.Context, deleteSettingsRequest *automated_archival.AutomatedArchivalSettingsDeleteRequest) error
+
+	GetExemptMetrics(ctx context.Context) (*[]automated_archival.ExemptMetric, error)
+
+	CreateExemptMetrics(ctx context.Context, exemptMetrics *[]automated_archival.ExemptMetric) (*[]automated_archival.ExemptMetric, error)
+
+	DeleteExemptMetrics(ctx context.Context, deleteExemptMetricsRequest *automated_archival.ExemptMetricDeleteRequest) error
+}
+
+// ChartAPI contains the methods of Client for charts.
+type ChartAPI interface {
+	// CreateChart creates a chart.
+	CreateChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error)
+
+	// CreateSloChart creates a SLO chart.
+	CreateSloChart(ctx context.Context, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error)
+
+	// DeleteChart deletes a chart.
+	DeleteChart(ctx context.Context, id string) error
+
+	// GetChart gets a chart.
+	GetChart(ctx context.Context, id string) (*chart.Chart, error)
+
+	// UpdateChart updates a chart.
+	UpdateChart(ctx context.Context, id string, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error)
+
+	// UpdateSloChart updates an SLO chart.
+	UpdateSloChart(ctx context.Context, id string, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error)
+
+	// ValidateChart validates a chart.
+	ValidateChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) error
+
+	// SearchCharts searches for charts, given a query string in `name`.
+	SearchCharts(ctx context.Context, limit int, name string, offset int, tags string) (*chart.SearchResult, error)
+
+	// EnsureChart creates the chart of the request, or updates the chart
+	// matching the key if it differs from the request.
+	EnsureChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest, key EnsureKey) (*chart.Chart, EnsureOutcome, error)
+
+	// ModifyChart is like ModifyDetector for charts.
+	ModifyChart(ctx context.Context, id string, mutate func(req *chart.CreateUpdateChartRequest) error) (*chart.Chart, error)
+
+	// AllCharts iterates over every chart matching the filter's Name and Tags.
+	AllCharts(ctx context.Context, filter SearchFilter) iter.Seq2[*chart.Chart, error]
+}
+
+// DashboardAPI contains the methods of Client for dashboards.
+type DashboardAPI interface {
+	// CreateDashboard creates a dashboard.
+	CreateDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error)
+
+	// DeleteDashboard deletes a dashboard.
+	DeleteDashboard(ctx context.Context, id string) error
+
+	// GetDashboard gets a dashboard.
+	GetDashboard(ctx context.Context, id string) (*dashboard.Dashboard, error)
+
+	// UpdateDashboard updates a dashboard.
+	UpdateDashboard(ctx context.Context, id string, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error)
+
+	// ValidateDashboard validates a dashboard with default mode.
+	ValidateDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) error
+
+	// ValidateDashboard validates a dashboard.
+	ValidateDashboardWithMode(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, validationMode VisualizationObjectsValidation) error
+
+	// SearchDashboard searches for dashboards, given a query string in `name`.
+	SearchDashboard(ctx context.Context, limit int, name string, offset int, tags string) (*dashboard.SearchResult, error)
+
+	// EnsureDashboard creates the dashboard of the request, or updates the
+	// dashboard matching the key if it differs from the request.
+	EnsureDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, key EnsureKey) (*dashboard.Dashboard, EnsureOutcome, error)
+
+	// ModifyDashboard is like ModifyDetector for dashboards.
+	ModifyDashboard(ctx context.Context, id string, mutate func(req *dashboard.CreateUpdateDashboardRequest) error) (*dashboard.Dashboard, error)
+
+	// AllDashboards iterates over every dashboard matching the filter's Name and
+	// Tags.
+	AllDashboards(ctx context.Context, filter SearchFilter) iter.Seq2[*dashboard.Dashboard, error]
+}
+
+// DashboardGroupAPI contains the methods of Client for dashboard groups.
+type DashboardGroupAPI interface {
+	// CreateDashboardGroup creates a dashboard.
+	CreateDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, skipImplicitDashboard bool) (*dashboard_group.DashboardGroup, error)
+
+	// DeleteDashboardGroup deletes a dashboard.
+	DeleteDashboardGroup(ctx context.Context, id string) error
+
+	// GetDashboardGroup gets a dashboard group.
+	GetDashboardGroup(ctx context.Context, id string) (*dashboard_group.DashboardGroup, error)
+
+	// UpdateDashboardGroup updates a dashboard group.
+	UpdateDashboardGroup(ctx context.Context, id string, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error)
+
+	// ValidateDashboardGroup validates a dashboard grouop with default mode.
+	ValidateDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) error
+
+	// ValidateDashboardGroupWithMode validates a dashboard grouop.
+	ValidateDashboardGroupWithMode(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, validationMode VisualizationObjectsValidation) error
+
+	// SearchDashboardGroup searches for dashboard groups, given a query string in `name`.
+	SearchDashboardGroups(ctx context.Context, limit int, name string, offset int) (*dashboard_group.SearchResult, error)
+
+	ListBuiltInDashboardGroups(ctx context.Context, limit int, offset int) (*dashboard_group.SearchResult, error)
+
+	// EnsureDashboardGroup creates the dashboard group of the request, without
+	// an implicit dashboard, or updates the group with the same name if it
+	// differs from the request.
+	EnsureDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, EnsureOutcome, error)
+
+	// AllDashboardGroups iterates over every dashboard group matching the
+	// filter's Name.
+	AllDashboardGroups(ctx context.Context, filter SearchFilter) iter.Seq2[*dashboard_group.DashboardGroup, error]
+}
+
+// DataLinkAPI contains the methods of Client for data links.
+type DataLinkAPI interface {
+	// CreateDataLink creates a data link.
+	CreateDataLink(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error)
+
+	// DeleteDataLink deletes a data link.
+	DeleteDataLink(ctx context.Context, id string) error
+
+	// GetDataLink gets a data link.
+	GetDataLink(ctx context.Context, id string) (*datalink.DataLink, error)
+
+	// UpdateDataLink updates a data link.
+	UpdateDataLink(ctx context.Context, id string, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error)
+
+	// SearchDataLinks searches for data links given a query string in `name`.
+	SearchDataLinks(ctx context.Context, limit int, context string, offset int) (*datalink.SearchResults, error)
+
+	// EnsureDataLink creates the data link of the request, or updates the data
+	// link of the same property, value and context if it differs from the
+	// request.
+	EnsureDataLink(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, EnsureOutcome, error)
+
+	// AllDataLinks iterates over every data link matching the filter's Context.
+	AllDataLinks(ctx context.Context, filter SearchFilter) iter.Seq2[*datalink.DataLink, error]
+}
+
+// DetectorAPI contains the methods of Client for detectors.
+type DetectorAPI interface {
+	// CreateDetector creates a detector.
+	CreateDetector(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error)
+
+	// DeleteDetector deletes a detector.
+	DeleteDetector(ctx context.Context, id string) error
+
+	// DisableDetector disables a detector.
+	DisableDetector(ctx context.Context, id string, labels []string) error
+
+	// EnableDetector enables a detector.
+	EnableDetector(ctx context.Context, id string, labels []string) error
+
+	// GetDetector gets a detector.
+	GetDetector(ctx context.Context, id string) (*detector.Detector, error)
+
+	// GetDetectors gets all detectors.
+	GetDetectors(ctx context.Context, limit int, name string, offset int) ([]*detector.Detector, error)
+
+	// UpdateDetector updates a detector.
+	UpdateDetector(ctx context.Context, id string, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error)
+
+	// SearchDetectors searches for detectors, given a query string in `name`.
+	SearchDetectors(ctx context.Context, limit int, name string, offset int, tags string) (*detector.SearchResults, error)
+
+	// GetDetectorEvents gets a detector's events.
+	GetDetectorEvents(ctx context.Context, id string, from int, to int, offset int, limit int) ([]*detector.Event, error)
+
+	// GetDetectorIncidents gets a detector's incidents.
+	GetDetectorIncidents(ctx context.Context, id string, offset int, limit int) ([]*detector.Incident, error)
+
+	// ValidateDetector validates a detector.
+	ValidateDetector(ctx context.Context, detectorRequest *detector.ValidateDetectorRequestModel) error
+
+	// EnsureDetector creates the detector of the request, or updates the
+	// detector matching the key if it differs from the request. It fails with
+	// ErrAmbiguousMatch if several detectors match.
+	EnsureDetector(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest, key EnsureKey) (*detector.Detector, EnsureOutcome, error)
+
+	// ModifyDetector reads a detector, applies mutate to its update request and
+	// writes it back unless the detector was updated meanwhile, in which case the
+	// cycle is retried. mutate may thus run several times, each time with a
+	// fresh request. An error returned by mutate aborts the modification.
+	ModifyDetector(ctx context.Context, id string, mutate func(req *detector.CreateUpdateDetectorRequest) error) (*detector.Detector, error)
+
+	// AllDetectors iterates over every detector matching the filter's Name and
+	// Tags.
+	AllDetectors(ctx context.Context, filter SearchFilter) iter.Seq2[*detector.Detector, error]
+}
+
+// EmailTemplateAPI contains the methods of Client for email templates.
+type EmailTemplateAPI interface {
+	// CreateEmailTemplate creates an email template.
+	CreateEmailTemplate(ctx context.Context, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error)
+
+	// GetEmailTemplate gets an email template by ID.
+	GetEmailTemplate(ctx context.Context, id string) (*emailtemplate.EmailTemplate, error)
+
+	// UpdateEmailTemplate updates an email template.
+	UpdateEmailTemplate(ctx context.Context, id string, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error)
+
+	// DeleteEmailTemplate deletes an email template.
+	DeleteEmailTemplate(ctx context.Context, id string) error
+
+	// SearchEmailTemplates searches email templates by name with pagination.
+	SearchEmailTemplates(ctx context.Context, limit int, name string, offset int, orderBy string) (*emailtemplate.SearchResult, error)
+
+	// AllEmailTemplates iterates over every email template matching the filter's
+	// Name and OrderBy.
+	AllEmailTemplates(ctx context.Context, filter SearchFilter) iter.Seq2[*emailtemplate.EmailTemplate, error]
+}
+
+// IncidentAPI contains the methods of Client for incidents.
+type IncidentAPI interface {
+	// Get incident with the given id
+	GetIncident(ctx context.Context, id string) (*detector.Incident, error)
+
+	// Get all incidents
+	GetIncidents(ctx context.Context, includeResolved bool, limit int, query string, offset int) ([]*detector.Incident, error)
+}
+
+// IntegrationAPI contains the methods of Client for integrations.
+type IntegrationAPI interface {
+	// CreateAWSCloudWatchIntegration creates an AWS CloudWatch integration.
+	CreateAWSCloudWatchIntegration(ctx context.Context, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error)
+
+	// GetAWSCloudWatchIntegration retrieves an AWS CloudWatch integration.
+	GetAWSCloudWatchIntegration(ctx context.Context, id string) (*integration.AwsCloudWatchIntegration, error)
+
+	// UpdateAWSCloudWatchIntegration updates an AWS CloudWatch integration.
+	UpdateAWSCloudWatchIntegration(ctx context.Context, id string, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error)
+
+	// DeleteAWSCloudWatchIntegration deletes an AWS CloudWatch integration.
+	DeleteAWSCloudWatchIntegration(ctx context.Context, id string) error
+
+	// CreateAzureIntegration creates an Azure integration.
+	CreateAzureIntegration(ctx context.Context, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error)
+
+	// GetAzureIntegration retrieves an Azure integration.
+	GetAzureIntegration(ctx context.Context, id string) (*integration.AzureIntegration, error)
+
+	// UpdateAzureIntegration updates an Azure integration.
+	UpdateAzureIntegration(ctx context.Context, id string, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error)
+
+	// DeleteAzureIntegration deletes an Azure integration.
+	DeleteAzureIntegration(ctx context.Context, id string) error
+
+	// CreateBigPandaIntegration creates a BigPanda integration.
+	CreateBigPandaIntegration(ctx context.Context, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error)
+
+	// GetBigPandaIntegration retrieves a BigPanda integration.
+	GetBigPandaIntegration(ctx context.Context, id string) (*integration.BigPandaIntegration, error)
+
+	// UpdateBigPandaIntegration updates a BigPanda integration.
+	UpdateBigPandaIntegration(ctx context.Context, id string, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error)
+
+	// DeleteBigPandaIntegration deletes a BigPanda integration.
+	DeleteBigPandaIntegration(ctx context.Context, id string) error
+
+	// CreateGCPIntegration creates a GCP integration.
+	CreateGCPIntegration(ctx context.Context, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error)
+
+	// GetGCPIntegration retrieves a GCP integration.
+	GetGCPIntegration(ctx context.Context, id string) (*integration.GCPIntegration, error)
+
+	// UpdateGCPIntegration updates a GCP integration.
+	UpdateGCPIntegration(ctx context.Context, id string, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error)
+
+	// DeleteGCPIntegration deletes a GCP integration.
+	DeleteGCPIntegration(ctx context.Context, id string) error
+
+	// GetIntegration gets an integration as map.
+	GetIntegration(ctx context.Context, id string) (map[string]interface{}, error)
+
+	// SearchIntegrations searches for integrations given a query string in
+	// `name` and their `integrationType`. Integrations are returned as maps,
+	// since their fields depend on their type.
+	SearchIntegrations(ctx context.Context, limit int, name string, offset int, integrationType string) (*integration.SearchResults, error)
+
+	// DeleteIntegration deletes an integration.
+	DeleteIntegration(ctx context.Context, id string) error
+
+	// CreateJiraIntegration creates an Jira integration.
+	CreateJiraIntegration(ctx context.Context, ji *integration.JiraIntegration) (*integration.JiraIntegration, error)
+
+	// GetJiraIntegration retrieves an Jira integration.
+	GetJiraIntegration(ctx context.Context, id string) (*integration.JiraIntegration, error)
+
+	// UpdateJiraIntegration updates an Jira integration.
+	UpdateJiraIntegration(ctx context.Context, id string, ji *integration.JiraIntegration) (*integration.JiraIntegration, error)
+
+	// DeleteJiraIntegration deletes an Jira integration.
+	DeleteJiraIntegration(ctx context.Context, id string) error
+
+	// CreateOpsgenieIntegration creates an Opsgenie integration.
+	CreateOpsgenieIntegration(ctx context.Context, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error)
+
+	// GetOpsgenieIntegration retrieves an Opsgenie integration.
+	GetOpsgenieIntegration(ctx context.Context, id string) (*integration.OpsgenieIntegration, error)
+
+	// UpdateOpsgenieIntegration updates an Opsgenie integration.
+	UpdateOpsgenieIntegration(ctx context.Context, id string, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error)
+
+	// DeleteOpsgenieIntegration deletes an Opsgenie integration.
+	DeleteOpsgenieIntegration(ctx context.Context, id string) error
+
+	// CreatePagerDutyIntegration creates a PagerDuty integration.
+	CreatePagerDutyIntegration(ctx context.Context, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error)
+
+	// GetPagerDutyIntegration retrieves a PagerDuty integration.
+	GetPagerDutyIntegration(ctx context.Context, id string) (*integration.PagerDutyIntegration, error)
+
+	// GetPagerDutyIntegrationByName retrieves a PagerDuty integration by name.
+	GetPagerDutyIntegrationByName(ctx context.Context, name string) (*integration.PagerDutyIntegration, error)
+
+	// UpdatePagerDutyIntegration updates a PagerDuty integration.
+	UpdatePagerDutyIntegration(ctx context.Context, id string, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error)
+
+	// DeletePagerDutyIntegration deletes a PagerDuty integration.
+	DeletePagerDutyIntegration(ctx context.Context, id string) error
+
+	// AllIntegrations iterates over every integration matching the filter's Name
+	// and Type.
+	AllIntegrations(ctx context.Context, filter SearchFilter) iter.Seq2[map[string]interface{}, error]
+
+	// CreateServiceNowIntegration creates SNOW integration.
+	CreateServiceNowIntegration(ctx context.Context, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error)
+
+	// GetServiceNowIntegration retrieves SNOW integration.
+	GetServiceNowIntegration(ctx context.Context, id string) (*integration.ServiceNowIntegration, error)
+
+	// UpdateServiceNowIntegration updates SNOW integration.
+	UpdateServiceNowIntegration(ctx context.Context, id string, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error)
+
+	// DeleteServiceNowIntegration deletes SNOW integration.
+	DeleteServiceNowIntegration(ctx context.Context, id string) error
+
+	// CreateSlackIntegration creates a Slack integration.
+	CreateSlackIntegration(ctx context.Context, si *integration.SlackIntegration) (*integration.SlackIntegration, error)
+
+	// GetSlackIntegration retrieves a Slack integration.
+	GetSlackIntegration(ctx context.Context, id string) (*integration.SlackIntegration, error)
+
+	// UpdateSlackIntegration updates a Slack integration.
+	UpdateSlackIntegration(ctx context.Context, id string, si *integration.SlackIntegration) (*integration.SlackIntegration, error)
+
+	// DeleteSlackIntegration deletes a Slack integration.
+	DeleteSlackIntegration(ctx context.Context, id string) error
+
+	// CreateVictorOpsIntegration creates an VictorOps integration.
+	CreateVictorOpsIntegration(ctx context.Context, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error)
+
+	// GetVictorOpsIntegration retrieves an VictorOps integration.
+	GetVictorOpsIntegration(ctx context.Context, id string) (*integration.VictorOpsIntegration, error)
+
+	// UpdateVictorOpsIntegration updates an VictorOps integration.
+	UpdateVictorOpsIntegration(ctx context.Context, id string, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error)
+
+	// DeleteVictorOpsIntegration deletes an VictorOps integration.
+	DeleteVictorOpsIntegration(ctx context.Context, id string) error
+
+	// CreateWebhookIntegration creates an Webhook integration.
+	CreateWebhookIntegration(ctx context.Context, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error)
+
+	// GetWebhookIntegration retrieves an Webhook integration.
+	GetWebhookIntegration(ctx context.Context, id string) (*integration.WebhookIntegration, error)
+
+	// UpdateWebhookIntegration updates an Webhook integration.
+	UpdateWebhookIntegration(ctx context.Context, id string, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error)
+
+	// DeleteWebhookIntegration deletes an Webhook integration.
+	DeleteWebhookIntegration(ctx context.Context, id string) error
+}
+
+// MetricRulesetAPI contains the methods of Client for metric rulesets.
+type MetricRulesetAPI interface {
+	// GetMetricRuleset gets a metric ruleset.
+	GetMetricRuleset(ctx context.Context, id string) (*metric_ruleset.GetMetricRulesetResponse, error)
+
+	// SearchMetricRulesets lists the metric rulesets.
+	SearchMetricRulesets(ctx context.Context, limit int, offset int) (*metric_ruleset.GetMetricRulesetsResponse, error)
+
+	// CreateMetricRuleset creates a metric ruleset.
+	CreateMetricRuleset(ctx context.Context, metricRuleset *metric_ruleset.CreateMetricRulesetRequest) (*metric_ruleset.CreateMetricRulesetResponse, error)
+
+	// UpdateMetricRuleset updates a metric ruleset.
+	UpdateMetricRuleset(ctx context.Context, id string, metricRuleset *metric_ruleset.UpdateMetricRulesetRequest) (*metric_ruleset.UpdateMetricRulesetResponse, error)
+
+	// DeleteMetricRuleset deletes a metric ruleset.
+	DeleteMetricRuleset(ctx context.Context, id string) error
+
+	GenerateAggregationMetricName(ctx context.Context, generateAggregationNameRequest metric_ruleset.GenerateAggregationNameRequest) (string, error)
+
+	// ModifyMetricRuleset is like ModifyDetector for metric rulesets, whose
+	// revisions are tracked by their version. The request carries the version
+	// read, so the API also rejects the update if the ruleset changed in
+	// between.
+	ModifyMetricRuleset(ctx context.Context, id string, mutate func(req *metric_ruleset.UpdateMetricRulesetRequest) error) (*metric_ruleset.UpdateMetricRulesetResponse, error)
+
+	// AllMetricRulesets iterates over every metric ruleset.
+	AllMetricRulesets(ctx context.Context, filter SearchFilter) iter.Seq2[*metric_ruleset.MetricRuleset, error]
+}
+
+// MetricsMetadataAPI contains the methods of Client for metrics, dimensions, tags and time series metadata.
+type MetricsMetadataAPI interface {
+	// GetDimension gets a dimension.
+	GetDimension(ctx context.Context, key string, value string) (*metrics_metadata.Dimension, error)
+
+	// UpdateDimension updates a dimension.
+	UpdateDimension(ctx context.Context, key string, value string, dim *metrics_metadata.Dimension) (*metrics_metadata.Dimension, error)
+
+	// SearchDimension searches for dimensions, given a query string in `query`.
+	SearchDimension(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.DimensionQueryResponseModel, error)
+
+	// SearchMetric searches for metrics, given a query string in `query`.
+	SearchMetric(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.RetrieveMetricMetadataResponseModel, error)
+
+	// GetMetric retrieves a single metric by name.
+	GetMetric(ctx context.Context, name string) (*metrics_metadata.Metric, error)
+
+	// UpdateMetric creates or updates a metric
+	CreateUpdateMetric(ctx context.Context, name string, cumr *metrics_metadata.CreateUpdateMetricRequest) (*metrics_metadata.Metric, error)
+
+	// GetMetricTimeSeries retrieves a metric time series by id.
+	GetMetricTimeSeries(ctx context.Context, id string) (*metrics_metadata.MetricTimeSeries, error)
+
+	// SearchMetricTimeSeries searches for metric time series, given a query string in `query`.
+	SearchMetricTimeSeries(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.MetricTimeSeriesRetrieveResponseModel, error)
+
+	// SearchTag searches for tags, given a query string in `query`.
+	SearchTag(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.TagRetrieveResponseModel, error)
+
+	// GetTag gets a tag by name
+	GetTag(ctx context.Context, name string) (*metrics_metadata.Tag, error)
+
+	// DeleteTag deletes a tag.
+	DeleteTag(ctx context.Context, id string) error
+
+	// CreateUpdateTag creates or updates a dimension.
+	CreateUpdateTag(ctx context.Context, name string, cutr *metrics_metadata.CreateUpdateTagRequest) (*metrics_metadata.Tag, error)
+
+	// AllDimensions iterates over every dimension matching the filter's Query.
+	// Results past MaxSearchOffset are reached through their creation time
+	// unless another OrderBy is set.
+	AllDimensions(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Dimension, error]
+
+	// AllMetrics iterates over every metric matching the filter's Query.
+	// Results past MaxSearchOffset are reached through their creation time
+	// unless another OrderBy is set.
+	AllMetrics(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Metric, error]
+
+	// AllMetricTimeSeries iterates over every metric time series matching the
+	// filter's Query. Results past MaxSearchOffset are reached through their
+	// creation time unless another OrderBy is set.
+	AllMetricTimeSeries(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.MetricTimeSeries, error]
+
+	// AllTags iterates over every tag matching the filter's Query. Results past
+	// MaxSearchOffset are reached through their creation time unless another
+	// OrderBy is set.
+	AllTags(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Tag, error]
+}
+
+// NavigatorAPI contains the methods of Client for navigators.
+type NavigatorAPI interface {
+	// CreateNavigator creates a navigator.
+	CreateNavigator(ctx context.Context, navigatorRequest *navigator.CreateNavigatorRequest) (*navigator.Navigator, error)
+
+	// DeleteNavigator deletes a navigator.
+	DeleteNavigator(ctx context.Context, id string) error
+
+	// GetNavigator gets a navigator.
+	GetNavigator(ctx context.Context, id string) (*navigator.Navigator, error)
+
+	// UpdateNavigator updates a navigator.
+	UpdateNavigator(ctx context.Context, id string, navigatorRequest *navigator.UpdateNavigatorRequest) (*navigator.Navigator, error)
+
+	// SearchNavigators searches for navigators given a query string in `name`.
+	SearchNavigators(ctx context.Context, limit int, name string, offset int) (*navigator.GetNavigatorsResponse, error)
+
+	// AllNavigators iterates over every navigator matching the filter's Name.
+	AllNavigators(ctx context.Context, filter SearchFilter) iter.Seq2[*navigator.GetNavigatorsResult, error]
+}
+
+// OrganizationAPI contains the methods of Client for the organization and its members.
+type OrganizationAPI interface {
+	// GetOrganization gets an organization associated with the token in use by the client.
+	GetOrganization(ctx context.Context, arg1 string) (*organization.Organization, error)
+
+	// GetMember gets a member.
+	GetMember(ctx context.Context, id string) (*organization.Member, error)
+
+	// DeleteMember deletes a detector.
+	DeleteMember(ctx context.Context, id string) error
+
+	// InviteMember invites a member to the organization.
+	InviteMember(ctx context.Context, inviteRequest *organization.CreateUpdateMemberRequest) (*organization.Member, error)
+
+	// Updates admin status of a member.
+	UpdateMember(ctx context.Context, id string, updateRequest *organization.UpdateMemberRequest) (*organization.Member, error)
+
+	// InviteMembers invites many members to the organization.
+	InviteMembers(ctx context.Context, inviteRequest *organization.InviteMembersRequest) (*organization.InviteMembersRequest, error)
+
+	// GetOrganizationMembers gets members for an org, with an optional search.
+	GetOrganizationMembers(ctx context.Context, limit int, query string, offset int, orderBy string) (*organization.MemberSearchResults, error)
+
+	// AllOrganizationMembers iterates over every organization member matching
+	// the filter's Query and OrderBy.
+	AllOrganizationMembers(ctx context.Context, filter SearchFilter) iter.Seq2[*organization.Member, error]
+}
+
+// OrgTokenAPI contains the methods of Client for org tokens.
+type OrgTokenAPI interface {
+	// CreateOrgToken creates a org token.
+	CreateOrgToken(ctx context.Context, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error)
+
+	// DeleteOrgToken deletes a token.
+	DeleteOrgToken(ctx context.Context, name string) error
+
+	// GetOrgToken gets a token.
+	GetOrgToken(ctx context.Context, id string) (*orgtoken.Token, error)
+
+	// UpdateOrgToken updates a token.
+	UpdateOrgToken(ctx context.Context, id string, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error)
+
+	// SearchOrgTokens searches for tokens given a query string in `name`.
+	SearchOrgTokens(ctx context.Context, limit int, name string, offset int) (*orgtoken.SearchResults, error)
+
+	// AllOrgTokens iterates over every org token matching the filter's Name.
+	AllOrgTokens(ctx context.Context, filter SearchFilter) iter.Seq2[*orgtoken.Token, error]
+}
+
+// SessionTokenAPI contains the methods of Client for session tokens.
+type SessionTokenAPI interface {
+	// CreateOrgToken creates a org token.
+	CreateSessionToken(ctx context.Context, tokenRequest *sessiontoken.CreateTokenRequest) (*sessiontoken.Token, error)
+
+	// DeleteOrgToken deletes a token.
+	DeleteSessionToken(ctx context.Context, token string) error
+}
+
+// SLOAPI contains the methods of Client for SLOs.
+type SLOAPI interface {
+	// AllSlos iterates over every SLO matching the filter's Name.
+	AllSlos(ctx context.Context, filter SearchFilter) iter.Seq2[*slo.SloObject, error]
+
+	GetSlo(ctx context.Context, id string) (*slo.SloObject, error)
+
+	CreateSlo(ctx context.Context, sloRequest *slo.SloObject) (*slo.SloObject, error)
+
+	ValidateSlo(ctx context.Context, sloRequest *slo.SloObject) error
+
+	UpdateSlo(ctx context.Context, id string, sloRequest *slo.SloObject) (*slo.SloObject, error)
+
+	DeleteSlo(ctx context.Context, id string) error
+
+	// SearchSlos searches for SLOs given a query string in `name`.
+	SearchSlos(ctx context.Context, limit int, name string, offset int) (*slo.SearchResults, error)
+}
+
+// TeamAPI contains the methods of Client for teams.
+type TeamAPI interface {
+	// EnsureTeam creates the team of the request, or updates the team with the
+	// same name if it differs from the request.
+	EnsureTeam(ctx context.Context, teamRequest *team.CreateUpdateTeamRequest) (*team.Team, EnsureOutcome, error)
+
+	// ModifyTeam is like ModifyDetector for teams. Teams have no `lastUpdated`
+	// time, so any difference in the team counts as a concurrent change.
+	ModifyTeam(ctx context.Context, id string, mutate func(req *team.CreateUpdateTeamRequest) error) (*team.Team, error)
+
+	// AllTeams iterates over every team matching the filter's Name and Tags.
+	AllTeams(ctx context.Context, filter SearchFilter) iter.Seq2[*team.Team, error]
+
+	// CreateTeam creates a team.
+	CreateTeam(ctx context.Context, t *team.CreateUpdateTeamRequest) (*team.Team, error)
+
+	// DeleteTeam deletes a team.
+	DeleteTeam(ctx context.Context, id string) error
+
+	// GetTeam gets a team.
+	GetTeam(ctx context.Context, id string) (*team.Team, error)
+
+	// UpdateTeam updates a team.
+	UpdateTeam(ctx context.Context, id string, t *team.CreateUpdateTeamRequest) (*team.Team, error)
+
+	// SearchTeam searches for teams, given a query string in `name`.
+	SearchTeam(ctx context.Context, limit int, name string, offset int, tags string) (*team.SearchResults, error)
+
+	// LinkDetectorToTeam links a detector to a team.
+	LinkDetectorToTeam(ctx context.Context, id string, detectorID string) error
+
+	// UnLinkDetectorFromTeam unlinks a detector from a team.
+	UnlinkDetectorFromTeam(ctx context.Context, id string, detectorID string) error
+
+	// LinkDashboardGroupToTeam links a dashboard group to a team.
+	LinkDashboardGroupToTeam(ctx context.Context, id string, dashboardGroupID string) error
+
+	// UnlinkDashboardGroupFromTeam unlinks a dashboard group from a team.
+	UnlinkDashboardGroupFromTeam(ctx context.Context, id string, dashboardGroupID string) error
+}
+
+// API contains every method of Client calling the API.
+type API interface {
+	AlertMutingRuleAPI
+	APMAPI
+	AutomatedArchivalAPI
+	ChartAPI
+	DashboardAPI
+	DashboardGroupAPI
+	DataLinkAPI
+	DetectorAPI
+	EmailTemplateAPI
+	IncidentAPI
+	IntegrationAPI
+	MetricRulesetAPI
+	MetricsMetadataAPI
+	NavigatorAPI
+	OrganizationAPI
+	OrgTokenAPI
+	SessionTokenAPI
+	SLOAPI
+	TeamAPI
+}
+
+var _ API = (*Client)(nil)
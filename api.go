@@ -0,0 +1,13 @@
+package signalfx
+
+// The interfaces of api.gen.go list the methods of Client by resource, so
+// that code using only some of them can depend on an interface and be tested
+// with the mock of the signalfxmock package. Methods added to Client must be
+// assigned to an interface in internal/cmd/apigen.
+
+//go:generate go run ./internal/cmd/apigen
+
+// The ToUpdateRequest methods converting the models to their update requests
+// are generated in the model packages by internal/cmd/requestgen.
+
+//go:generate go run ./internal/cmd/requestgen
@@ -0,0 +1,357 @@
+// Package bundle exports the objects of an organization to a directory and
+// imports them into another organization, for backups and migrations
+// between organizations.
+//
+// A bundle is a directory holding a `bundle.json` file, which records the
+// version of its format, and a subdirectory per kind of object with one file
+// per object, named after its ID:
+//
+//	bundle.json
+//	charts/EXAMPLEAAAA.json
+//	dashboards/EXAMPLEBAAA.json
+//	detectors/EXAMPLECAAA.json
+//
+// Objects are written as indented JSON with sorted keys and without the
+// fields changing on every update, so that a bundle kept in version control
+// shows what changed between two exports. The secrets of integrations are
+// redacted.
+//
+// Import creates the objects of a bundle in dependency order and rewrites
+// the IDs they refer to, such as the charts of dashboards, the teams of
+// detectors and the integrations of notifications, through a Mapping from
+// the IDs of the exported objects to the IDs of their copies. Integrations
+// are not created, since their secrets are not exported: each one is mapped
+// to the integration of the target organization with the same type and
+// name.
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// Version is the version of the format of the bundles written by Export.
+const Version = 1
+
+// Redacted replaces the secrets of exported integrations.
+const Redacted = "<redacted>"
+
+// Client is the part of the API used to export and import the objects,
+// implemented by *signalfx.Client.
+type Client interface {
+	signalfx.TeamAPI
+	signalfx.IntegrationAPI
+	signalfx.SLOAPI
+	signalfx.MetricRulesetAPI
+	signalfx.DashboardGroupAPI
+	signalfx.ChartAPI
+	signalfx.DashboardAPI
+	signalfx.NavigatorAPI
+	signalfx.DetectorAPI
+	signalfx.AlertMutingRuleAPI
+	signalfx.DataLinkAPI
+}
+
+// SecretFields are the fields of integrations, at any depth, whose values
+// are redacted.
+var SecretFields = []string{
+	"accessToken",
+	"apiKey",
+	"apiToken",
+	"appKey",
+	"hecToken",
+	"key",
+	"password",
+	"postUrl",
+	"projectKey",
+	"secret",
+	"secretKey",
+	"sharedSecret",
+	"token",
+	"webhookUrl",
+}
+
+// kind is a kind of object of a bundle.
+type kind struct {
+	// dir is the subdirectory of the objects in a bundle.
+	dir string
+	// list returns the objects of the organization.
+	list func(ctx context.Context, c Client) iter.Seq2[any, error]
+	// restore returns the ID of the copy of an object read from a bundle,
+	// without its read-only fields and with its references rewritten. It
+	// returns an empty ID for objects that can not be copied.
+	restore func(ctx context.Context, c Client, data []byte) (string, error)
+	// drop are the fields not carried over to the copies, usually because
+	// the API derives them from other objects.
+	drop []string
+	// secret is set for the kinds whose SecretFields are redacted.
+	secret bool
+}
+
+// kinds are the kinds of objects of a bundle, in the order they are
+// imported.
+var kinds = []kind{
+	// Integrations come first since the notification lists of teams refer
+	// to them.
+	{
+		dir:     "integrations",
+		list:    objects(Client.AllIntegrations),
+		restore: findIntegration,
+		secret:  true,
+	},
+	{
+		dir:     "teams",
+		list:    objects(Client.AllTeams),
+		restore: create((*team.Team).ToUpdateRequest, Client.CreateTeam, func(t *team.Team) string { return t.Id }),
+		drop:    []string{"dashboardGroups", "detectors"},
+	},
+	{
+		dir:  "slos",
+		list: objects(Client.AllSlos),
+		restore: create(func(s *slo.SloObject) *slo.SloObject { return s }, Client.CreateSlo,
+			func(s *slo.SloObject) string { return s.Id }),
+	},
+	{
+		dir: "metric-rulesets",
+		list: details(Client.AllMetricRulesets, (*metric_ruleset.MetricRuleset).GetId,
+			Client.GetMetricRuleset),
+		restore: create(metricRulesetRequest, Client.CreateMetricRuleset, (*metric_ruleset.CreateMetricRulesetResponse).GetId),
+	},
+	{
+		dir:  "dashboard-groups",
+		list: objects(Client.AllDashboardGroups),
+		restore: create((*dashboard_group.DashboardGroup).ToUpdateRequest,
+			func(c Client, ctx context.Context, req *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error) {
+				// The dashboards are imported on their own.
+				return c.CreateDashboardGroup(ctx, req, true)
+			},
+			func(g *dashboard_group.DashboardGroup) string { return g.Id }),
+		drop: []string{"dashboards", "dashboardConfigs"},
+	},
+	{
+		dir:     "charts",
+		list:    objects(Client.AllCharts),
+		restore: restoreChart,
+	},
+	{
+		dir:     "dashboards",
+		list:    objects(Client.AllDashboards),
+		restore: create((*dashboard.Dashboard).ToUpdateRequest, Client.CreateDashboard, func(d *dashboard.Dashboard) string { return d.Id }),
+	},
+	{
+		dir:     "navigators",
+		list:    details(Client.AllNavigators, (*navigator.GetNavigatorsResult).GetId, Client.GetNavigator),
+		restore: create(navigatorRequest, Client.CreateNavigator, (*navigator.Navigator).GetId),
+	},
+	{
+		dir:     "detectors",
+		list:    objects(Client.AllDetectors),
+		restore: create((*detector.Detector).ToUpdateRequest, Client.CreateDetector, func(d *detector.Detector) string { return d.Id }),
+	},
+	{
+		dir:     "alert-muting-rules",
+		list:    objects(Client.AllAlertMutingRules),
+		restore: create((*alertmuting.AlertMutingRule).ToUpdateRequest, Client.CreateAlertMutingRule, func(r *alertmuting.AlertMutingRule) string { return r.Id }),
+	},
+	{
+		dir:     "data-links",
+		list:    objects(Client.AllDataLinks),
+		restore: create((*datalink.DataLink).ToUpdateRequest, Client.CreateDataLink, func(l *datalink.DataLink) string { return l.Id }),
+	},
+}
+
+// readOnly are the fields set by the API, which are not carried over to
+// the copies.
+var readOnly = []string{"id", "created", "creator", "lastUpdated", "lastUpdatedBy"}
+
+// volatile are the fields left out of exported objects, since they change
+// on every update.
+var volatile = []string{"lastUpdated", "lastUpdatedBy", "lastUpdatedByName"}
+
+// objects lists the objects of an `All*` iterator of the client.
+func objects[T any](all func(Client, context.Context, signalfx.SearchFilter) iter.Seq2[T, error]) func(context.Context, Client) iter.Seq2[any, error] {
+	return func(ctx context.Context, c Client) iter.Seq2[any, error] {
+		return func(yield func(any, error) bool) {
+			for obj, err := range all(c, ctx, signalfx.SearchFilter{}) {
+				if !yield(obj, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// details lists the objects of an `All*` iterator returning summaries,
+// getting each object in full.
+func details[S, T any](all func(Client, context.Context, signalfx.SearchFilter) iter.Seq2[S, error], id func(S) string, get func(Client, context.Context, string) (T, error)) func(context.Context, Client) iter.Seq2[any, error] {
+	return func(ctx context.Context, c Client) iter.Seq2[any, error] {
+		return func(yield func(any, error) bool) {
+			for summary, err := range all(c, ctx, signalfx.SearchFilter{}) {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				obj, err := get(c, ctx, id(summary))
+				if !yield(obj, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// create returns the restore function of a kind created from a Req, the
+// request of its Obj, and returned as a Res.
+func create[Obj, Req, Res any](request func(*Obj) *Req, create func(Client, context.Context, *Req) (*Res, error), id func(*Res) string) func(context.Context, Client, []byte) (string, error) {
+	return func(ctx context.Context, c Client, data []byte) (string, error) {
+		obj := new(Obj)
+		if err := json.Unmarshal(data, obj); err != nil {
+			return "", err
+		}
+		created, err := create(c, ctx, request(obj))
+		if err != nil {
+			return "", err
+		}
+		return id(created), nil
+	}
+}
+
+// metricRulesetRequest returns the request creating a copy of a metric
+// ruleset.
+func metricRulesetRequest(r *metric_ruleset.GetMetricRulesetResponse) *metric_ruleset.CreateMetricRulesetRequest {
+	req := r.ToUpdateRequest()
+	return &metric_ruleset.CreateMetricRulesetRequest{
+		AggregationRules: req.AggregationRules,
+		ExceptionRules:   req.ExceptionRules,
+		MetricName:       req.GetMetricName(),
+		Description:      req.Description,
+		RoutingRule:      req.GetRoutingRule(),
+		Version:          req.GetVersion(),
+	}
+}
+
+// navigatorRequest returns the request creating a copy of a navigator.
+func navigatorRequest(n *navigator.Navigator) *navigator.CreateNavigatorRequest {
+	req := n.ToUpdateRequest()
+	return &navigator.CreateNavigatorRequest{
+		AlertQuery:                 req.AlertQuery,
+		Categories:                 req.Categories,
+		DefaultGroupBy:             req.DefaultGroupBy,
+		DisplayName:                req.GetDisplayName(),
+		EntityMetrics:              req.EntityMetrics,
+		IdDisplayName:              req.IdDisplayName,
+		InstanceDashboards:         req.InstanceDashboards,
+		InstanceDisplayText:        req.InstanceDisplayText,
+		InstanceLabel:              req.InstanceLabel,
+		ListColumns:                req.ListColumns,
+		PropertyIdentifierTemplate: req.GetPropertyIdentifierTemplate(),
+		RequiredProperties:         req.RequiredProperties,
+		SystemTypes:                req.SystemTypes,
+		SummaryMetricLabel:         req.SummaryMetricLabel,
+		SummaryMetricProgramText:   req.SummaryMetricProgramText,
+		TooltipKeyList:             req.TooltipKeyList,
+	}
+}
+
+// restoreChart creates a chart, through the endpoint of SLO charts for the
+// charts of an SLO.
+func restoreChart(ctx context.Context, c Client, data []byte) (string, error) {
+	ch := &chart.Chart{}
+	if err := json.Unmarshal(data, ch); err != nil {
+		return "", err
+	}
+	var (
+		created *chart.Chart
+		err     error
+	)
+	if ch.SloId != "" {
+		created, err = c.CreateSloChart(ctx, &chart.CreateUpdateSloChartRequest{SloId: ch.SloId})
+	} else {
+		created, err = c.CreateChart(ctx, ch.ToUpdateRequest())
+	}
+	if err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
+
+// findIntegration returns the ID of the integration of the organization
+// with the type and name of an exported one, or an empty ID if there is
+// none.
+func findIntegration(ctx context.Context, c Client, data []byte) (string, error) {
+	var exported struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		return "", err
+	}
+	filter := signalfx.SearchFilter{Name: exported.Name, Type: exported.Type}
+	for found, err := range c.AllIntegrations(ctx, filter) {
+		if err != nil {
+			return "", err
+		}
+		if found["name"] == exported.Name && found["type"] == exported.Type {
+			id, _ := found["id"].(string)
+			return id, nil
+		}
+	}
+	return "", nil
+}
+
+// redact replaces the values of the SecretFields of v, decoded from JSON.
+func redact(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, e := range v {
+			if slices.Contains(SecretFields, key) {
+				if e != nil && e != "" {
+					v[key] = Redacted
+				}
+				continue
+			}
+			redact(e)
+		}
+	case []any:
+		for _, e := range v {
+			redact(e)
+		}
+	}
+}
+
+// decode returns the fields of an object returned by the client.
+func decode(obj any) (map[string]any, error) {
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// objectID returns the ID of an object decoded from JSON.
+func objectID(fields map[string]any) (string, error) {
+	id, _ := fields["id"].(string)
+	if id == "" {
+		return "", errors.New("object without an ID")
+	}
+	return id, nil
+}
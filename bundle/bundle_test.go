@@ -0,0 +1,333 @@
+package bundle_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/bundle"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrg(t *testing.T) (*signalfxtest.Server, *signalfx.Client) {
+	fake := signalfxtest.NewServer()
+	t.Cleanup(fake.Close)
+	c, err := signalfx.NewClient("token", signalfx.APIUrl(fake.URL))
+	require.NoError(t, err, "Must create the client")
+	return fake, c
+}
+
+func createPagerDuty(t *testing.T, c *signalfx.Client, apiKey string) string {
+	in, err := c.CreatePagerDutyIntegration(context.Background(), &integration.PagerDutyIntegration{
+		Name:    "on call",
+		Type:    "PagerDuty",
+		Enabled: true,
+		ApiKey:  apiKey,
+	})
+	require.NoError(t, err, "Must create the integration")
+	return in.Id
+}
+
+// source holds the IDs of the objects of the exported organization.
+type source struct {
+	team, integration, slo, ruleset, group, chart, sloChart, dashboard, navigator, detector, rule, link string
+}
+
+// populate creates an object of every kind, referring to each other.
+func populate(t *testing.T, c *signalfx.Client) source {
+	ctx := context.Background()
+	var s source
+
+	tm, err := c.CreateTeam(ctx, &team.CreateUpdateTeamRequest{Name: "Payments", Members: []string{"USER"}})
+	require.NoError(t, err, "Must create the team")
+	s.team = tm.Id
+	s.integration = createPagerDuty(t, c, "s3cr3t")
+
+	objective, err := c.CreateSlo(ctx, &slo.SloObject{
+		BaseSlo:         slo.BaseSlo{Name: "Availability", Type: slo.RequestBased},
+		RequestBasedSlo: &slo.RequestBasedSlo{Inputs: &slo.RequestBasedSloInput{ProgramText: "G = data('good')"}},
+	})
+	require.NoError(t, err, "Must create the SLO")
+	s.slo = objective.Id
+
+	destination := "Archived"
+	ruleset, err := c.CreateMetricRuleset(ctx, &metric_ruleset.CreateMetricRulesetRequest{
+		MetricName:  "cpu",
+		RoutingRule: metric_ruleset.RoutingRule{Destination: &destination},
+	})
+	require.NoError(t, err, "Must create the metric ruleset")
+	s.ruleset = ruleset.GetId()
+
+	group, err := c.CreateDashboardGroup(ctx, &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "Payments", Teams: []string{s.team}}, true)
+	require.NoError(t, err, "Must create the dashboard group")
+	s.group = group.Id
+
+	ch, err := c.CreateChart(ctx, &chart.CreateUpdateChartRequest{Name: "Latency", ProgramText: "data('latency').publish()"})
+	require.NoError(t, err, "Must create the chart")
+	s.chart = ch.Id
+	sloChart, err := c.CreateSloChart(ctx, &chart.CreateUpdateSloChartRequest{SloId: s.slo})
+	require.NoError(t, err, "Must create the SLO chart")
+	s.sloChart = sloChart.Id
+
+	dash, err := c.CreateDashboard(ctx, &dashboard.CreateUpdateDashboardRequest{
+		Name:    "Overview",
+		GroupId: s.group,
+		Charts:  []*dashboard.DashboardChart{{ChartId: s.chart, Width: 6, Height: 1}, {ChartId: s.sloChart, Width: 6, Height: 1}},
+	})
+	require.NoError(t, err, "Must create the dashboard")
+	s.dashboard = dash.Id
+
+	nav, err := c.CreateNavigator(ctx, &navigator.CreateNavigatorRequest{
+		DisplayName:                "Hosts",
+		Categories:                 []navigator.Category{},
+		EntityMetrics:              []navigator.Metric{},
+		PropertyIdentifierTemplate: "{host}",
+		RequiredProperties:         []string{"host"},
+		InstanceDashboards:         []string{s.dashboard},
+	})
+	require.NoError(t, err, "Must create the navigator")
+	s.navigator = nav.GetId()
+
+	det, err := c.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{
+		Name:        "CPU",
+		ProgramText: "detect(when(data('cpu') > 90)).publish('CPU')",
+		Teams:       []string{s.team},
+		Rules: []*detector.Rule{{
+			DetectLabel: "CPU",
+			Severity:    detector.CRITICAL,
+			Notifications: []*notification.Notification{
+				{Type: "PagerDuty", Value: &notification.PagerDutyNotification{Type: "PagerDuty", CredentialId: s.integration}},
+				{Type: "Team", Value: &notification.TeamNotification{Type: "Team", Team: s.team}},
+			},
+		}},
+	})
+	require.NoError(t, err, "Must create the detector")
+	s.detector = det.Id
+
+	rule, err := c.CreateAlertMutingRule(ctx, &alertmuting.CreateUpdateAlertMutingRuleRequest{
+		Description: "maintenance",
+		Filters: []*alertmuting.AlertMutingRuleFilter{
+			{Property: "sf_detectorId", PropertyValue: alertmuting.StringOrArray{Values: []string{s.detector}}},
+			{Property: "host", PropertyValue: alertmuting.StringOrArray{Values: []string{"db-1"}}},
+		},
+	})
+	require.NoError(t, err, "Must create the alert muting rule")
+	s.rule = rule.Id
+
+	link, err := c.CreateDataLink(ctx, &datalink.CreateUpdateDataLinkRequest{
+		PropertyName: "host",
+		Targets:      []*datalink.Target{{Type: datalink.INTERNAL_LINK, Name: "Overview", DashboardId: s.dashboard, DashboardGroupId: s.group}},
+	})
+	require.NoError(t, err, "Must create the data link")
+	s.link = link.Id
+
+	return s
+}
+
+func readObject(t *testing.T, dir, kind, id string) map[string]any {
+	content, err := os.ReadFile(filepath.Join(dir, kind, id+".json"))
+	require.NoError(t, err, "Must export %s %s", kind, id)
+	fields := map[string]any{}
+	require.NoError(t, json.Unmarshal(content, &fields), "Must write %s %s as JSON", kind, id)
+	return fields
+}
+
+// snapshot returns the content of the files of a directory.
+func snapshot(t *testing.T, dir string) map[string]string {
+	files := map[string]string{}
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		files[path] = string(content)
+		return err
+	}), "Must read the bundle")
+	return files
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srcFake, src := newOrg(t)
+	s := populate(t, src)
+
+	dir := t.TempDir()
+	require.NoError(t, bundle.Export(ctx, src, dir), "Must export the organization")
+	assert.Len(t, snapshot(t, dir), 13, "Must write one file per object and the manifest")
+
+	in := readObject(t, dir, "integrations", s.integration)
+	assert.Equal(t, bundle.Redacted, in["apiKey"], "Must redact secrets")
+	assert.Equal(t, "on call", in["name"], "Must keep the other fields")
+	assert.NotContains(t, readObject(t, dir, "detectors", s.detector), "lastUpdated", "Must leave out volatile fields")
+
+	// Updates that change nothing leave the bundle as is, and deleted
+	// objects are removed from it.
+	exported := snapshot(t, dir)
+	_, err := src.UpdateChart(ctx, s.chart, &chart.CreateUpdateChartRequest{Name: "Latency", ProgramText: "data('latency').publish()"})
+	require.NoError(t, err, "Must update the chart")
+	extra, err := src.CreateChart(ctx, &chart.CreateUpdateChartRequest{Name: "Extra"})
+	require.NoError(t, err, "Must create the chart")
+	require.NoError(t, bundle.Export(ctx, src, dir), "Must export the organization again")
+	require.NoError(t, src.DeleteChart(ctx, extra.Id), "Must delete the chart")
+	require.NoError(t, bundle.Export(ctx, src, dir), "Must export the organization again")
+	assert.Equal(t, exported, snapshot(t, dir), "Must export unchanged objects identically")
+
+	dstFake, dst := newOrg(t)
+	pagerDuty := createPagerDuty(t, dst, "other")
+	mapping := bundle.Mapping{}
+	require.NoError(t, bundle.Import(ctx, dst, dir, mapping), "Must import the bundle")
+	assert.Len(t, mapping, 12, "Must map every object")
+	assert.Equal(t, pagerDuty, mapping[s.integration], "Must map integrations by type and name")
+
+	tm, err := dst.GetTeam(ctx, mapping[s.team])
+	require.NoError(t, err, "Must copy the team")
+	assert.Empty(t, tm.Members, "Must drop the users that are not mapped")
+	group, err := dst.GetDashboardGroup(ctx, mapping[s.group])
+	require.NoError(t, err, "Must copy the dashboard group")
+	assert.Equal(t, []string{mapping[s.team]}, group.Teams, "Must rewrite the teams of dashboard groups")
+	assert.Equal(t, []string{mapping[s.dashboard]}, group.Dashboards, "Must only add the copied dashboards to groups")
+	sloChart, err := dst.GetChart(ctx, mapping[s.sloChart])
+	require.NoError(t, err, "Must copy the SLO chart")
+	assert.Equal(t, mapping[s.slo], sloChart.SloId, "Must rewrite the SLO of charts")
+
+	dash, err := dst.GetDashboard(ctx, mapping[s.dashboard])
+	require.NoError(t, err, "Must copy the dashboard")
+	assert.Equal(t, mapping[s.group], dash.GroupId, "Must rewrite the group of dashboards")
+	require.Len(t, dash.Charts, 2, "Must copy the charts of dashboards")
+	assert.Equal(t, mapping[s.chart], dash.Charts[0].ChartId, "Must rewrite the charts of dashboards")
+	assert.Equal(t, mapping[s.sloChart], dash.Charts[1].ChartId, "Must rewrite the charts of dashboards")
+	nav, err := dst.GetNavigator(ctx, mapping[s.navigator])
+	require.NoError(t, err, "Must copy the navigator")
+	assert.Equal(t, []string{mapping[s.dashboard]}, nav.InstanceDashboards, "Must rewrite the dashboards of navigators")
+
+	det, err := dst.GetDetector(ctx, mapping[s.detector])
+	require.NoError(t, err, "Must copy the detector")
+	assert.Equal(t, []string{mapping[s.team]}, det.Teams, "Must rewrite the teams of detectors")
+	notifications := det.Rules[0].Notifications
+	require.Len(t, notifications, 2, "Must copy the notifications")
+	assert.Equal(t, pagerDuty, notifications[0].Value.(*notification.PagerDutyNotification).CredentialId, "Must rewrite the integrations of notifications")
+	assert.Equal(t, mapping[s.team], notifications[1].Value.(*notification.TeamNotification).Team, "Must rewrite the teams of notifications")
+
+	rule, err := dst.GetAlertMutingRule(ctx, mapping[s.rule])
+	require.NoError(t, err, "Must copy the alert muting rule")
+	assert.Equal(t, []string{mapping[s.detector]}, rule.Filters[0].PropertyValue.Values, "Must rewrite the detectors of muting filters")
+	assert.Equal(t, []string{"db-1"}, rule.Filters[1].PropertyValue.Values, "Must keep the other filters")
+	link, err := dst.GetDataLink(ctx, mapping[s.link])
+	require.NoError(t, err, "Must copy the data link")
+	assert.Equal(t, mapping[s.dashboard], link.Targets[0].DashboardId, "Must rewrite the dashboards of data links")
+	assert.Equal(t, mapping[s.group], link.Targets[0].DashboardGroupId, "Must rewrite the groups of data links")
+
+	require.NoError(t, bundle.Import(ctx, dst, dir, mapping), "Must import the bundle again")
+	assert.Len(t, dstFake.Objects(signalfx.ChartAPIURL), 2, "Must skip the objects already mapped")
+	assert.Len(t, srcFake.Objects(signalfx.ChartAPIURL), 2, "Must not change the exported organization")
+}
+
+func TestExportSecretFields(t *testing.T) {
+	t.Parallel()
+
+	fake, c := newOrg(t)
+	req, err := http.NewRequest(http.MethodPost, fake.URL+signalfx.IntegrationAPIURL, strings.NewReader(
+		`{"name": "hooks", "type": "Webhook", "url": "https://example.com", "sharedSecret": "s1", "headers": {"secret": "s2"}, "oauth": {"accessToken": "s3"}}`))
+	require.NoError(t, err, "Must build the request")
+	req.Header.Set("X-SF-Token", "token")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Must create the integration")
+	var created struct{ ID string }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created), "Must return the integration")
+	resp.Body.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, bundle.Export(context.Background(), c, dir), "Must export the organization")
+	in := readObject(t, dir, "integrations", created.ID)
+	assert.Equal(t, bundle.Redacted, in["sharedSecret"], "Must redact the credentials of integrations")
+	assert.Equal(t, map[string]any{"secret": bundle.Redacted}, in["headers"], "Must redact secrets at any depth")
+	assert.Equal(t, map[string]any{"accessToken": bundle.Redacted}, in["oauth"], "Must redact access tokens")
+	assert.Equal(t, "https://example.com", in["url"], "Must keep the other fields")
+}
+
+func TestImportUnmappedIntegration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, src := newOrg(t)
+	s := populate(t, src)
+	dir := t.TempDir()
+	require.NoError(t, bundle.Export(ctx, src, dir), "Must export the organization")
+
+	_, dst := newOrg(t)
+	mappingPath := filepath.Join(t.TempDir(), "mapping.json")
+	mapping, err := bundle.LoadMapping(mappingPath)
+	require.NoError(t, err, "Must start from an empty mapping")
+	err = bundle.Import(ctx, dst, dir, mapping)
+	assert.ErrorContains(t, err, s.integration+" is not mapped", "Must reject references to integrations without a counterpart")
+	assert.Contains(t, mapping, s.dashboard, "Must map the objects imported before the failure")
+	assert.NotContains(t, mapping, s.detector, "Must not map the failed object")
+
+	// Mapping the integration by hand resumes the import.
+	mapping[s.integration] = createPagerDuty(t, dst, "other")
+	require.NoError(t, mapping.Save(mappingPath), "Must save the mapping")
+	mapping, err = bundle.LoadMapping(mappingPath)
+	require.NoError(t, err, "Must load the mapping")
+	require.NoError(t, bundle.Import(ctx, dst, dir, mapping), "Must resume the import")
+	assert.Len(t, mapping, 12, "Must map every object")
+}
+
+func TestImportTeamNotifications(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, src := newOrg(t)
+	integrationID := createPagerDuty(t, src, "s3cr3t")
+	tm, err := src.CreateTeam(ctx, &team.CreateUpdateTeamRequest{
+		Name: "Payments",
+		NotificationLists: team.NotificationLists{
+			Critical: []*notification.Notification{
+				{Type: "PagerDuty", Value: &notification.PagerDutyNotification{Type: "PagerDuty", CredentialId: integrationID}},
+			},
+		},
+	})
+	require.NoError(t, err, "Must create the team")
+	dir := t.TempDir()
+	require.NoError(t, bundle.Export(ctx, src, dir), "Must export the organization")
+
+	_, dst := newOrg(t)
+	pagerDuty := createPagerDuty(t, dst, "other")
+	mapping := bundle.Mapping{}
+	require.NoError(t, bundle.Import(ctx, dst, dir, mapping), "Must import teams notifying integrations")
+
+	copied, err := dst.GetTeam(ctx, mapping[tm.Id])
+	require.NoError(t, err, "Must copy the team")
+	require.Len(t, copied.NotificationLists.Critical, 1, "Must copy the notification lists of teams")
+	assert.Equal(t, pagerDuty, copied.NotificationLists.Critical[0].Value.(*notification.PagerDutyNotification).CredentialId, "Must rewrite the integrations of notification lists")
+}
+
+func TestImportVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bundle.json"), []byte(`{"version": 2}`), 0o644), "Must write the manifest")
+	err := bundle.Import(context.Background(), nil, dir, bundle.Mapping{})
+	assert.ErrorContains(t, err, "has version 2, expected 1", "Must reject other versions")
+}
@@ -0,0 +1,69 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// manifestFile records the version of a bundle.
+const manifestFile = "bundle.json"
+
+// manifest is the content of manifestFile.
+type manifest struct {
+	Version int `json:"version"`
+}
+
+// Export writes the objects of the organization to the bundle dir. The
+// objects of a previous export to dir are replaced, so that objects deleted
+// since are removed from the bundle.
+func Export(ctx context.Context, c Client, dir string) error {
+	for _, k := range kinds {
+		kindDir := filepath.Join(dir, k.dir)
+		if err := os.RemoveAll(kindDir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(kindDir, 0o755); err != nil {
+			return err
+		}
+		for obj, err := range k.list(ctx, c) {
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", k.dir, err)
+			}
+			if err := export(k, obj, kindDir); err != nil {
+				return fmt.Errorf("exporting %s: %w", k.dir, err)
+			}
+		}
+	}
+	return writeJSON(filepath.Join(dir, manifestFile), manifest{Version: Version})
+}
+
+// export writes an object to the directory of its kind.
+func export(k kind, obj any, kindDir string) error {
+	fields, err := decode(obj)
+	if err != nil {
+		return err
+	}
+	id, err := objectID(fields)
+	if err != nil {
+		return err
+	}
+	for _, f := range volatile {
+		delete(fields, f)
+	}
+	if k.secret {
+		redact(fields)
+	}
+	return writeJSON(filepath.Join(kindDir, id+".json"), fields)
+}
+
+// writeJSON writes v as indented JSON, whose maps have sorted keys.
+func writeJSON(path string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
@@ -0,0 +1,98 @@
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Import copies the objects of the bundle dir to the organization, in
+// dependency order, and adds them to mapping, which should be saved even
+// when Import fails, since the objects copied before the failure exist.
+// Objects already in mapping are skipped, as are integrations without a
+// counterpart in the organization.
+//
+// Alert muting rules are copied with their schedule, which the API rejects
+// once it is over, and mirrored dashboards are not carried over to the
+// copies of their groups.
+func Import(ctx context.Context, c Client, dir string, mapping Mapping) error {
+	content, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return fmt.Errorf("reading bundle: %w", err)
+	}
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return fmt.Errorf("decoding %s: %w", manifestFile, err)
+	}
+	if m.Version != Version {
+		return fmt.Errorf("bundle %s has version %d, expected %d", dir, m.Version, Version)
+	}
+
+	for _, k := range kinds {
+		kindDir := filepath.Join(dir, k.dir)
+		entries, err := os.ReadDir(kindDir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// ReadDir sorts the entries by name, so that imports are repeatable.
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				continue
+			}
+			path := filepath.Join(kindDir, entry.Name())
+			if err := importObject(ctx, c, k, path, mapping); err != nil {
+				return fmt.Errorf("importing %s: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// importObject copies the object of a file of the bundle.
+func importObject(ctx context.Context, c Client, k kind, path string, mapping Mapping) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return err
+	}
+	id, err := objectID(fields)
+	if err != nil {
+		return err
+	}
+	if _, ok := mapping[id]; ok {
+		return nil
+	}
+
+	for key := range fields {
+		if slices.Contains(readOnly, key) || slices.Contains(k.drop, key) {
+			delete(fields, key)
+		}
+	}
+	if err := mapping.rewrite(fields); err != nil {
+		return err
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	copied, err := k.restore(ctx, c, data)
+	if err != nil {
+		return err
+	}
+	if copied != "" {
+		mapping[id] = copied
+	}
+	return nil
+}
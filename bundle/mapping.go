@@ -0,0 +1,146 @@
+package bundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Mapping maps the IDs of the objects of a bundle to the IDs of their copies
+// in the target organization. Import adds the objects it copies, and skips
+// the ones already mapped, so that an interrupted import can be resumed.
+//
+// A mapping may be written by hand to map integrations with another name in
+// the target organization, and users, whose IDs are kept in team members and
+// authorized writers only when they are mapped.
+type Mapping map[string]string
+
+// references are the fields holding the IDs of other objects of a bundle,
+// alone or in a list.
+var references = map[string]bool{
+	"aggregateDashboards": true,
+	"chartId":             true,
+	"credentialId":        true,
+	"dashboardGroupId":    true,
+	"dashboardId":         true,
+	"groupId":             true,
+	"instanceDashboards":  true,
+	"sloId":               true,
+	"team":                true,
+	"teams":               true,
+}
+
+// users are the fields holding the IDs of users, alone or in a list.
+var users = map[string]bool{
+	"members": true,
+	"users":   true,
+}
+
+// detectorProperty is the property of the alert muting rule filters muting
+// a detector.
+const detectorProperty = "sf_detectorId"
+
+// LoadMapping reads a mapping file, or returns an empty mapping if it does
+// not exist.
+func LoadMapping(path string) (Mapping, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Mapping{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := Mapping{}
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("decoding mapping %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Save writes the mapping to a file, replacing it atomically.
+func (m Mapping) Save(path string) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// rewrite replaces the IDs v refers to, decoded from JSON, by the IDs of
+// their copies. References to objects that are not mapped are an error,
+// and users that are not mapped are dropped.
+func (m Mapping) rewrite(v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, e := range v {
+			var err error
+			switch {
+			case references[key]:
+				v[key], err = m.ids(e, true)
+			case users[key]:
+				v[key], err = m.ids(e, false)
+			case key == "propertyValue" && v["property"] == detectorProperty:
+				v[key], err = m.ids(e, true)
+			default:
+				err = m.rewrite(e)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if err := m.rewrite(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ids maps an ID or a list of IDs. IDs that are not mapped are an error if
+// required, and are dropped from lists otherwise.
+func (m Mapping) ids(v any, required bool) (any, error) {
+	switch v := v.(type) {
+	case string:
+		if id, ok := m[v]; ok {
+			return id, nil
+		}
+		if required && v != "" {
+			return nil, fmt.Errorf("%s is not mapped to an object of the organization", v)
+		}
+		return v, nil
+	case []any:
+		mapped := make([]any, 0, len(v))
+		for _, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected ID %v", e)
+			}
+			id, ok := m[s]
+			if !ok && required {
+				return nil, fmt.Errorf("%s is not mapped to an object of the organization", s)
+			}
+			if ok {
+				mapped = append(mapped, id)
+			}
+		}
+		return mapped, nil
+	}
+	return v, nil
+}
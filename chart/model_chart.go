@@ -14,6 +14,8 @@ type Chart struct {
 	Created int64 `json:"created,omitempty"`
 	// SignalFx user ID of the user that initially created the chart
 	Creator string `json:"creator,omitempty"`
+	// User-defined JSON object containing metadata
+	CustomProperties *interface{} `json:"customProperties,omitempty"`
 	// Description of the chart. This value appears underneath the chart name in the SignalFx web UI.
 	Description string `json:"description,omitempty"`
 	// System-defined identifier for the chart
@@ -0,0 +1,36 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package chart
+
+import "encoding/json"
+
+// ToUpdateRequest returns the request updating the Chart to its current state,
+// without the fields the request cannot set: Id, Created, Creator, LastUpdated,
+// LastUpdatedBy, SloId. The request shares the slices, maps and pointers of o.
+func (o *Chart) ToUpdateRequest() *CreateUpdateChartRequest {
+	if o == nil {
+		return nil
+	}
+	req := &CreateUpdateChartRequest{
+		Description:           o.Description,
+		Name:                  o.Name,
+		Options:               o.Options,
+		PackageSpecifications: o.PackageSpecifications,
+		ProgramText:           o.ProgramText,
+		Tags:                  o.Tags,
+	}
+	req.CustomProperties = jsonString(o.CustomProperties)
+	return req
+}
+
+// jsonString returns v encoded as JSON, or v itself if it is a string.
+func jsonString(v *interface{}) string {
+	if v == nil || *v == nil {
+		return ""
+	}
+	if s, ok := (*v).(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(*v)
+	return string(b)
+}
@@ -3,12 +3,17 @@ package signalfx
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	stdpath "path"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/signalfx/signalfx-go/realm"
 )
 
 // DefaultAPIURL is the default URL for making API requests
@@ -21,11 +26,16 @@ const AuthHeaderKey = "X-Sf-Token"
 
 // Client is a SignalFx API client.
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	authToken  string
-	userAgent  string
-	pool       sync.Pool
+	baseURL     string
+	httpClient  *http.Client
+	authToken   string
+	tokens      TokenProvider
+	userAgent   string
+	retry       *RetryPolicy
+	limiter     *RateLimiter
+	dryRun      *dryRun
+	middlewares []Middleware
+	pool        sync.Pool
 }
 
 // ClientParam is an option for NewClient. Its implementation borrows
@@ -50,7 +60,9 @@ func NewClient(token string, options ...ClientParam) (*Client, error) {
 	}
 
 	for _, option := range options {
-		option(client)
+		if err := option(client); err != nil {
+			return nil, err
+		}
 	}
 
 	return client, nil
@@ -66,6 +78,19 @@ func APIUrl(apiURL string) ClientParam {
 	}
 }
 
+// Realm sets the URL that our client will communicate with to the API of a
+// known SignalFx realm, such as `"us1"`. It fails for unknown realms.
+func Realm(name string) ClientParam {
+	return func(client *Client) error {
+		endpoints, err := realm.Lookup(name)
+		if err != nil {
+			return err
+		}
+		client.baseURL = endpoints.API
+		return nil
+	}
+}
+
 // UserAgent sets the UserAgent string to include with the request.
 func UserAgent(userAgent string) ClientParam {
 	return func(client *Client) error {
@@ -84,7 +109,28 @@ func HTTPClient(httpClient *http.Client) ClientParam {
 	}
 }
 
+// Timeout sets the time limit of the requests made by the client, including
+// reading the responses. It applies to a copy of the `http.Client` in use,
+// and fails if HTTPClient set none.
+func Timeout(timeout time.Duration) ClientParam {
+	return func(client *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("negative timeout %s", timeout)
+		}
+		if client.httpClient == nil {
+			return errors.New("timeout set without an http client")
+		}
+		httpClient := *client.httpClient
+		httpClient.Timeout = timeout
+		client.httpClient = &httpClient
+		return nil
+	}
+}
+
 func (c *Client) doRequest(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+	if c.tokens != nil {
+		return c.doRequestWithProvider(ctx, method, path, params, body)
+	}
 	return c.doRequestWithToken(ctx, method, path, params, body, c.authToken)
 }
 
@@ -98,17 +144,39 @@ func (c *Client) doRequestWithToken(ctx context.Context, method string, path str
 	if params != nil {
 		destURL.RawQuery = params.Encode()
 	}
+
+	if c.retry != nil {
+		// Retried requests need a body that can be read more than once.
+		if body, err = replayableBody(body); err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, destURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
 	if token != "" {
 		req.Header.Set(AuthHeaderKey, token)
 	}
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, method, path, params)
+}
+
+// replayableBody returns a reader for which `http.NewRequest` is able to
+// set `GetBody`, buffering the content if needed.
+func replayableBody(body io.Reader) (io.Reader, error) {
+	switch body.(type) {
+	case nil, *bytes.Buffer, *bytes.Reader, *strings.Reader:
+		return body, nil
+	}
+	content, err := io.ReadAll(body)
 	if err != nil {
 		return nil, err
 	}
-
-	return c.httpClient.Do(req)
+	return bytes.NewReader(content), nil
 }
 
 func (c *Client) leaseBuffer() *bytes.Buffer {
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/realm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const TestToken = "abc123"
@@ -129,3 +131,14 @@ func TestPathHandling(t *testing.T) {
 	assert.NoError(t, err, "Unexpected error creating chart")
 	assert.Equal(t, "string", result.Name, "Name does not match")
 }
+
+func TestRealm(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	require.NoError(t, Realm("US1")(c), "Must accept known realms")
+	assert.Equal(t, "https://api.us1.signalfx.com", c.baseURL, "Must use the API of the realm")
+
+	assert.ErrorIs(t, Realm("us99")(c), realm.ErrUnknownRealm, "Must reject unknown realms")
+	assert.Equal(t, "https://api.us1.signalfx.com", c.baseURL, "Must not change the URL on error")
+}
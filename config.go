@@ -0,0 +1,181 @@
+package signalfx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/signalfx/signalfx-go/realm"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by LoadConfig.
+const (
+	// EnvVarConfig sets the path of the profiles file.
+	EnvVarConfig = "SFX_CONFIG"
+	// EnvVarProfile selects the profile when none is given.
+	EnvVarProfile = "SFX_PROFILE"
+	EnvVarToken   = "SFX_TOKEN"
+	EnvVarRealm   = "SFX_REALM"
+	EnvVarAPIURL  = "SFX_API_URL"
+)
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// Config is the configuration of a client.
+type Config struct {
+	Token string `yaml:"token"`
+	// Realm and APIURL both set the API to use, and are exclusive.
+	Realm     string        `yaml:"realm"`
+	APIURL    string        `yaml:"api_url"`
+	UserAgent string        `yaml:"user_agent"`
+	Timeout   time.Duration `yaml:"timeout"`
+}
+
+// configFile is the profiles file, such as:
+//
+//	default_profile: prod
+//	profiles:
+//	  prod:
+//	    token: abc123
+//	    realm: us1
+//	  lab:
+//	    token: def456
+//	    api_url: https://api.lab.example.com
+//	    timeout: 1m
+type configFile struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]*Config `yaml:"profiles"`
+}
+
+// DefaultConfigPath returns the default location of the profiles file,
+// `~/.signalfx/config.yaml`.
+func DefaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".signalfx", "config.yaml"), nil
+}
+
+// LoadConfig builds the parameters of a client from a profile of the
+// profiles file, overridden by the `SFX_TOKEN`, `SFX_REALM` and
+// `SFX_API_URL` environment variables. The file is read from `SFX_CONFIG`
+// or DefaultConfigPath, and may be missing from the latter. An empty profile
+// selects `SFX_PROFILE`, then the default profile of the file.
+//
+//	params, err := signalfx.LoadConfig("")
+//	...
+//	client, err := signalfx.NewClient("", params...)
+func LoadConfig(profile string) ([]ClientParam, error) {
+	path, explicit := os.LookupEnv(EnvVarConfig)
+	if !explicit {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		content, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(content, profile)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", path, err)
+	}
+	return config.ClientParams(), nil
+}
+
+// ParseConfig selects a profile of a profiles file, applies the environment
+// overrides and validates the result. See LoadConfig.
+func ParseConfig(content []byte, profile string) (*Config, error) {
+	file := &configFile{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(file); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	explicit := true
+	if profile == "" {
+		profile = os.Getenv(EnvVarProfile)
+	}
+	if profile == "" {
+		profile, explicit = file.DefaultProfile, file.DefaultProfile != ""
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	config := &Config{}
+	if p, ok := file.Profiles[profile]; ok && p != nil {
+		*config = *p
+	} else if explicit {
+		return nil, fmt.Errorf("unknown profile %q", profile)
+	}
+
+	if token := os.Getenv(EnvVarToken); token != "" {
+		config.Token = token
+	}
+	// The environment replaces the API of the profile as a whole.
+	if r, u := os.Getenv(EnvVarRealm), os.Getenv(EnvVarAPIURL); r != "" || u != "" {
+		config.Realm, config.APIURL = r, u
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", profile, err)
+	}
+	return config, nil
+}
+
+// Validate checks that the configuration can be used to build a client.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Token == "" {
+		errs = append(errs, errors.New("missing token"))
+	}
+	if c.Realm != "" && c.APIURL != "" {
+		errs = append(errs, errors.New("realm and api_url are exclusive"))
+	}
+	if c.Realm != "" && !realm.Valid(c.Realm) {
+		errs = append(errs, fmt.Errorf("%w %q", realm.ErrUnknownRealm, c.Realm))
+	}
+	if c.APIURL != "" {
+		if u, err := url.Parse(c.APIURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid api_url %q", c.APIURL))
+		}
+	}
+	if c.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("negative timeout %s", c.Timeout))
+	}
+	return errors.Join(errs...)
+}
+
+// ClientParams returns the parameters of NewClient for the configuration.
+func (c *Config) ClientParams() []ClientParam {
+	params := []ClientParam{Credentials(StaticToken(c.Token))}
+	if c.Realm != "" {
+		params = append(params, Realm(c.Realm))
+	}
+	if c.APIURL != "" {
+		params = append(params, APIUrl(c.APIURL))
+	}
+	if c.UserAgent != "" {
+		params = append(params, UserAgent(c.UserAgent))
+	}
+	if c.Timeout != 0 {
+		params = append(params, Timeout(c.Timeout))
+	}
+	return params
+}
@@ -0,0 +1,142 @@
+package signalfx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/realm"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfiles = `
+default_profile: prod
+profiles:
+  prod:
+    token: prod-token
+    realm: us1
+  lab:
+    token: lab-token
+    api_url: https://api.lab.example.com
+    user_agent: lab-tool
+    timeout: 1m
+`
+
+func TestParseConfig(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		profile string
+		env     map[string]string
+		config  *Config
+		err     string
+	}{
+		{
+			name:    "default profile of the file",
+			content: testProfiles,
+			config:  &Config{Token: "prod-token", Realm: "us1"},
+		},
+		{
+			name:    "selected profile",
+			content: testProfiles,
+			profile: "lab",
+			config:  &Config{Token: "lab-token", APIURL: "https://api.lab.example.com", UserAgent: "lab-tool", Timeout: time.Minute},
+		},
+		{
+			name:    "profile from the environment",
+			content: testProfiles,
+			env:     map[string]string{EnvVarProfile: "lab"},
+			config:  &Config{Token: "lab-token", APIURL: "https://api.lab.example.com", UserAgent: "lab-tool", Timeout: time.Minute},
+		},
+		{
+			name:    "environment overrides",
+			content: testProfiles,
+			env:     map[string]string{EnvVarToken: "env-token", EnvVarAPIURL: "http://localhost:8080"},
+			config:  &Config{Token: "env-token", APIURL: "http://localhost:8080"},
+		},
+		{
+			name:   "environment only",
+			env:    map[string]string{EnvVarToken: "env-token", EnvVarRealm: "eu0"},
+			config: &Config{Token: "env-token", Realm: "eu0"},
+		},
+		{
+			name:    "unknown profile",
+			content: testProfiles,
+			profile: "staging",
+			err:     `unknown profile "staging"`,
+		},
+		{
+			name:    "unknown field",
+			content: "profiles:\n  default:\n    tokn: abc\n",
+			err:     "field tokn not found",
+		},
+		{
+			name:    "invalid profile",
+			content: "profiles:\n  default:\n    realm: us99\n    api_url: ftp://example.com\n    timeout: -1s\n",
+			err:     "profile \"default\": missing token\nrealm and api_url are exclusive\nunknown realm \"us99\"\ninvalid api_url \"ftp://example.com\"\nnegative timeout -1s",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{EnvVarProfile, EnvVarToken, EnvVarRealm, EnvVarAPIURL} {
+				t.Setenv(name, tc.env[name])
+			}
+
+			config, err := ParseConfig([]byte(tc.content), tc.profile)
+			if tc.err != "" {
+				require.Error(t, err, "Must fail")
+				assert.Contains(t, err.Error(), tc.err, "Must explain the failure")
+				return
+			}
+			require.NoError(t, err, "Must parse the config")
+			assert.Equal(t, tc.config, config, "Must return the config")
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	fake := signalfxtest.NewServer(signalfxtest.Token("lab-token"))
+	defer fake.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testProfiles), 0o600))
+	t.Setenv(EnvVarConfig, path)
+	t.Setenv(EnvVarAPIURL, fake.URL)
+	t.Setenv(EnvVarProfile, "")
+	t.Setenv(EnvVarToken, "")
+	t.Setenv(EnvVarRealm, "")
+
+	params, err := LoadConfig("lab")
+	require.NoError(t, err, "Must load the config")
+
+	c, err := NewClient("", params...)
+	require.NoError(t, err, "Must create the client")
+	assert.Equal(t, "lab-tool", c.userAgent, "Must set the user agent")
+	assert.Equal(t, time.Minute, c.httpClient.Timeout, "Must set the timeout")
+
+	_, err = c.SearchDetectors(context.Background(), 10, "", 0, "")
+	assert.NoError(t, err, "Must authenticate with the token of the profile")
+
+	t.Setenv(EnvVarConfig, filepath.Join(t.TempDir(), "missing.yaml"))
+	_, err = LoadConfig("lab")
+	assert.ErrorIs(t, err, os.ErrNotExist, "Must fail on a missing explicit file")
+}
+
+func TestNewClientOptionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewClient(TestToken, Realm("us99"))
+	assert.ErrorIs(t, err, realm.ErrUnknownRealm, "Must return the error of the option")
+
+	_, err = NewClient(TestToken, Timeout(-time.Second))
+	assert.Error(t, err, "Must reject negative timeouts")
+
+	_, err = NewClient(TestToken, HTTPClient(nil), Timeout(time.Second))
+	assert.Error(t, err, "Must reject timeouts without an http client")
+}
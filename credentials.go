@@ -0,0 +1,237 @@
+package signalfx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/signalfx/signalfx-go/sessiontoken"
+)
+
+// DefaultSessionRenewal is how long before its expiry a session token is
+// renewed by a SessionTokenProvider.
+const DefaultSessionRenewal = 5 * time.Minute
+
+// TokenProvider supplies the token authenticating the requests of a client.
+// Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenInvalidator is implemented by the providers able to replace a token
+// rejected by the API. The client calls Invalidate when a request fails
+// with a 401, then retries it once with a new token.
+type TokenInvalidator interface {
+	Invalidate(token string)
+}
+
+// Credentials makes the client get the token of each request from provider,
+// instead of using the token given to NewClient.
+func Credentials(provider TokenProvider) ClientParam {
+	return func(client *Client) error {
+		if provider == nil {
+			return errors.New("nil token provider")
+		}
+		client.tokens = provider
+		return nil
+	}
+}
+
+// StaticToken is a TokenProvider always returning the same token.
+type StaticToken string
+
+// Token returns the token.
+func (t StaticToken) Token(context.Context) (string, error) {
+	if t == "" {
+		return "", errors.New("empty token")
+	}
+	return string(t), nil
+}
+
+// EnvToken is a TokenProvider reading the token from the environment
+// variable it names, such as `SFX_TOKEN`, on every request.
+type EnvToken string
+
+// Token returns the value of the environment variable.
+func (e EnvToken) Token(context.Context) (string, error) {
+	token := os.Getenv(string(e))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s is not set", string(e))
+	}
+	return token, nil
+}
+
+// FileTokenProvider reads the token from a file, reading it again whenever
+// the file changes so that tokens can be rotated without restarting.
+type FileTokenProvider struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+var _ TokenInvalidator = (*FileTokenProvider)(nil)
+
+// FileToken creates a provider reading the token from the file at path.
+// Surrounding whitespace is ignored.
+func FileToken(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// Token returns the content of the file.
+func (f *FileTokenProvider) Token(context.Context) (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", f.path)
+	}
+	f.token, f.modTime, f.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// Invalidate makes the next call read the file again.
+func (f *FileTokenProvider) Invalidate(token string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token == token {
+		f.token = ""
+	}
+}
+
+// SessionTokenProvider logs in with an email and a password and renews the
+// session token before it expires or when the API rejects it.
+type SessionTokenProvider struct {
+	client  *Client
+	request sessiontoken.CreateTokenRequest
+	// RenewBefore is how long before its expiry the token is renewed. It
+	// defaults to DefaultSessionRenewal.
+	RenewBefore time.Duration
+
+	mu      sync.Mutex
+	session *sessiontoken.Token
+	now     func() time.Time
+}
+
+var _ TokenInvalidator = (*SessionTokenProvider)(nil)
+
+// SessionToken creates a provider of session tokens created with client,
+// which only needs the URL of the API.
+func SessionToken(client *Client, request sessiontoken.CreateTokenRequest) *SessionTokenProvider {
+	return &SessionTokenProvider{
+		client:      client,
+		request:     request,
+		RenewBefore: DefaultSessionRenewal,
+		now:         time.Now,
+	}
+}
+
+// Token returns the current session token, creating a new session first if
+// there is none or the current one expires soon. The replaced session is
+// deleted.
+func (s *SessionTokenProvider) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session != nil && s.now().Add(s.RenewBefore).UnixMilli() < s.session.ExpiryMs {
+		return s.session.AccessToken, nil
+	}
+
+	request := s.request
+	session, err := s.client.CreateSessionToken(ctx, &request)
+	if err != nil {
+		return "", err
+	}
+	if s.session != nil {
+		// The old session only needs to be cleaned up, and may have
+		// already expired.
+		_ = s.client.DeleteSessionToken(ctx, s.session.AccessToken)
+	}
+	s.session = session
+	return session.AccessToken, nil
+}
+
+// Invalidate drops the session if it uses token, so that the next call
+// creates a new one.
+func (s *SessionTokenProvider) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session != nil && s.session.AccessToken == token {
+		s.session = nil
+	}
+}
+
+// Close deletes the current session, if any.
+func (s *SessionTokenProvider) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.session == nil {
+		return nil
+	}
+	token := s.session.AccessToken
+	s.session = nil
+	return s.client.DeleteSessionToken(ctx, token)
+}
+
+// doRequestWithProvider sends a request with a token from the provider of
+// the client, retrying once with a new token if it is rejected.
+func (c *Client) doRequestWithProvider(ctx context.Context, method string, path string, params url.Values, body io.Reader) (*http.Response, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting token: %w", err)
+	}
+
+	invalidator, ok := c.tokens.(TokenInvalidator)
+	if !ok {
+		return c.doRequestWithToken(ctx, method, path, params, body, token)
+	}
+
+	var content []byte
+	if body != nil {
+		if content, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := c.doRequestWithToken(ctx, method, path, params, bytes.NewReader(content), token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	invalidator.Invalidate(token)
+	renewed, err := c.tokens.Token(ctx)
+	if err != nil || renewed == token {
+		// Keep the original response, which explains the failure.
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return c.doRequestWithToken(ctx, method, path, params, bytes.NewReader(content), renewed)
+}
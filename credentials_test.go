@@ -0,0 +1,123 @@
+package signalfx
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticAndEnvTokens(t *testing.T) {
+	ctx := context.Background()
+
+	token, err := StaticToken("abc").Token(ctx)
+	require.NoError(t, err, "Must return the token")
+	assert.Equal(t, "abc", token, "Must return the token")
+
+	_, err = StaticToken("").Token(ctx)
+	assert.Error(t, err, "Must reject empty tokens")
+
+	t.Setenv("SFX_TEST_TOKEN", "from-env")
+	token, err = EnvToken("SFX_TEST_TOKEN").Token(ctx)
+	require.NoError(t, err, "Must read the variable")
+	assert.Equal(t, "from-env", token, "Must return the variable")
+
+	_, err = EnvToken("SFX_TEST_TOKEN_UNSET").Token(ctx)
+	assert.Error(t, err, "Must reject unset variables")
+}
+
+func TestFileToken(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	provider := FileToken(path)
+	token, err := provider.Token(ctx)
+	require.NoError(t, err, "Must read the file")
+	assert.Equal(t, "first", token, "Must trim the content")
+
+	require.NoError(t, os.WriteFile(path, []byte("second-token\n"), 0o600))
+	token, err = provider.Token(ctx)
+	require.NoError(t, err, "Must read the file")
+	assert.Equal(t, "second-token", token, "Must read the file again once changed")
+
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	_, err = provider.Token(ctx)
+	assert.Error(t, err, "Must reject empty files")
+}
+
+func TestCredentials(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer(signalfxtest.Token("rotated"))
+	defer fake.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("rotated"), 0o600))
+
+	c, _ := NewClient("ignored", APIUrl(fake.URL), Credentials(FileToken(path)))
+	_, err := c.SearchDetectors(context.Background(), 10, "", 0, "")
+	assert.NoError(t, err, "Must use the token of the provider")
+
+	require.Error(t, Credentials(nil)(c), "Must reject nil providers")
+}
+
+func TestSessionToken(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer(signalfxtest.Token("org-token"))
+	defer fake.Close()
+	ctx := context.Background()
+
+	login, _ := NewClient("", APIUrl(fake.URL))
+	provider := SessionToken(login, sessiontoken.CreateTokenRequest{Email: "me@example.com", Password: "secret"})
+	c, _ := NewClient("", APIUrl(fake.URL), Credentials(provider))
+
+	_, err := c.SearchDetectors(ctx, 10, "", 0, "")
+	require.NoError(t, err, "Must log in")
+	first, _ := provider.Token(ctx)
+
+	_, err = c.SearchDetectors(ctx, 10, "", 0, "")
+	require.NoError(t, err, "Must reuse the session")
+	token, _ := provider.Token(ctx)
+	assert.Equal(t, first, token, "Must not renew a valid session")
+
+	// The session is revoked behind the back of the provider.
+	require.NoError(t, login.DeleteSessionToken(ctx, first), "Must delete the session")
+	_, err = c.SearchDetectors(ctx, 10, "", 0, "")
+	require.NoError(t, err, "Must renew the session on a 401")
+	second, _ := provider.Token(ctx)
+	assert.NotEqual(t, first, second, "Must use a new session")
+
+	// Sessions expire after an hour.
+	provider.now = func() time.Time { return time.Now().Add(time.Hour) }
+	third, err := provider.Token(ctx)
+	require.NoError(t, err, "Must renew the session")
+	assert.NotEqual(t, second, third, "Must renew sessions about to expire")
+
+	require.NoError(t, provider.Close(ctx), "Must log out")
+
+	var logins, logouts int
+	for _, r := range fake.Requests() {
+		if r.Path != SessionTokenAPIURL {
+			continue
+		}
+		switch r.Method {
+		case http.MethodPost:
+			logins++
+		case http.MethodDelete:
+			logouts++
+		}
+	}
+	assert.Equal(t, 3, logins, "Must create a session per renewal")
+	assert.Equal(t, 3, logouts, "Must delete the replaced sessions")
+}
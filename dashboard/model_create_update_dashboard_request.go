@@ -14,6 +14,8 @@ type CreateUpdateDashboardRequest struct {
 	Permissions       *ObjectPermissions    `json:"permissions,omitempty"`
 	ChartDensity      DashboardChartDensity `json:"chartDensity,omitempty"`
 	Charts            []*DashboardChart     `json:"charts,omitempty"`
+	// Custom properties for the dashboard, in the form of a JSON object that contains key-value pairs.
+	CustomProperties map[string]interface{} `json:"customProperties,omitempty"`
 	// Description of the dashboard. The system displays the value in the dashboard tab tooltip in the dashboard group in the web UI.
 	Description string `json:"description,omitempty"`
 
@@ -0,0 +1,32 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package dashboard
+
+// ToUpdateRequest returns the request updating the Dashboard to its current
+// state, without the fields the request cannot set: Id, Created, Creator,
+// LastUpdated, LastUpdatedBy, Locked. The request shares the slices, maps and
+// pointers of o.
+func (o *Dashboard) ToUpdateRequest() *CreateUpdateDashboardRequest {
+	if o == nil {
+		return nil
+	}
+	req := &CreateUpdateDashboardRequest{
+		AuthorizedWriters:     o.AuthorizedWriters,
+		Permissions:           o.Permissions,
+		Charts:                o.Charts,
+		CustomProperties:      o.CustomProperties,
+		Description:           o.Description,
+		DiscoveryOptions:      o.DiscoveryOptions,
+		EventOverlays:         o.EventOverlays,
+		Filters:               o.Filters,
+		GroupId:               o.GroupId,
+		MaxDelayOverride:      o.MaxDelayOverride,
+		Name:                  o.Name,
+		SelectedEventOverlays: o.SelectedEventOverlays,
+		Tags:                  o.Tags,
+	}
+	if o.ChartDensity != nil {
+		req.ChartDensity = *o.ChartDensity
+	}
+	return req
+}
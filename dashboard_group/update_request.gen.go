@@ -0,0 +1,23 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package dashboard_group
+
+// ToUpdateRequest returns the request updating the DashboardGroup to its
+// current state, without the fields the request cannot set: Id, Created,
+// Creator, LastUpdated, LastUpdatedBy. The request shares the slices, maps and
+// pointers of o.
+func (o *DashboardGroup) ToUpdateRequest() *CreateUpdateDashboardGroupRequest {
+	if o == nil {
+		return nil
+	}
+	return &CreateUpdateDashboardGroupRequest{
+		AuthorizedWriters: o.AuthorizedWriters,
+		Permissions:       o.Permissions,
+		Dashboards:        o.Dashboards,
+		DashboardConfigs:  o.DashboardConfigs,
+		Description:       o.Description,
+		ImportQualifiers:  o.ImportQualifiers,
+		Name:              o.Name,
+		Teams:             o.Teams,
+	}
+}
@@ -0,0 +1,19 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package datalink
+
+// ToUpdateRequest returns the request updating the DataLink to its current
+// state, without the fields the request cannot set: Id, Created, Creator,
+// LastUpdated, LastUpdatedBy. The request shares the slices, maps and pointers
+// of o.
+func (o *DataLink) ToUpdateRequest() *CreateUpdateDataLinkRequest {
+	if o == nil {
+		return nil
+	}
+	return &CreateUpdateDataLinkRequest{
+		PropertyName:  o.PropertyName,
+		PropertyValue: o.PropertyValue,
+		ContextId:     o.ContextId,
+		Targets:       o.Targets,
+	}
+}
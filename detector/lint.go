@@ -0,0 +1,183 @@
+package detector
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/signalfx/signalfx-go/signalflow/parse"
+)
+
+// Issue is a problem found in a detector request by Lint.
+type Issue struct {
+	// Field is the path of the field at fault, as in its JSON, such as
+	// "rules[1].detectLabel".
+	Field   string
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+// severities are the valid severities of rules.
+var severities = []Severity{CRITICAL, MAJOR, MINOR, WARNING, INFO}
+
+// publication is a publish statement of a program.
+type publication struct {
+	label  string
+	pos    parse.Pos
+	detect bool
+}
+
+// Lint checks a detector request without calling the API, reporting what
+// the API would reject or what is likely a mistake: the problems of the
+// program, the rules and visualization options whose labels the program
+// does not publish, the detect labels without rules, and the invalid
+// severities, runbook URLs and template variables of the rules.
+func Lint(req *CreateUpdateDetectorRequest) []Issue {
+	var issues []Issue
+	report := func(field, format string, args ...any) {
+		issues = append(issues, Issue{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var published []publication
+	// Variables stay nil, accepting any input in templates, unless the
+	// program parses.
+	var variables []string
+	prog, err := parse.Parse(req.ProgramText)
+	if err != nil {
+		report("programText", "%s", err)
+	} else {
+		for _, issue := range parse.Lint(prog) {
+			report("programText", "%s", issue)
+		}
+		published, variables = publications(prog)
+		for _, p := range published {
+			if p.label == "" && p.detect {
+				report("programText", "%s: detect is published without a label", p.pos)
+			}
+		}
+	}
+	find := func(label string) *publication {
+		i := slices.IndexFunc(published, func(p publication) bool { return p.label == label })
+		if i < 0 {
+			return nil
+		}
+		return &published[i]
+	}
+
+	ruled := map[string]bool{}
+	for i, rule := range req.Rules {
+		if rule == nil {
+			continue
+		}
+		field := fmt.Sprintf("rules[%d]", i)
+		ruled[rule.DetectLabel] = true
+		switch p := find(rule.DetectLabel); {
+		case rule.DetectLabel == "":
+			report(field+".detectLabel", "is required")
+		case prog == nil:
+		case p == nil:
+			report(field+".detectLabel", "the program does not publish %q", rule.DetectLabel)
+		case !p.detect:
+			report(field+".detectLabel", "%q is published at %s by a stream, not a detect", rule.DetectLabel, p.pos)
+		}
+		switch {
+		case rule.Severity == "":
+			report(field+".severity", "is required")
+		case !slices.Contains(severities, rule.Severity):
+			report(field+".severity", "%q is not one of Critical, Major, Minor, Warning, Info", rule.Severity)
+		}
+		if rule.RunbookUrl != "" {
+			if u, err := url.Parse(rule.RunbookUrl); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				report(field+".runbookUrl", "%q is not an absolute http or https URL", rule.RunbookUrl)
+			}
+		}
+		for _, template := range []struct{ field, text string }{
+			{"parameterizedSubject", rule.ParameterizedSubject},
+			{"parameterizedBody", rule.ParameterizedBody},
+		} {
+			t, errs := parseTemplate(template.text)
+			for _, err := range append(errs, t.UnknownVariables(variables)...) {
+				report(field+"."+template.field, "%s", err)
+			}
+		}
+	}
+
+	if req.VisualizationOptions != nil {
+		for i, options := range req.VisualizationOptions.PublishLabelOptions {
+			if options != nil && prog != nil && find(options.Label) == nil {
+				report(fmt.Sprintf("visualizationOptions.publishLabelOptions[%d].label", i), "the program does not publish %q", options.Label)
+			}
+		}
+	}
+
+	for _, p := range published {
+		if p.detect && p.label != "" && !ruled[p.label] {
+			report("rules", "no rule for the detect published at %s with label %q", p.pos, p.label)
+		}
+	}
+	return issues
+}
+
+// publications returns the publish statements of a program, and the
+// variables it defines.
+func publications(prog *parse.Program) ([]publication, []string) {
+	values := map[string]parse.Expr{}
+	variables := []string{}
+	for _, stmt := range prog.Statements {
+		if a, ok := stmt.(*parse.Assign); ok {
+			for _, t := range a.Targets {
+				values[t.Name] = a.Value
+				variables = append(variables, t.Name)
+			}
+		}
+	}
+	var published []publication
+	for _, stmt := range prog.Statements {
+		parse.Inspect(stmt, func(n parse.Node) bool {
+			c, ok := n.(*parse.Call)
+			if !ok || c.Name() != "publish" || c.Receiver() == nil {
+				return true
+			}
+			p := publication{pos: c.Pos(), detect: isDetect(c.Receiver(), values, 0)}
+			if label, ok := c.Arg("label", 0).(*parse.String); ok {
+				p.label = label.Value
+			}
+			published = append(published, p)
+			return true
+		})
+	}
+	return published, variables
+}
+
+// isDetect returns whether a published expression may be a detect: only
+// the streams of data and the other stream functions are known not to be,
+// while the functions of libraries, such as against_recent, return detects.
+func isDetect(e parse.Expr, values map[string]parse.Expr, depth int) bool {
+	switch e := e.(type) {
+	case *parse.Ident:
+		// Bound the depth for programs reassigning variables to themselves.
+		if v, ok := values[e.Name]; ok && depth < 16 {
+			return isDetect(v, values, depth+1)
+		}
+		return true
+	case *parse.Call:
+		switch e.Name() {
+		case "detect":
+			return true
+		case "publish":
+			return isDetect(e.Receiver(), values, depth)
+		}
+		if r := e.Receiver(); r != nil {
+			if module, ok := r.(*parse.Ident); ok && values[module.Name] == nil {
+				// A function of an imported module.
+				return true
+			}
+			return isDetect(r, values, depth)
+		}
+		return !parse.IsBuiltin(e.Name())
+	}
+	return false
+}
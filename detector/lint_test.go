@@ -0,0 +1,118 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const program = `from signalfx.detectors.against_recent import against_recent
+A = data('cpu.utilization').mean(by=['host'])
+detect(when(A > 90, lasting='5m')).publish('CPU high')
+against_recent.detector_mean_std(A).publish('CPU unusual')
+A.publish('cpu')
+`
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *CreateUpdateDetectorRequest {
+		return &CreateUpdateDetectorRequest{
+			ProgramText: program,
+			Rules: []*Rule{
+				{
+					DetectLabel:          "CPU high",
+					Severity:             CRITICAL,
+					RunbookUrl:           "https://runbooks.example.com/cpu",
+					ParameterizedSubject: "{{ruleSeverity}} on {{dimensions.host}}",
+					ParameterizedBody:    "{{#if anomalous}}CPU is {{inputs.A.value}}{{else}}CPU is back{{/if}}\n{{{runbookUrl}}}",
+				},
+				{DetectLabel: "CPU unusual", Severity: WARNING},
+			},
+			VisualizationOptions: &Visualization{
+				PublishLabelOptions: []*PublishLabelOptions{{Label: "cpu", DisplayName: "CPU"}},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(req *CreateUpdateDetectorRequest)
+		want   []Issue
+	}{
+		{
+			name:   "valid",
+			modify: func(*CreateUpdateDetectorRequest) {},
+		},
+		{
+			name: "syntax error",
+			modify: func(req *CreateUpdateDetectorRequest) {
+				req.ProgramText = "detect(when(A > 90).publish('CPU high')"
+				req.VisualizationOptions = nil
+			},
+			want: []Issue{{Field: "programText", Message: `1:40: expected ")", found end of line`}},
+		},
+		{
+			name: "program issues",
+			modify: func(req *CreateUpdateDetectorRequest) {
+				req.ProgramText = program + "B = data('memory')\ndetect(when(A > 95)).publish()\n"
+			},
+			want: []Issue{
+				{Field: "programText", Message: `6:1: stream "B" is never used nor published (unused-stream)`},
+				{Field: "programText", Message: "7:1: detect is published without a label"},
+			},
+		},
+		{
+			name: "orphaned rules and options",
+			modify: func(req *CreateUpdateDetectorRequest) {
+				req.Rules[1].DetectLabel = "CPU unusal"
+				req.Rules = append(req.Rules, &Rule{DetectLabel: "cpu", Severity: INFO}, &Rule{Severity: INFO})
+				req.VisualizationOptions.PublishLabelOptions[0].Label = "CPU"
+			},
+			want: []Issue{
+				{Field: "rules[1].detectLabel", Message: `the program does not publish "CPU unusal"`},
+				{Field: "rules[2].detectLabel", Message: `"cpu" is published at 5:1 by a stream, not a detect`},
+				{Field: "rules[3].detectLabel", Message: "is required"},
+				{Field: "visualizationOptions.publishLabelOptions[0].label", Message: `the program does not publish "CPU"`},
+				{Field: "rules", Message: `no rule for the detect published at 4:1 with label "CPU unusual"`},
+			},
+		},
+		{
+			name: "severity and runbook",
+			modify: func(req *CreateUpdateDetectorRequest) {
+				req.Rules[0].Severity = "critical"
+				req.Rules[0].RunbookUrl = "runbooks/cpu"
+				req.Rules[1].Severity = ""
+			},
+			want: []Issue{
+				{Field: "rules[0].severity", Message: `"critical" is not one of Critical, Major, Minor, Warning, Info`},
+				{Field: "rules[0].runbookUrl", Message: `"runbooks/cpu" is not an absolute http or https URL`},
+				{Field: "rules[1].severity", Message: "is required"},
+			},
+		},
+		{
+			name: "templates",
+			modify: func(req *CreateUpdateDetectorRequest) {
+				req.Rules[0].ParameterizedSubject = "{{rulename}} {{inputs.B.value}} {{#each dimensions}}{{@key}}{{/each}}"
+				req.Rules[0].ParameterizedBody = "{{#if anomalous}}{{#unless normal}}{{/if}}{{else}} {{timestamp"
+			},
+			want: []Issue{
+				{Field: "rules[0].parameterizedSubject", Message: "1:1: unknown variable {{rulename}}"},
+				{Field: "rules[0].parameterizedSubject", Message: `1:14: {{inputs.B.value}} refers to "B", which the program does not define`},
+				{Field: "rules[0].parameterizedBody", Message: "1:36: {{/if}} does not close a block"},
+				{Field: "rules[0].parameterizedBody", Message: `1:52: "{{timestamp" is not closed`},
+				{Field: "rules[0].parameterizedBody", Message: "1:1: {{#if}} is not closed"},
+				{Field: "rules[0].parameterizedBody", Message: "1:18: {{#unless}} is not closed"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := valid()
+			tc.modify(req)
+			assert.Equal(t, tc.want, Lint(req), "Must report the issues")
+		})
+	}
+}
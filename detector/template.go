@@ -0,0 +1,500 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/signalfx/signalfx-go/signalflow/parse"
+)
+
+// templateVariables are the variables of notification templates, other
+// than dimensions, inputs and event annotations, which take a path.
+var templateVariables = []string{
+	"anomalous", "detectorId", "detectorName", "imageUrl", "incidentId",
+	"normal", "readableRule", "ruleName", "ruleSeverity", "runbookUrl",
+	"timestamp", "tip",
+}
+
+// templateBlocks are the block helpers of notification templates.
+var templateBlocks = []string{"each", "if", "notEmpty", "unless", "with"}
+
+// TemplateError is a problem of a notification template.
+type TemplateError struct {
+	Pos parse.Pos
+	Msg string
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Template is a parsed notification template, such as the
+// ParameterizedSubject or ParameterizedBody of a rule. Templates use the
+// Handlebars syntax: variables, as in {{ruleSeverity}}, {{{runbookUrl}}} or
+// {{dimensions.host}}, and blocks, as in {{#if anomalous}}...{{else}}...{{/if}}.
+type Template struct {
+	nodes []*templateNode
+}
+
+// templateNode is text, a variable or a block of a template.
+type templateNode struct {
+	pos  parse.Pos
+	text string
+	// variable is the variable of a tag, or the argument of a block.
+	variable string
+	block    string
+	body     []*templateNode
+	alt      []*templateNode
+	hasElse  bool
+}
+
+// ParseTemplate parses a notification template. It fails on tags that are
+// not closed, unknown blocks and unbalanced blocks, but not on unknown
+// variables, which UnknownVariables reports. The error joins every problem
+// found, each one a *TemplateError.
+func ParseTemplate(text string) (*Template, error) {
+	t, errs := parseTemplate(text)
+	if len(errs) > 0 {
+		return nil, joinTemplateErrors(errs)
+	}
+	return t, nil
+}
+
+// parseTemplate returns the template and its problems. The template is
+// parsed as far as possible despite them.
+func parseTemplate(text string) (*Template, []*TemplateError) {
+	var errs []*TemplateError
+	fail := func(pos parse.Pos, format string, args ...any) {
+		errs = append(errs, &TemplateError{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+	}
+	root := &templateNode{}
+	stack := []*templateNode{root}
+	add := func(n *templateNode) {
+		top := stack[len(stack)-1]
+		if top.hasElse {
+			top.alt = append(top.alt, n)
+		} else {
+			top.body = append(top.body, n)
+		}
+	}
+	pos := func(offset int) parse.Pos {
+		before := text[:offset]
+		line := strings.Count(before, "\n") + 1
+		return parse.Pos{Line: line, Column: len([]rune(before[strings.LastIndexByte(before, '\n')+1:])) + 1}
+	}
+
+	offset := 0
+	for {
+		start := strings.Index(text[offset:], "{{")
+		if start < 0 {
+			break
+		}
+		start += offset
+		if start > offset {
+			add(&templateNode{pos: pos(offset), text: text[offset:start]})
+		}
+		open, closing := start+2, "}}"
+		if strings.HasPrefix(text[open:], "{") {
+			open, closing = open+1, "}}}"
+		}
+		end := strings.Index(text[open:], closing)
+		if end < 0 {
+			// Nothing after the tag can be parsed.
+			line, _, _ := strings.Cut(text[start:], "\n")
+			fail(pos(start), "%q is not closed", line)
+			offset = len(text)
+			break
+		}
+		tag := strings.TrimSpace(text[open : open+end])
+		offset = open + end + len(closing)
+		at := pos(start)
+		fields := strings.Fields(tag)
+
+		switch {
+		case strings.HasPrefix(tag, "!"):
+		case tag == "":
+			fail(at, "empty tag")
+		case strings.HasPrefix(tag, "#"):
+			name := strings.TrimPrefix(fields[0], "#")
+			n := &templateNode{pos: at, block: name}
+			switch {
+			case !slices.Contains(templateBlocks, name):
+				fail(at, "unknown block {{#%s}}", name)
+			case len(fields) != 2:
+				fail(at, "{{#%s}} takes a variable", name)
+			default:
+				n.variable = fields[1]
+			}
+			// Blocks at fault are still pushed, for their closing tags to
+			// match.
+			add(n)
+			stack = append(stack, n)
+		case strings.HasPrefix(tag, "/"):
+			name := strings.TrimSpace(tag[1:])
+			if top := stack[len(stack)-1]; top == root || top.block != name {
+				fail(at, "{{/%s}} does not close a block", name)
+				continue
+			}
+			stack = stack[:len(stack)-1]
+		case tag == "else":
+			top := stack[len(stack)-1]
+			if top == root || top.hasElse {
+				fail(at, "{{else}} is outside of a block")
+				continue
+			}
+			top.hasElse = true
+		case len(fields) > 1:
+			fail(at, "unknown helper {{%s}}", tag)
+		default:
+			add(&templateNode{pos: at, variable: tag})
+		}
+	}
+	if offset < len(text) {
+		add(&templateNode{pos: pos(offset), text: text[offset:]})
+	}
+	for _, n := range stack[1:] {
+		fail(n.pos, "{{#%s}} is not closed", n.block)
+	}
+	return &Template{nodes: root.body}, errs
+}
+
+// joinTemplateErrors joins template errors with errors.Join.
+func joinTemplateErrors(errs []*TemplateError) error {
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
+
+// UnknownVariables returns the variables of the template that do not
+// exist. Inputs lists the variables of the program of the detector, which
+// the inputs of templates refer to, or is nil to accept any input.
+// Variables within each and with blocks are relative and not checked.
+func (t *Template) UnknownVariables(inputs []string) []*TemplateError {
+	var errs []*TemplateError
+	var walk func(nodes []*templateNode)
+	walk = func(nodes []*templateNode) {
+		for _, n := range nodes {
+			if n.variable != "" {
+				if msg := checkVariable(n.variable, inputs); msg != "" {
+					errs = append(errs, &TemplateError{Pos: n.pos, Msg: msg})
+				}
+			}
+			if n.block != "each" && n.block != "with" {
+				walk(n.body)
+			}
+			walk(n.alt)
+		}
+	}
+	walk(t.nodes)
+	return errs
+}
+
+// checkVariable returns why a variable does not exist, or "".
+func checkVariable(name string, inputs []string) string {
+	path := strings.Split(name, ".")
+	switch path[0] {
+	case "dimensions":
+		return ""
+	case "event_annotations":
+		if len(path) > 1 {
+			return ""
+		}
+	case "inputs":
+		if len(path) < 2 {
+			break
+		}
+		if inputs != nil && !slices.Contains(inputs, path[1]) {
+			return fmt.Sprintf("{{%s}} refers to %q, which the program does not define", name, path[1])
+		}
+		return ""
+	default:
+		if len(path) == 1 && slices.Contains(templateVariables, name) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("unknown variable {{%s}}", name)
+}
+
+// TemplateData is the data templates are rendered with, as of an alert.
+type TemplateData struct {
+	DetectorId   string
+	DetectorName string
+	RuleName     string
+	RuleSeverity Severity
+	ReadableRule string
+	RunbookUrl   string
+	Tip          string
+	IncidentId   string
+	ImageUrl     string
+	Timestamp    time.Time
+	// Anomalous is whether the alert is triggered, rather than cleared.
+	Anomalous  bool
+	Dimensions map[string]string
+	// Inputs are the values of the streams of the detect, by variable.
+	Inputs           map[string]TemplateInput
+	EventAnnotations map[string]any
+}
+
+// TemplateInput is the value of a stream of a detect.
+type TemplateInput struct {
+	Value      any
+	Dimensions map[string]string
+}
+
+// NewTemplateData returns the data of an event of a rule, such as a
+// synthetic event previewing the notifications of the rule. The
+// dimensions of the alert are those of its inputs.
+func NewTemplateData(rule *Rule, event *Event) *TemplateData {
+	data := newTemplateData(rule, event.DetectorId, event.DetectorName, event.IncidentId, event.Severity, event.AnomalyState, event.Inputs)
+	if event.Timestamp != 0 {
+		data.Timestamp = time.UnixMilli(event.Timestamp)
+	}
+	if event.EventAnnotations != nil {
+		data.EventAnnotations = *event.EventAnnotations
+	}
+	return data
+}
+
+// IncidentTemplateData returns the data of an incident of a rule.
+func IncidentTemplateData(rule *Rule, incident *Incident) *TemplateData {
+	data := newTemplateData(rule, incident.DetectorId, incident.DetectorName, incident.IncidentId, incident.Severity, incident.AnomalyState, incident.Inputs)
+	if n := len(incident.Events); n > 0 && incident.Events[n-1] != nil {
+		last := incident.Events[n-1]
+		if last.Timestamp != 0 {
+			data.Timestamp = time.UnixMilli(last.Timestamp)
+		}
+		if last.EventAnnotations != nil {
+			data.EventAnnotations = *last.EventAnnotations
+		}
+	}
+	return data
+}
+
+func newTemplateData(rule *Rule, detectorID, detectorName, incidentID, severity, anomalyState string, inputs *map[string]any) *TemplateData {
+	data := &TemplateData{
+		DetectorId:   detectorID,
+		DetectorName: detectorName,
+		IncidentId:   incidentID,
+		RuleSeverity: Severity(severity),
+		Anomalous:    anomalyState == "ANOMALOUS",
+		Dimensions:   map[string]string{},
+		Inputs:       map[string]TemplateInput{},
+	}
+	if rule != nil {
+		data.RuleName = rule.DetectLabel
+		data.ReadableRule = rule.Description
+		data.RunbookUrl = rule.RunbookUrl
+		data.Tip = rule.Tip
+		if data.RuleSeverity == "" {
+			data.RuleSeverity = rule.Severity
+		}
+	}
+	if inputs == nil {
+		return data
+	}
+	for name, v := range *inputs {
+		input := TemplateInput{Value: v, Dimensions: map[string]string{}}
+		// Inputs are objects with the value and the dimensions, as key.
+		if fields, ok := v.(map[string]any); ok {
+			input.Value = fields["value"]
+			if key, ok := fields["key"].(map[string]any); ok {
+				for k, v := range key {
+					input.Dimensions[k] = fmt.Sprint(v)
+					data.Dimensions[k] = fmt.Sprint(v)
+				}
+			}
+		}
+		data.Inputs[name] = input
+	}
+	return data
+}
+
+// context returns the variables of the data.
+func (d *TemplateData) context() map[string]any {
+	inputs := map[string]any{}
+	for name, input := range d.Inputs {
+		inputs[name] = map[string]any{"value": input.Value, "dimensions": stringMap(input.Dimensions)}
+	}
+	c := map[string]any{
+		"anomalous":         d.Anomalous,
+		"detectorId":        d.DetectorId,
+		"detectorName":      d.DetectorName,
+		"dimensions":        stringMap(d.Dimensions),
+		"event_annotations": d.EventAnnotations,
+		"imageUrl":          d.ImageUrl,
+		"incidentId":        d.IncidentId,
+		"inputs":            inputs,
+		"normal":            !d.Anomalous,
+		"readableRule":      d.ReadableRule,
+		"ruleName":          d.RuleName,
+		"ruleSeverity":      string(d.RuleSeverity),
+		"runbookUrl":        d.RunbookUrl,
+		"tip":               d.Tip,
+	}
+	if !d.Timestamp.IsZero() {
+		c["timestamp"] = d.Timestamp.UTC().Format(time.RFC1123)
+	}
+	return c
+}
+
+func stringMap(m map[string]string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// RenderTemplate parses and renders a template.
+func RenderTemplate(text string, data *TemplateData) (string, error) {
+	t, err := ParseTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(data)
+}
+
+// Render renders the template with the data. It fails on unknown
+// variables, reporting all of them, while known ones without a value, such
+// as a dimension the data lacks, render empty. Double and triple braces both render values as
+// they are.
+func (t *Template) Render(data *TemplateData) (string, error) {
+	if errs := t.UnknownVariables(nil); len(errs) > 0 {
+		return "", joinTemplateErrors(errs)
+	}
+	var b strings.Builder
+	renderNodes(&b, t.nodes, &templateScope{value: data.context()})
+	return b.String(), nil
+}
+
+// templateScope is the context of variables, which each and with blocks
+// change.
+type templateScope struct {
+	value  any
+	parent *templateScope
+	key    string
+	index  int
+}
+
+func (s *templateScope) lookup(name string) any {
+	for strings.HasPrefix(name, "../") && s.parent != nil {
+		name, s = name[3:], s.parent
+	}
+	switch name {
+	case "this", ".":
+		return s.value
+	case "@key":
+		return s.key
+	case "@index":
+		return s.index
+	}
+	v := s.value
+	for _, part := range strings.Split(strings.TrimPrefix(name, "this."), ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[part]
+	}
+	return v
+}
+
+func renderNodes(b *strings.Builder, nodes []*templateNode, s *templateScope) {
+	for _, n := range nodes {
+		switch {
+		case n.block == "":
+			if n.variable == "" {
+				b.WriteString(n.text)
+			} else {
+				b.WriteString(format(s.lookup(n.variable)))
+			}
+		case n.block == "if" || n.block == "notEmpty":
+			renderNodes(b, choose(n, truthy(s.lookup(n.variable))), s)
+		case n.block == "unless":
+			renderNodes(b, choose(n, !truthy(s.lookup(n.variable))), s)
+		case n.block == "with":
+			v := s.lookup(n.variable)
+			if !truthy(v) {
+				renderNodes(b, n.alt, s)
+				continue
+			}
+			renderNodes(b, n.body, &templateScope{value: v, parent: s})
+		case n.block == "each":
+			v := s.lookup(n.variable)
+			if !truthy(v) {
+				renderNodes(b, n.alt, s)
+				continue
+			}
+			switch v := v.(type) {
+			case map[string]any:
+				for i, k := range slices.Sorted(maps.Keys(v)) {
+					renderNodes(b, n.body, &templateScope{value: v[k], parent: s, key: k, index: i})
+				}
+			case []any:
+				for i, item := range v {
+					renderNodes(b, n.body, &templateScope{value: item, parent: s, index: i})
+				}
+			}
+		}
+	}
+}
+
+func choose(n *templateNode, cond bool) []*templateNode {
+	if cond {
+		return n.body
+	}
+	return n.alt
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case int:
+		return v != 0
+	case float64:
+		return v != 0
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// format returns the text of a value. Maps render their entries sorted by
+// key, as in "{host=a, region=b}".
+func format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case map[string]any:
+		entries := make([]string, 0, len(v))
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			entries = append(entries, k+"="+format(v[k]))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = format(item)
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprint(v)
+}
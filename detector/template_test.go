@@ -0,0 +1,147 @@
+package detector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/signalflow/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+
+	rule := &Rule{DetectLabel: "CPU high", Description: "The value of cpu is above 90", Severity: CRITICAL, RunbookUrl: "https://runbooks.example.com/cpu"}
+	inputs := map[string]any{"A": map[string]any{"value": 95.5, "key": map[string]any{"host": "web-1", "env": "prod"}}}
+	annotations := map[string]any{"team": "infra"}
+	anomalous := NewTemplateData(rule, &Event{
+		AnomalyState:     "ANOMALOUS",
+		DetectorName:     "CPU",
+		Inputs:           &inputs,
+		EventAnnotations: &annotations,
+		Timestamp:        time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC).UnixMilli(),
+	})
+	cleared := IncidentTemplateData(rule, &Incident{AnomalyState: "OK", DetectorName: "CPU", Severity: "Major", Inputs: &inputs})
+
+	for _, tc := range []struct {
+		name     string
+		template string
+		data     *TemplateData
+		want     string
+	}{
+		{
+			name:     "variables",
+			template: "{{ruleSeverity}} alert {{{ruleName}}} on {{detectorName}}: {{readableRule}} ({{runbookUrl}})",
+			data:     anomalous,
+			want:     "Critical alert CPU high on CPU: The value of cpu is above 90 (https://runbooks.example.com/cpu)",
+		},
+		{
+			name:     "paths",
+			template: "{{dimensions.host}} at {{inputs.A.value}}, {{event_annotations.team}}{{dimensions.missing}} at {{timestamp}}",
+			data:     anomalous,
+			want:     "web-1 at 95.5, infra at Sat, 17 Oct 2026 12:00:00 UTC",
+		},
+		{
+			name:     "if anomalous",
+			template: "{{#if anomalous}}Triggered{{else}}Cleared{{/if}} {{ruleSeverity}}",
+			data:     anomalous,
+			want:     "Triggered Critical",
+		},
+		{
+			name:     "if normal",
+			template: "{{#if anomalous}}Triggered{{else}}Cleared{{/if}} {{ruleSeverity}}{{timestamp}}",
+			data:     cleared,
+			want:     "Cleared Major",
+		},
+		{
+			name:     "unless and notEmpty",
+			template: "{{#unless normal}}!{{/unless}}{{#notEmpty dimensions.env}}[{{dimensions.env}}]{{/notEmpty}}{{#notEmpty tip}}{{tip}}{{else}}no tip{{/notEmpty}}",
+			data:     anomalous,
+			want:     "![prod]no tip",
+		},
+		{
+			name:     "each and with",
+			template: "{{! dimensions }}{{#each dimensions}}{{@key}}={{this}};{{/each}} {{#with inputs.A}}{{value}} {{../ruleName}}{{/with}}",
+			data:     anomalous,
+			want:     "env=prod;host=web-1; 95.5 CPU high",
+		},
+		{
+			name:     "whole dimensions",
+			template: "{{{dimensions}}}\n",
+			data:     anomalous,
+			want:     "{env=prod, host=web-1}\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderTemplate(tc.template, tc.data)
+			require.NoError(t, err, "Must render the template")
+			assert.Equal(t, tc.want, got, "Must render the template")
+		})
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "unclosed tag", template: "{{ruleName} on\n{{detectorName", want: `1:1: "{{ruleName} on" is not closed`},
+		{name: "unclosed block", template: "{{#if anomalous}}\n  {{#each dimensions}}{{/each}}", want: "1:1: {{#if}} is not closed"},
+		{name: "mismatched block", template: "{{#if anomalous}}{{/unless}}", want: "1:18: {{/unless}} does not close a block\n1:1: {{#if}} is not closed"},
+		{name: "else outside of a block", template: "a\nb {{else}}", want: "2:3: {{else}} is outside of a block"},
+		{name: "unknown block", template: "{{#repeat anomalous}}{{/repeat}}", want: "1:1: unknown block {{#repeat}}"},
+		{name: "block without a variable", template: "{{#if}}{{/if}}", want: "1:1: {{#if}} takes a variable"},
+		{name: "unknown variable", template: "{{#if anomalous}}{{severity}}{{/if}}", want: "1:18: unknown variable {{severity}}"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := RenderTemplate(tc.template, &TemplateData{})
+			var templateErr *TemplateError
+			require.ErrorAs(t, err, &templateErr, "Must report the problem of the template")
+			assert.Equal(t, tc.want, err.Error(), "Must report the problem of the template")
+		})
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseTemplate("{{#if anomalous}}{{#repeat x}}{{/repeat}}{{else}}{{else}}{{/unless}}\n{{#unless normal}} {{a b}} {{tip")
+	require.Error(t, err, "Must fail on the problems of the template")
+	var errs []*TemplateError
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var templateErr *TemplateError
+		require.ErrorAs(t, err, &templateErr, "Must join template errors")
+		errs = append(errs, templateErr)
+	}
+	assert.Equal(t, []*TemplateError{
+		{Pos: parse.Pos{Line: 1, Column: 18}, Msg: "unknown block {{#repeat}}"},
+		{Pos: parse.Pos{Line: 1, Column: 50}, Msg: "{{else}} is outside of a block"},
+		{Pos: parse.Pos{Line: 1, Column: 58}, Msg: "{{/unless}} does not close a block"},
+		{Pos: parse.Pos{Line: 2, Column: 20}, Msg: "unknown helper {{a b}}"},
+		{Pos: parse.Pos{Line: 2, Column: 28}, Msg: `"{{tip" is not closed`},
+		{Pos: parse.Pos{Line: 1, Column: 1}, Msg: "{{#if}} is not closed"},
+		{Pos: parse.Pos{Line: 2, Column: 1}, Msg: "{{#unless}} is not closed"},
+	}, errs, "Must report every problem of the template")
+}
+
+func TestUnknownVariables(t *testing.T) {
+	t.Parallel()
+
+	tmpl, err := ParseTemplate("{{ruleName}} {{inputs.A.value}} {{inputs.B.value}}\n{{#each dimensions}}{{anything}}{{/each}}{{event_annotations}}")
+	require.NoError(t, err, "Must parse the template")
+	assert.Equal(t, []*TemplateError{
+		{Pos: parse.Pos{Line: 1, Column: 33}, Msg: `{{inputs.B.value}} refers to "B", which the program does not define`},
+		{Pos: parse.Pos{Line: 2, Column: 42}, Msg: "unknown variable {{event_annotations}}"},
+	}, tmpl.UnknownVariables([]string{"A"}), "Must report the unknown variables")
+	assert.Len(t, tmpl.UnknownVariables(nil), 1, "Must accept any input without a program")
+}
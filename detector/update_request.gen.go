@@ -0,0 +1,45 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package detector
+
+import "encoding/json"
+
+// ToUpdateRequest returns the request updating the Detector to its current
+// state, without the fields the request cannot set: Id, Created, Creator,
+// LastUpdated, LastUpdatedBy, LabelResolutions, Locked, OverMTSLimit. The
+// request shares the slices, maps and pointers of o.
+func (o *Detector) ToUpdateRequest() *CreateUpdateDetectorRequest {
+	if o == nil {
+		return nil
+	}
+	req := &CreateUpdateDetectorRequest{
+		AuthorizedWriters:    o.AuthorizedWriters,
+		Description:          o.Description,
+		TimeZone:             o.TimeZone,
+		MaxDelay:             o.MaxDelay,
+		MinDelay:             o.MinDelay,
+		Name:                 o.Name,
+		PackageSpecification: o.PackageSpecification,
+		ProgramText:          o.ProgramText,
+		Rules:                o.Rules,
+		Tags:                 o.Tags,
+		Teams:                o.Teams,
+		VisualizationOptions: o.VisualizationOptions,
+		ParentDetectorId:     o.ParentDetectorId,
+		DetectorOrigin:       o.DetectorOrigin,
+	}
+	req.CustomProperties = jsonString(o.CustomProperties)
+	return req
+}
+
+// jsonString returns v encoded as JSON, or v itself if it is a string.
+func jsonString(v *interface{}) string {
+	if v == nil || *v == nil {
+		return ""
+	}
+	if s, ok := (*v).(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(*v)
+	return string(b)
+}
@@ -0,0 +1,364 @@
+// Package diff compares the desired state of SignalFx objects, as the
+// requests creating or updating them, with their actual state, as returned
+// by the API, and reports the differences as a list of changes addressed by
+// the path of the field in the API, such as
+//
+//	rules[1].notifications[0].channel: "#a" -> "#b"
+//
+// The comparison follows the API rather than Go: fields managed by the API
+// are ignored, as are the fields it sets by default when the desired request
+// leaves them out, a missing value equals its zero value, including null and
+// empty lists and objects, custom properties are compared as JSON, and
+// notifications are compared by their type and content.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// ManagedFields are the fields set by the API that Integration ignores.
+var ManagedFields = []string{"id", "created", "creator", "lastUpdated", "lastUpdatedBy"}
+
+// DetectorDefaults are the fields of detectors the API sets when requests
+// leave them out.
+var DetectorDefaults = []string{"detectorOrigin", "maxDelay", "minDelay", "packageSpecifications", "timezone", "visualizationOptions"}
+
+// ChartDefaults are the fields of charts the API sets when requests leave
+// them out, such as the defaults of the options of each type of chart.
+var ChartDefaults = []string{"options", "packageSpecifications"}
+
+// DashboardDefaults are the fields of dashboards the API sets when requests
+// leave them out. Dashboards without a group are put in a new one.
+var DashboardDefaults = []string{"chartDensity", "discoveryOptions", "filters", "groupId", "maxDelayOverride", "permissions"}
+
+// DashboardGroupDefaults are the fields of dashboard groups the API derives
+// from their dashboards.
+var DashboardGroupDefaults = []string{"dashboards", "dashboardConfigs"}
+
+// TeamDefaults are the fields of teams the API derives from the detectors
+// and dashboard groups linked to them.
+var TeamDefaults = []string{"dashboardGroups", "detectors"}
+
+// Change is a difference between the actual and the desired value of a
+// field. A nil value means that the field is missing or has its zero value.
+type Change struct {
+	// Path of the field, using the names of the API.
+	Path string
+	From any
+	To   any
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, format(c.From), format(c.To))
+}
+
+// Changes lists the changes turning an object into the desired one, ordered
+// by path. It is empty when the object is up to date.
+type Changes []Change
+
+// String returns the changes one per line.
+func (cs Changes) String() string {
+	lines := make([]string, len(cs))
+	for i, c := range cs {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Except returns the changes other than those clearing the defaulted
+// fields, or their subfields, which the API sets when requests leave them
+// out. Defaulted fields are thus only compared where the desired request
+// sets them.
+func (cs Changes) Except(defaulted []string) Changes {
+	var kept Changes
+	for _, c := range cs {
+		if c.To == nil && slices.ContainsFunc(defaulted, func(field string) bool {
+			return c.Path == field || strings.HasPrefix(c.Path, field+".") || strings.HasPrefix(c.Path, field+"[")
+		}) {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// Detector returns the changes updating the actual detector to the desired
+// one, except for the DetectorDefaults.
+func Detector(desired *detector.CreateUpdateDetectorRequest, actual *detector.Detector) Changes {
+	return Values(desired, actual.ToUpdateRequest()).Except(DetectorDefaults)
+}
+
+// Chart returns the changes updating the actual chart to the desired one,
+// except for the ChartDefaults.
+func Chart(desired *chart.CreateUpdateChartRequest, actual *chart.Chart) Changes {
+	return Values(desired, actual.ToUpdateRequest()).Except(ChartDefaults)
+}
+
+// Dashboard returns the changes updating the actual dashboard to the desired
+// one, except for the DashboardDefaults.
+func Dashboard(desired *dashboard.CreateUpdateDashboardRequest, actual *dashboard.Dashboard) Changes {
+	return Values(desired, actual.ToUpdateRequest()).Except(DashboardDefaults)
+}
+
+// DashboardGroup returns the changes updating the actual dashboard group to
+// the desired one, except for the DashboardGroupDefaults.
+func DashboardGroup(desired *dashboard_group.CreateUpdateDashboardGroupRequest, actual *dashboard_group.DashboardGroup) Changes {
+	return Values(desired, actual.ToUpdateRequest()).Except(DashboardGroupDefaults)
+}
+
+// Team returns the changes updating the actual team to the desired one,
+// except for the TeamDefaults.
+func Team(desired *team.CreateUpdateTeamRequest, actual *team.Team) Changes {
+	return Values(desired, actual.ToUpdateRequest()).Except(TeamDefaults)
+}
+
+// Integration returns the changes updating the actual integration to the
+// desired one, both of the same type of the integration package, ignoring
+// the ManagedFields.
+func Integration(desired, actual any) Changes {
+	from, _ := tree(reflect.ValueOf(actual), "").(map[string]any)
+	to, _ := tree(reflect.ValueOf(desired), "").(map[string]any)
+	for _, field := range ManagedFields {
+		delete(from, field)
+		delete(to, field)
+	}
+	var changes Changes
+	compare(&changes, "", nilIfEmpty(from), nilIfEmpty(to))
+	return changes
+}
+
+// Values returns the changes turning the actual value into the desired one,
+// comparing them as they are encoded in JSON.
+func Values(desired, actual any) Changes {
+	var changes Changes
+	compare(&changes, "", tree(reflect.ValueOf(actual), ""), tree(reflect.ValueOf(desired), ""))
+	return changes
+}
+
+func compare(changes *Changes, path string, from, to any) {
+	switch f := from.(type) {
+	case map[string]any:
+		if t, ok := to.(map[string]any); ok {
+			keys := make([]string, 0, len(f)+len(t))
+			for k := range f {
+				keys = append(keys, k)
+			}
+			for k := range t {
+				if _, ok := f[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				compare(changes, join(path, k), f[k], t[k])
+			}
+			return
+		}
+	case []any:
+		if t, ok := to.([]any); ok {
+			for i := range max(len(f), len(t)) {
+				var fi, ti any
+				if i < len(f) {
+					fi = f[i]
+				}
+				if i < len(t) {
+					ti = t[i]
+				}
+				compare(changes, fmt.Sprintf("%s[%d]", path, i), fi, ti)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, Change{Path: path, From: from, To: to})
+	}
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func join(path, key string) string {
+	if !identifier.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func format(v any) string {
+	if v == nil {
+		return "null"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+var (
+	marshalerType    = reflect.TypeFor[json.Marshaler]()
+	notificationType = reflect.TypeFor[notification.Notification]()
+)
+
+// tree returns v as decoded from its JSON encoding, with numbers as float64,
+// and without its zero values, which are nil. name is the JSON name of the
+// field holding v.
+func tree(v reflect.Value, name string) any {
+	if !v.IsValid() {
+		return nil
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == notificationType {
+		n := v.Interface().(notification.Notification)
+		return notificationTree(&n)
+	}
+	if reflect.PointerTo(v.Type()).Implements(marshalerType) {
+		// Methods with a pointer receiver need an addressable value.
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return decoded(p.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m := map[string]any{}
+		structTree(v, m)
+		return nilIfEmpty(m)
+	case reflect.Map:
+		m := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			if e := tree(iter.Value(), ""); e != nil {
+				m[fmt.Sprint(iter.Key().Interface())] = e
+			}
+		}
+		return nilIfEmpty(m)
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil
+		}
+		s := make([]any, v.Len())
+		for i := range v.Len() {
+			s[i] = tree(v.Index(i), "")
+		}
+		return s
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+		// Custom properties are JSON objects sent as strings.
+		if name == "customProperties" {
+			var properties map[string]any
+			if json.Unmarshal([]byte(v.String()), &properties) == nil {
+				return tree(reflect.ValueOf(properties), "")
+			}
+		}
+		return v.String()
+	case reflect.Bool:
+		if !v.Bool() {
+			return nil
+		}
+		return true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() == 0 {
+			return nil
+		}
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() == 0 {
+			return nil
+		}
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		if v.Float() == 0 {
+			return nil
+		}
+		return v.Float()
+	}
+	return decoded(v.Interface())
+}
+
+// structTree adds the fields of a struct to m under their JSON names.
+func structTree(v reflect.Value, m map[string]any) {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Anonymous && name == "" {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				structTree(fv, m)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if e := tree(fv, name); e != nil {
+			m[name] = e
+		}
+	}
+}
+
+// notificationTree returns a notification with the type of the Notification
+// when its value has none, so that notifications built in Go compare equal
+// to the ones decoded from the API.
+func notificationTree(n *notification.Notification) any {
+	m, _ := tree(reflect.ValueOf(n.Value), "").(map[string]any)
+	if m == nil {
+		m = map[string]any{}
+	}
+	if _, ok := m["type"]; !ok && n.Type != "" {
+		m["type"] = n.Type
+	}
+	return nilIfEmpty(m)
+}
+
+// decoded returns the tree of a value encoded by its own MarshalJSON method.
+func decoded(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var d any
+	if err := json.Unmarshal(b, &d); err != nil {
+		return string(b)
+	}
+	return tree(reflect.ValueOf(d), "")
+}
+
+func nilIfEmpty(m map[string]any) any {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
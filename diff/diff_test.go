@@ -0,0 +1,177 @@
+package diff
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const actualDetector = `{
+	"id": "D1",
+	"created": 1,
+	"creator": "U1",
+	"lastUpdated": 2,
+	"lastUpdatedBy": "U1",
+	"name": "cpu",
+	"programText": "detect(when(data('cpu') > 90)).publish('CPU')",
+	"customProperties": {"owner": "pipeline", "tier": 1},
+	"tags": [],
+	"teams": null,
+	"rules": [
+		{"detectLabel": "CPU", "severity": "Critical", "notifications": [{"type": "Email", "email": "a@example.com"}]},
+		{"detectLabel": "CPU", "severity": "Warning", "notifications": [{"type": "Slack", "credentialId": "C1", "channel": "#a"}]}
+	]
+}`
+
+func decodeDetector(t *testing.T) *detector.Detector {
+	var d detector.Detector
+	require.NoError(t, json.Unmarshal([]byte(actualDetector), &d), "Must decode the detector")
+	return &d
+}
+
+func desiredDetector() *detector.CreateUpdateDetectorRequest {
+	return &detector.CreateUpdateDetectorRequest{
+		Name:             "cpu",
+		ProgramText:      "detect(when(data('cpu') > 90)).publish('CPU')",
+		CustomProperties: `{"tier":1,"owner":"pipeline"}`,
+		Rules: []*detector.Rule{
+			{
+				DetectLabel: "CPU",
+				Severity:    detector.CRITICAL,
+				Notifications: []*notification.Notification{
+					{Type: "Email", Value: &notification.EmailNotification{Email: "a@example.com"}},
+				},
+			},
+			{
+				DetectLabel: "CPU",
+				Severity:    detector.WARNING,
+				Notifications: []*notification.Notification{
+					{Type: "Slack", Value: notification.SlackNotification{Type: "Slack", CredentialId: "C1", Channel: "#a"}},
+				},
+			},
+		},
+	}
+}
+
+func TestDetector(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, Detector(desiredDetector(), decodeDetector(t)), "Must ignore managed fields, empty values and the representation of notifications")
+
+	desired := desiredDetector()
+	desired.Rules[1].Notifications[0].Value = &notification.SlackNotification{Type: "Slack", CredentialId: "C1", Channel: "#b"}
+	desired.Tags = []string{"team:payments"}
+	desired.CustomProperties = `{"owner":"pipeline"}`
+
+	changes := Detector(desired, decodeDetector(t))
+	assert.Equal(t, Changes{
+		{Path: "customProperties.tier", From: float64(1), To: nil},
+		{Path: "rules[1].notifications[0].channel", From: "#a", To: "#b"},
+		{Path: "tags", From: nil, To: []any{"team:payments"}},
+	}, changes, "Must list the changes by path")
+	assert.Equal(t, `customProperties.tier: 1 -> null
+rules[1].notifications[0].channel: "#a" -> "#b"
+tags: null -> ["team:payments"]`, changes.String(), "Must print the changes")
+}
+
+// withoutFields returns a copy of the request without the fields.
+func withoutFields[T any](t *testing.T, req *T, fields []string) *T {
+	b, err := json.Marshal(req)
+	require.NoError(t, err, "Must encode the request")
+	m := map[string]any{}
+	require.NoError(t, json.Unmarshal(b, &m), "Must decode the request")
+	for _, field := range fields {
+		delete(m, field)
+	}
+	b, err = json.Marshal(m)
+	require.NoError(t, err, "Must encode the request")
+	out := new(T)
+	require.NoError(t, json.Unmarshal(b, out), "Must decode the request")
+	return out
+}
+
+func readFixture(t *testing.T, path string, v any) {
+	b, err := os.ReadFile(filepath.Join("..", "testdata", "fixtures", path))
+	require.NoError(t, err, "Must read %s", path)
+	require.NoError(t, json.Unmarshal(b, v), "Must decode %s", path)
+}
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+
+	var d detector.Detector
+	readFixture(t, "detector/get_success.json", &d)
+	desired := withoutFields(t, d.ToUpdateRequest(), DetectorDefaults)
+	assert.Empty(t, Detector(desired, &d), "Must ignore the defaults of detectors left out of the request")
+	desired.DetectorOrigin = "AutoDetect"
+	assert.Equal(t, Changes{
+		{Path: "detectorOrigin", From: "Standard", To: "AutoDetect"},
+	}, Detector(desired, &d), "Must compare the defaults set by the request")
+
+	var ch chart.Chart
+	readFixture(t, "chart/get_success.json", &ch)
+	assert.Empty(t, Chart(withoutFields(t, ch.ToUpdateRequest(), ChartDefaults), &ch), "Must ignore the defaults of charts left out of the request")
+
+	var dash dashboard.Dashboard
+	readFixture(t, "dashboard/get_success.json", &dash)
+	assert.Empty(t, Dashboard(withoutFields(t, dash.ToUpdateRequest(), DashboardDefaults), &dash), "Must ignore the defaults of dashboards left out of the request")
+
+	var group dashboard_group.DashboardGroup
+	readFixture(t, "dashboardgroup/get_success.json", &group)
+	assert.Empty(t, DashboardGroup(withoutFields(t, group.ToUpdateRequest(), DashboardGroupDefaults), &group), "Must ignore the fields of dashboard groups derived from their dashboards")
+
+	var tm team.Team
+	readFixture(t, "team/get_success.json", &tm)
+	assert.Empty(t, Team(withoutFields(t, tm.ToUpdateRequest(), TeamDefaults), &tm), "Must ignore the fields of teams derived from their links")
+}
+
+func TestAddedElement(t *testing.T) {
+	t.Parallel()
+
+	desired := desiredDetector()
+	desired.Rules = desired.Rules[:1]
+
+	changes := Detector(desired, decodeDetector(t))
+	require.Len(t, changes, 1, "Must report the removed rule as one change")
+	assert.Equal(t, "rules[1]", changes[0].Path, "Must address the rule")
+	assert.Nil(t, changes[0].To, "Must remove the rule")
+}
+
+func TestTeam(t *testing.T) {
+	t.Parallel()
+
+	actual := &team.Team{Id: "T1", Name: "payments", Members: []string{"A"}}
+	assert.Empty(t, Team(&team.CreateUpdateTeamRequest{Name: "payments", Members: []string{"A"}}, actual), "Must ignore the id")
+	assert.Equal(t, Changes{{Path: "members[1]", From: nil, To: "B"}},
+		Team(&team.CreateUpdateTeamRequest{Name: "payments", Members: []string{"A", "B"}}, actual), "Must address list elements")
+}
+
+func TestIntegration(t *testing.T) {
+	t.Parallel()
+
+	actual := &integration.SlackIntegration{Id: "I1", Created: 1, Name: "slack", Type: "Slack", Enabled: true, WebhookUrl: "https://hooks.slack.com/a"}
+	desired := &integration.SlackIntegration{Name: "slack", Type: "Slack", Enabled: true, WebhookUrl: "https://hooks.slack.com/a"}
+	assert.Empty(t, Integration(desired, actual), "Must ignore managed fields")
+
+	desired.Enabled = false
+	assert.Equal(t, Changes{{Path: "enabled", From: true, To: nil}}, Integration(desired, actual), "Must report the disabled integration")
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, Values(map[string]any{"a": "", "b": []string{}, "c": map[string]int{}, "d": false, "e": 0}, nil), "Must treat zero values as missing")
+	assert.Equal(t, Changes{{Path: `labels["a.b"]`, From: "x", To: "y"}},
+		Values(map[string]any{"labels": map[string]string{"a.b": "y"}}, map[string]any{"labels": map[string]string{"a.b": "x"}}), "Must quote keys that are not identifiers")
+}
@@ -0,0 +1,173 @@
+package signalfx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dryRunHeader marks the responses synthesized in dry run mode, which
+// satisfy any expected status code.
+const dryRunHeader = "X-Signalfx-Go-Dry-Run"
+
+// readOnlyPosts are the POST endpoints that do not change anything and are
+// therefore still sent in dry run mode.
+var readOnlyPosts = []string{
+	APMTopologyURL,
+	MetricRulesetApiURL + "/generateAggregationMetricName",
+	SessionTokenAPIURL,
+}
+
+// PlannedRequest is a mutating request captured in dry run mode.
+type PlannedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  url.Values      `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+func (p PlannedRequest) String() string {
+	s := p.Method + " " + p.Path
+	if len(p.Query) > 0 {
+		s += "?" + p.Query.Encode()
+	}
+	if len(p.Body) > 0 {
+		s += " " + string(p.Body)
+	}
+	return s
+}
+
+type dryRun struct {
+	mu   sync.Mutex
+	plan []PlannedRequest
+	ids  int
+}
+
+// DryRun makes the client capture POST, PUT and DELETE requests into a plan,
+// available from Plan, instead of sending them. Other requests are still
+// sent. Create and update methods return the requested object, completed
+// with a placeholder id and timestamps, so that scripts can carry on.
+func DryRun() ClientParam {
+	return func(client *Client) error {
+		client.dryRun = &dryRun{}
+		return nil
+	}
+}
+
+// Plan returns the requests captured so far in dry run mode, in the order
+// they were made.
+func (c *Client) Plan() []PlannedRequest {
+	if c.dryRun == nil {
+		return nil
+	}
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	return append([]PlannedRequest(nil), c.dryRun.plan...)
+}
+
+// captures reports whether a request is captured rather than sent.
+func (d *dryRun) captures(method, path string) bool {
+	switch method {
+	case http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		if strings.HasSuffix(path, "/validate") {
+			return false
+		}
+		for _, p := range readOnlyPosts {
+			if path == p {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// capture records the request and synthesizes its response.
+func (d *dryRun) capture(req *http.Request, path string) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	d.mu.Lock()
+	d.plan = append(d.plan, PlannedRequest{
+		Method: req.Method,
+		Path:   path,
+		Query:  req.URL.Query(),
+		Body:   body,
+	})
+	d.mu.Unlock()
+
+	var respBody []byte
+	if req.Method != http.MethodDelete {
+		respBody = d.synthesize(req.Method, path, body)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{dryRunHeader: []string{"true"}, "Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(string(respBody))),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// synthesize builds the object the API would likely return: the request
+// body with an id and timestamps. Bodies that are not JSON objects are
+// returned as is.
+func (d *dryRun) synthesize(method, path string, body []byte) []byte {
+	obj := map[string]any{}
+	if json.Unmarshal(body, &obj) != nil {
+		return body
+	}
+
+	now := time.Now().UnixMilli()
+	switch method {
+	case http.MethodPost:
+		if _, ok := obj["id"]; !ok {
+			obj["id"] = d.newID()
+		}
+		obj["created"] = now
+	case http.MethodPut:
+		// Updates are made on `/v2/<resource>/<id>`.
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if _, ok := obj["id"]; !ok && len(segments) == 3 {
+			obj["id"] = segments[2]
+		}
+	}
+	obj["lastUpdated"] = now
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// newID returns placeholder ids that are easy to spot in a plan.
+func (d *dryRun) newID() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.ids++
+	return fmt.Sprintf("DRYRUN%05d", d.ids)
+}
+
+// isDryRun reports whether the response was synthesized in dry run mode.
+func isDryRun(resp *http.Response) bool {
+	return resp.Header.Get(dryRunHeader) != ""
+}
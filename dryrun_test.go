@@ -0,0 +1,65 @@
+package signalfx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	ctx := context.Background()
+
+	live, _ := NewClient(TestToken, APIUrl(fake.URL))
+	existing, err := live.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "existing"})
+	require.NoError(t, err, "Must create the detector")
+
+	c, _ := NewClient(TestToken, APIUrl(fake.URL), DryRun())
+
+	fetched, err := c.GetDetector(ctx, existing.Id)
+	require.NoError(t, err, "Must still send reads")
+	assert.Equal(t, "existing", fetched.Name, "Must return the stored detector")
+
+	group, err := c.CreateDashboardGroup(ctx, &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "group"}, true)
+	require.NoError(t, err, "Must synthesize the group")
+	assert.Equal(t, "DRYRUN00001", group.Id, "Must assign a placeholder id")
+	assert.Equal(t, "group", group.Name, "Must return the requested object")
+
+	dash, err := c.CreateDashboard(ctx, &dashboard.CreateUpdateDashboardRequest{Name: "dash", GroupId: group.Id})
+	require.NoError(t, err, "Must synthesize the dashboard")
+	assert.Equal(t, group.Id, dash.GroupId, "Must carry placeholder ids along")
+
+	updated, err := c.UpdateDetector(ctx, existing.Id, &detector.CreateUpdateDetectorRequest{Name: "renamed"})
+	require.NoError(t, err, "Must synthesize the update")
+	assert.Equal(t, existing.Id, updated.Id, "Must keep the id from the path")
+	assert.Equal(t, "renamed", updated.Name, "Must return the requested object")
+
+	require.NoError(t, c.DisableDetector(ctx, existing.Id, []string{"High"}), "Must accept any status")
+	require.NoError(t, c.DeleteDashboard(ctx, dash.Id), "Must capture deletes")
+	require.NoError(t, c.ValidateDetector(ctx, &detector.ValidateDetectorRequestModel{Name: "v"}), "Must still send validations")
+
+	plan := c.Plan()
+	require.Len(t, plan, 5, "Must capture every mutating request")
+	assert.Equal(t, http.MethodPost, plan[0].Method, "Must record the method")
+	assert.Equal(t, "/v2/dashboardgroup", plan[0].Path, "Must record the path")
+	assert.Equal(t, "true", plan[0].Query.Get("empty"), "Must record the query")
+	assert.JSONEq(t, `{"name":"group"}`, string(plan[0].Body), "Must record the body")
+	assert.Equal(t, `PUT /v2/detector/`+existing.Id+`/disable ["High"]`, plan[3].String(), "Must format the request")
+	assert.Equal(t, "DELETE /v2/dashboard/DRYRUN00002", plan[4].String(), "Must format requests without body")
+
+	fetched, err = live.GetDetector(ctx, existing.Id)
+	require.NoError(t, err, "Must get the detector")
+	assert.Equal(t, "existing", fetched.Name, "Must not send updates")
+	assert.Empty(t, fake.Objects(DashboardGroupAPIURL), "Must not send creations")
+	assert.Nil(t, live.Plan(), "Must not plan without dry run")
+}
@@ -0,0 +1,343 @@
+package signalfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/diff"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// ErrAmbiguousMatch is returned by the Ensure helpers when several objects
+// match the key, since they cannot tell which one to update.
+var ErrAmbiguousMatch = errors.New("several objects match")
+
+// EnsureOutcome reports what an Ensure helper did.
+type EnsureOutcome int
+
+const (
+	// Unchanged means that an object matching the request already existed.
+	Unchanged EnsureOutcome = iota
+	Created
+	Updated
+)
+
+// Changed reports whether the object was created or updated.
+func (o EnsureOutcome) Changed() bool {
+	return o != Unchanged
+}
+
+func (o EnsureOutcome) String() string {
+	switch o {
+	case Unchanged:
+		return "unchanged"
+	case Created:
+		return "created"
+	case Updated:
+		return "updated"
+	}
+	return fmt.Sprintf("EnsureOutcome(%d)", int(o))
+}
+
+// EnsureKey selects the existing object updated by an Ensure helper. The
+// zero value matches the objects having the name of the request.
+type EnsureKey struct {
+	// Tag matches the objects having this tag instead. The tag is added to
+	// the request.
+	Tag string
+	// Property and Value match the objects whose custom properties map
+	// Property to Value instead. The property is added to the request.
+	Property, Value string
+}
+
+func (k EnsureKey) byName() bool {
+	return k.Tag == "" && k.Property == ""
+}
+
+// ensurer is an Ensure helper for one type of object.
+type ensurer[Req, Obj any] struct {
+	key EnsureKey
+	// name of the request, for keys by name.
+	name       string
+	candidates func(ctx context.Context, filter SearchFilter) iter.Seq2[*Obj, error]
+	// matches reports whether an object has the key.
+	matches func(obj *Obj) bool
+	id      func(obj *Obj) string
+	// request converts an object to its update request.
+	request func(obj *Obj) *Req
+	// computed are the fields the API sets when the request leaves them
+	// out, which are not compared then, such as diff.DetectorDefaults.
+	computed []string
+	create   func(ctx context.Context, req *Req) (*Obj, error)
+	update   func(ctx context.Context, id string, req *Req) (*Obj, error)
+}
+
+// ensure creates the object of req if no object matches the key, or updates
+// the matching object if it differs from req.
+func (e ensurer[Req, Obj]) ensure(ctx context.Context, req *Req) (*Obj, EnsureOutcome, error) {
+	filter := SearchFilter{Tags: e.key.Tag}
+	if e.key.byName() {
+		if e.name == "" {
+			return nil, Unchanged, errors.New("the request has no name to match")
+		}
+		filter.Name = e.name
+	}
+
+	var found []*Obj
+	for obj, err := range e.candidates(ctx, filter) {
+		if err != nil {
+			return nil, Unchanged, err
+		}
+		if e.matches(obj) {
+			found = append(found, obj)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		obj, err := e.create(ctx, req)
+		return obj, Created, err
+	case 1:
+		if upToDate(e.request(found[0]), req, e.computed) {
+			return found[0], Unchanged, nil
+		}
+		obj, err := e.update(ctx, e.id(found[0]), req)
+		return obj, Updated, err
+	}
+	ids := make([]string, len(found))
+	for i, obj := range found {
+		ids[i] = e.id(obj)
+	}
+	return nil, Unchanged, fmt.Errorf("%w: %v", ErrAmbiguousMatch, ids)
+}
+
+// matchKey reports whether an object with the given name, tags and custom
+// properties has the key.
+func (k EnsureKey) matchKey(reqName, name string, tags []string, properties any) bool {
+	switch {
+	case k.Property != "":
+		return propertyValue(properties, k.Property) == k.Value
+	case k.Tag != "":
+		return slices.Contains(tags, k.Tag)
+	}
+	return name == reqName
+}
+
+// withTag returns tags with the tag of the key added.
+func (k EnsureKey) withTag(tags []string) []string {
+	if k.Tag == "" || slices.Contains(tags, k.Tag) {
+		return tags
+	}
+	return append(slices.Clone(tags), k.Tag)
+}
+
+// withProperty returns the custom properties, a JSON object, with the
+// property of the key set.
+func (k EnsureKey) withProperty(properties string) (string, error) {
+	if k.Property == "" {
+		return properties, nil
+	}
+	m := map[string]any{}
+	if properties != "" {
+		if err := json.Unmarshal([]byte(properties), &m); err != nil {
+			return "", fmt.Errorf("decoding custom properties: %w", err)
+		}
+	}
+	m[k.Property] = k.Value
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+// withPropertyMap returns the custom properties with the property of the
+// key set.
+func (k EnsureKey) withPropertyMap(properties map[string]any) map[string]any {
+	if k.Property == "" {
+		return properties
+	}
+	m := maps.Clone(properties)
+	if m == nil {
+		m = map[string]any{}
+	}
+	m[k.Property] = k.Value
+	return m
+}
+
+// propertyValue returns the value of a custom property, given the custom
+// properties of an object as a map or as a JSON object in a string.
+func propertyValue(properties any, key string) any {
+	switch p := properties.(type) {
+	case *any:
+		if p == nil {
+			return nil
+		}
+		return propertyValue(*p, key)
+	case string:
+		m := map[string]any{}
+		if json.Unmarshal([]byte(p), &m) != nil {
+			return nil
+		}
+		return m[key]
+	case map[string]any:
+		return p[key]
+	}
+	return nil
+}
+
+// upToDate reports whether the request of an existing object, current, is
+// the desired one. Fields the desired request leaves empty must be empty in
+// the object too, so that updates clear them, except for the computed
+// fields, which the API sets when requests leave them out.
+func upToDate(current, desired any, computed []string) bool {
+	return len(diff.Values(desired, current).Except(computed)) == 0
+}
+
+// EnsureDetector creates the detector of the request, or updates the
+// detector matching the key if it differs from the request. It fails with
+// ErrAmbiguousMatch if several detectors match.
+func (c *Client) EnsureDetector(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest, key EnsureKey) (*detector.Detector, EnsureOutcome, error) {
+	req := *detectorRequest
+	req.Tags = key.withTag(req.Tags)
+	properties, err := key.withProperty(req.CustomProperties)
+	if err != nil {
+		return nil, Unchanged, err
+	}
+	req.CustomProperties = properties
+
+	return ensurer[detector.CreateUpdateDetectorRequest, detector.Detector]{
+		key:        key,
+		name:       req.Name,
+		candidates: c.AllDetectors,
+		matches: func(d *detector.Detector) bool {
+			return key.matchKey(req.Name, d.Name, d.Tags, d.CustomProperties)
+		},
+		id:       func(d *detector.Detector) string { return d.Id },
+		request:  (*detector.Detector).ToUpdateRequest,
+		computed: diff.DetectorDefaults,
+		create:   c.CreateDetector,
+		update:   c.UpdateDetector,
+	}.ensure(ctx, &req)
+}
+
+// EnsureChart creates the chart of the request, or updates the chart
+// matching the key if it differs from the request.
+func (c *Client) EnsureChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest, key EnsureKey) (*chart.Chart, EnsureOutcome, error) {
+	req := *chartRequest
+	req.Tags = key.withTag(req.Tags)
+	properties, err := key.withProperty(req.CustomProperties)
+	if err != nil {
+		return nil, Unchanged, err
+	}
+	req.CustomProperties = properties
+
+	return ensurer[chart.CreateUpdateChartRequest, chart.Chart]{
+		key:        key,
+		name:       req.Name,
+		candidates: c.AllCharts,
+		matches: func(ch *chart.Chart) bool {
+			return key.matchKey(req.Name, ch.Name, ch.Tags, ch.CustomProperties)
+		},
+		id:       func(ch *chart.Chart) string { return ch.Id },
+		request:  (*chart.Chart).ToUpdateRequest,
+		computed: diff.ChartDefaults,
+		create:   c.CreateChart,
+		update:   c.UpdateChart,
+	}.ensure(ctx, &req)
+}
+
+// EnsureDashboard creates the dashboard of the request, or updates the
+// dashboard matching the key if it differs from the request.
+func (c *Client) EnsureDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, key EnsureKey) (*dashboard.Dashboard, EnsureOutcome, error) {
+	req := *dashboardRequest
+	req.Tags = key.withTag(req.Tags)
+	req.CustomProperties = key.withPropertyMap(req.CustomProperties)
+
+	return ensurer[dashboard.CreateUpdateDashboardRequest, dashboard.Dashboard]{
+		key:        key,
+		name:       req.Name,
+		candidates: c.AllDashboards,
+		matches: func(d *dashboard.Dashboard) bool {
+			return key.matchKey(req.Name, d.Name, d.Tags, d.CustomProperties)
+		},
+		id:       func(d *dashboard.Dashboard) string { return d.Id },
+		request:  (*dashboard.Dashboard).ToUpdateRequest,
+		computed: diff.DashboardDefaults,
+		create:   c.CreateDashboard,
+		update:   c.UpdateDashboard,
+	}.ensure(ctx, &req)
+}
+
+// EnsureDashboardGroup creates the dashboard group of the request, without
+// an implicit dashboard, or updates the group with the same name if it
+// differs from the request.
+func (c *Client) EnsureDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, EnsureOutcome, error) {
+	req := *dashboardGroupRequest
+
+	return ensurer[dashboard_group.CreateUpdateDashboardGroupRequest, dashboard_group.DashboardGroup]{
+		name:       req.Name,
+		candidates: c.AllDashboardGroups,
+		matches: func(g *dashboard_group.DashboardGroup) bool {
+			return g.Name == req.Name
+		},
+		id:       func(g *dashboard_group.DashboardGroup) string { return g.Id },
+		request:  (*dashboard_group.DashboardGroup).ToUpdateRequest,
+		computed: diff.DashboardGroupDefaults,
+		create: func(ctx context.Context, req *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error) {
+			return c.CreateDashboardGroup(ctx, req, true)
+		},
+		update: c.UpdateDashboardGroup,
+	}.ensure(ctx, &req)
+}
+
+// EnsureTeam creates the team of the request, or updates the team with the
+// same name if it differs from the request.
+func (c *Client) EnsureTeam(ctx context.Context, teamRequest *team.CreateUpdateTeamRequest) (*team.Team, EnsureOutcome, error) {
+	req := *teamRequest
+
+	return ensurer[team.CreateUpdateTeamRequest, team.Team]{
+		name:       req.Name,
+		candidates: c.AllTeams,
+		matches: func(t *team.Team) bool {
+			return t.Name == req.Name
+		},
+		id:       func(t *team.Team) string { return t.Id },
+		request:  (*team.Team).ToUpdateRequest,
+		computed: diff.TeamDefaults,
+		create:   c.CreateTeam,
+		update:   c.UpdateTeam,
+	}.ensure(ctx, &req)
+}
+
+// EnsureDataLink creates the data link of the request, or updates the data
+// link of the same property, value and context if it differs from the
+// request.
+func (c *Client) EnsureDataLink(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, EnsureOutcome, error) {
+	req := *dataLinkRequest
+	if req.PropertyName == "" && req.ContextId == "" {
+		return nil, Unchanged, errors.New("the request has no property or context to match")
+	}
+
+	return ensurer[datalink.CreateUpdateDataLinkRequest, datalink.DataLink]{
+		// Data links have no name, their key is their property.
+		name: req.PropertyName + req.ContextId,
+		candidates: func(ctx context.Context, _ SearchFilter) iter.Seq2[*datalink.DataLink, error] {
+			return c.AllDataLinks(ctx, SearchFilter{Context: req.ContextId})
+		},
+		matches: func(l *datalink.DataLink) bool {
+			return l.PropertyName == req.PropertyName && l.PropertyValue == req.PropertyValue && l.ContextId == req.ContextId
+		},
+		id:      func(l *datalink.DataLink) string { return l.Id },
+		request: (*datalink.DataLink).ToUpdateRequest,
+		create:  c.CreateDataLink,
+		update:  c.UpdateDataLink,
+	}.ensure(ctx, &req)
+}
@@ -0,0 +1,234 @@
+package signalfx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureDetector(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	req := &detector.CreateUpdateDetectorRequest{
+		Name:        "cpu high",
+		ProgramText: "detect(when(data('cpu') > 90)).publish('CPU')",
+		Tags:        []string{"team:payments"},
+	}
+
+	created, outcome, err := c.EnsureDetector(ctx, req, EnsureKey{})
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, Created, outcome, "Must report the creation")
+
+	again, outcome, err := c.EnsureDetector(ctx, req, EnsureKey{})
+	require.NoError(t, err, "Must find the detector")
+	assert.Equal(t, Unchanged, outcome, "Must not change an up to date detector")
+	assert.False(t, outcome.Changed(), "Must not report a change")
+	assert.Equal(t, created.Id, again.Id, "Must return the existing detector")
+
+	changed := *req
+	changed.ProgramText = "detect(when(data('cpu') > 95)).publish('CPU')"
+	updated, outcome, err := c.EnsureDetector(ctx, &changed, EnsureKey{})
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, Updated, outcome, "Must report the update")
+	assert.Equal(t, created.Id, updated.Id, "Must update the existing detector")
+	assert.Len(t, fake.Objects(DetectorAPIURL), 1, "Must not create duplicates")
+
+	// Fields left out of the request are cleared.
+	described := changed
+	described.Description = "CPU is high"
+	_, outcome, err = c.EnsureDetector(ctx, &described, EnsureKey{})
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, Updated, outcome, "Must set the description")
+	cleared, outcome, err := c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "cpu high", ProgramText: changed.ProgramText}, EnsureKey{})
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, Updated, outcome, "Must clear the fields left out")
+	assert.Empty(t, cleared.Description, "Must clear the description")
+	assert.Empty(t, cleared.Tags, "Must clear the tags")
+	_, outcome, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "cpu high", ProgramText: changed.ProgramText}, EnsureKey{})
+	require.NoError(t, err, "Must find the detector")
+	assert.Equal(t, Unchanged, outcome, "Must not change the cleared detector again")
+
+	// A detector whose name contains the other must not match.
+	_, outcome, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "cpu"}, EnsureKey{})
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, Created, outcome, "Must match names exactly")
+
+	_, _, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{}, EnsureKey{})
+	assert.Error(t, err, "Must require a name")
+}
+
+func TestEnsureDetectorByKey(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	byTag := EnsureKey{Tag: "managed-by:pipeline-cpu"}
+	first, outcome, err := c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "cpu"}, byTag)
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, Created, outcome, "Must report the creation")
+	assert.Contains(t, first.Tags, byTag.Tag, "Must tag the detector")
+
+	renamed, outcome, err := c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "cpu renamed"}, byTag)
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, Updated, outcome, "Must find the detector by tag")
+	assert.Equal(t, first.Id, renamed.Id, "Must rename the existing detector")
+
+	byProperty := EnsureKey{Property: "owner", Value: "pipeline"}
+	_, outcome, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "mem", CustomProperties: `{"tier":"1"}`}, byProperty)
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, Created, outcome, "Must report the creation")
+
+	_, outcome, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "mem", CustomProperties: `{"tier":"1"}`}, byProperty)
+	require.NoError(t, err, "Must find the detector")
+	assert.Equal(t, Unchanged, outcome, "Must find the detector by custom property")
+
+	_, _, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "disk"}, EnsureKey{Tag: "team:payments"})
+	require.NoError(t, err, "Must create the detector")
+	_, err = c.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "disk 2", Tags: []string{"team:payments"}})
+	require.NoError(t, err, "Must create the detector")
+	_, _, err = c.EnsureDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: "disk"}, EnsureKey{Tag: "team:payments"})
+	assert.ErrorIs(t, err, ErrAmbiguousMatch, "Must refuse to pick between several detectors")
+}
+
+func TestEnsureChartAndDashboardByProperty(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	key := EnsureKey{Property: "owner", Value: "pipeline"}
+	for i, tc := range []struct {
+		name string
+		want EnsureOutcome
+	}{
+		{name: "latency", want: Created},
+		{name: "latency", want: Unchanged},
+		{name: "latency renamed", want: Updated},
+	} {
+		ch, outcome, err := c.EnsureChart(ctx, &chart.CreateUpdateChartRequest{Name: tc.name, CustomProperties: `{"tier":"1"}`}, key)
+		require.NoError(t, err, "Must ensure the chart")
+		assert.Equal(t, tc.want, outcome, "Must find the chart by custom property in run %d", i)
+		assert.Equal(t, "pipeline", propertyValue(ch.CustomProperties, "owner"), "Must set the property of the key on the chart")
+		assert.Equal(t, "1", propertyValue(ch.CustomProperties, "tier"), "Must keep the other properties of the chart")
+
+		dash, outcome, err := c.EnsureDashboard(ctx, &dashboard.CreateUpdateDashboardRequest{Name: tc.name, CustomProperties: map[string]any{"tier": "1"}}, key)
+		require.NoError(t, err, "Must ensure the dashboard")
+		assert.Equal(t, tc.want, outcome, "Must find the dashboard by custom property in run %d", i)
+		assert.Equal(t, map[string]any{"owner": "pipeline", "tier": "1"}, dash.CustomProperties, "Must set the property of the key on the dashboard")
+	}
+
+	_, outcome, err := c.EnsureDashboard(ctx, &dashboard.CreateUpdateDashboardRequest{Name: "latency renamed"}, EnsureKey{Property: "owner", Value: "other"})
+	require.NoError(t, err, "Must ensure the dashboard")
+	assert.Equal(t, Created, outcome, "Must not match another value of the property")
+
+	assert.Len(t, fake.Objects(ChartAPIURL), 1, "Must not create duplicate charts")
+	assert.Len(t, fake.Objects(DashboardAPIURL), 2, "Must only create a dashboard per value")
+}
+
+// TestEnsureDefaults ensures objects shaped like the responses of the API,
+// with the fields it sets by default, which the fake server leaves out.
+func TestEnsureDefaults(t *testing.T) {
+	t.Parallel()
+
+	var updates atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		obj := fixture(strings.Split(r.URL.Path, "/")[2] + "/get_success.json")
+		if r.Method == http.MethodPut {
+			updates.Add(1)
+			fmt.Fprint(w, obj)
+			return
+		}
+		fmt.Fprintf(w, `{"count": 1, "results": [%s]}`, obj)
+	}))
+	defer server.Close()
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+	ctx := context.Background()
+
+	detectorReq := &detector.CreateUpdateDetectorRequest{}
+	require.NoError(t, json.Unmarshal([]byte(fixture("detector/get_success.json")), detectorReq), "Must decode the detector")
+	detectorReq.DetectorOrigin, detectorReq.MaxDelay, detectorReq.VisualizationOptions = "", nil, nil
+	_, outcome, err := c.EnsureDetector(ctx, detectorReq, EnsureKey{})
+	require.NoError(t, err, "Must ensure the detector")
+	assert.Equal(t, Unchanged, outcome, "Must ignore the defaults of detectors")
+
+	chartReq := &chart.CreateUpdateChartRequest{}
+	require.NoError(t, json.Unmarshal([]byte(fixture("chart/get_success.json")), chartReq), "Must decode the chart")
+	chartReq.Options = nil
+	_, outcome, err = c.EnsureChart(ctx, chartReq, EnsureKey{})
+	require.NoError(t, err, "Must ensure the chart")
+	assert.Equal(t, Unchanged, outcome, "Must ignore the default options of charts")
+
+	dashboardReq := &dashboard.CreateUpdateDashboardRequest{}
+	require.NoError(t, json.Unmarshal([]byte(fixture("dashboard/get_success.json")), dashboardReq), "Must decode the dashboard")
+	dashboardReq.GroupId, dashboardReq.ChartDensity, dashboardReq.Filters = "", "", nil
+	_, outcome, err = c.EnsureDashboard(ctx, dashboardReq, EnsureKey{})
+	require.NoError(t, err, "Must ensure the dashboard")
+	assert.Equal(t, Unchanged, outcome, "Must ignore the defaults of dashboards")
+
+	detectorReq.Description = ""
+	_, outcome, err = c.EnsureDetector(ctx, detectorReq, EnsureKey{})
+	require.NoError(t, err, "Must ensure the detector")
+	assert.Equal(t, Updated, outcome, "Must still clear the other fields")
+	assert.Equal(t, int32(1), updates.Load(), "Must only update the changed detector")
+}
+
+func TestEnsureOtherResources(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	for i, want := range []EnsureOutcome{Created, Unchanged} {
+		_, outcome, err := c.EnsureChart(ctx, &chart.CreateUpdateChartRequest{Name: "latency", ProgramText: "data('latency').publish()"}, EnsureKey{})
+		require.NoError(t, err, "Must ensure the chart")
+		assert.Equal(t, want, outcome, "Must report the outcome of run %d", i)
+
+		group, outcome, err := c.EnsureDashboardGroup(ctx, &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "payments"})
+		require.NoError(t, err, "Must ensure the group")
+		assert.Equal(t, want, outcome, "Must report the outcome of run %d", i)
+		assert.Empty(t, group.Dashboards, "Must not create an implicit dashboard")
+
+		_, outcome, err = c.EnsureTeam(ctx, &team.CreateUpdateTeamRequest{Name: "payments", Description: "Payments"})
+		require.NoError(t, err, "Must ensure the team")
+		assert.Equal(t, want, outcome, "Must report the outcome of run %d", i)
+
+		_, outcome, err = c.EnsureDataLink(ctx, &datalink.CreateUpdateDataLinkRequest{PropertyName: "service", PropertyValue: "checkout"})
+		require.NoError(t, err, "Must ensure the data link")
+		assert.Equal(t, want, outcome, "Must report the outcome of run %d", i)
+	}
+
+	_, outcome, err := c.EnsureTeam(ctx, &team.CreateUpdateTeamRequest{Name: "payments", Description: "Payments team"})
+	require.NoError(t, err, "Must ensure the team")
+	assert.Equal(t, Updated, outcome, "Must update the description")
+
+	for _, path := range []string{ChartAPIURL, DashboardGroupAPIURL, TeamAPIURL, DataLinkAPIURL} {
+		assert.Len(t, fake.Objects(path), 1, "Must not create duplicates in %s", path)
+	}
+}
@@ -1,6 +1,7 @@
 package signalfx
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -8,12 +9,43 @@ import (
 	"slices"
 )
 
+// Sentinel errors matched by a `ResponseError` with [errors.Is], based on
+// the status code returned by the API.
+var (
+	ErrNotFound     = errors.New("resource not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+)
+
+// requestIDHeaders are the response headers checked, in order, for an
+// identifier of the request to share with support.
+var requestIDHeaders = []string{"X-Request-Id", "X-Trace-Id"}
+
+// FieldError describes an issue with a single field of a request.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// errorBody is the JSON document sent by the API along with an error.
+type errorBody struct {
+	Code    int          `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
 // ResponseError captures the error details
 // and allows for it to be inspected by external libraries.
 type ResponseError struct {
-	code    int
-	route   string
-	details string
+	code      int
+	route     string
+	details   string
+	method    string
+	requestID string
+	body      *errorBody
 }
 
 var _ error = (*ResponseError)(nil)
@@ -23,17 +55,31 @@ func newResponseError(resp *http.Response, target int, targets ...int) error {
 		return nil
 	}
 
-	if slices.Contains(append([]int{target}, targets...), resp.StatusCode) {
+	if isDryRun(resp) || slices.Contains(append([]int{target}, targets...), resp.StatusCode) {
 		return nil
 	}
 
 	details, _ := io.ReadAll(resp.Body)
 
-	return &ResponseError{
+	re := &ResponseError{
 		code:    resp.StatusCode,
 		route:   resp.Request.URL.Path,
 		details: string(details),
+		method:  resp.Request.Method,
 	}
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); id != "" {
+			re.requestID = id
+			break
+		}
+	}
+
+	body := &errorBody{}
+	if json.Unmarshal(details, body) == nil && (body.Message != "" || len(body.Errors) > 0) {
+		re.body = body
+	}
+
+	return re
 }
 
 // AsResponseError is a convenience function to check the error
@@ -78,3 +124,51 @@ func (re *ResponseError) Route() string {
 func (re *ResponseError) Details() string {
 	return re.details
 }
+
+// Method returns the HTTP method of the failed request.
+func (re *ResponseError) Method() string {
+	return re.method
+}
+
+// RequestID returns the identifier the API assigned to the failed request,
+// if any. Include it when contacting support.
+func (re *ResponseError) RequestID() string {
+	return re.requestID
+}
+
+// Message returns the error message sent by the API, or an empty string when
+// the response did not contain a JSON error.
+func (re *ResponseError) Message() string {
+	if re.body == nil {
+		return ""
+	}
+	return re.body.Message
+}
+
+// FieldErrors returns the field level issues reported by the API.
+func (re *ResponseError) FieldErrors() []FieldError {
+	if re.body == nil {
+		return nil
+	}
+	return re.body.Errors
+}
+
+// Is reports whether the error matches one of the sentinel errors of this
+// package, allowing checks such as `errors.Is(err, signalfx.ErrNotFound)`.
+func (re *ResponseError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return re.code == http.StatusNotFound
+	case ErrUnauthorized:
+		return re.code == http.StatusUnauthorized
+	case ErrForbidden:
+		return re.code == http.StatusForbidden
+	case ErrConflict:
+		return re.code == http.StatusConflict
+	case ErrRateLimited:
+		return re.code == http.StatusTooManyRequests
+	case ErrValidation:
+		return re.code == http.StatusBadRequest || re.code == http.StatusUnprocessableEntity
+	}
+	return false
+}
@@ -161,3 +161,63 @@ func TestRequestErrorMethods(t *testing.T) {
 	assert.Equal(t, "/heathz", re.Route(), "Must match the expected route")
 	assert.Equal(t, "service alive", re.Details(), "Must match the expected details")
 }
+
+func TestResponseErrorIs(t *testing.T) {
+	t.Parallel()
+
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrConflict, ErrRateLimited, ErrValidation}
+
+	for _, tc := range []struct {
+		name   string
+		code   int
+		expect error
+	}{
+		{name: "not found", code: http.StatusNotFound, expect: ErrNotFound},
+		{name: "unauthorized", code: http.StatusUnauthorized, expect: ErrUnauthorized},
+		{name: "forbidden", code: http.StatusForbidden, expect: ErrForbidden},
+		{name: "conflict", code: http.StatusConflict, expect: ErrConflict},
+		{name: "rate limited", code: http.StatusTooManyRequests, expect: ErrRateLimited},
+		{name: "bad request", code: http.StatusBadRequest, expect: ErrValidation},
+		{name: "unprocessable", code: http.StatusUnprocessableEntity, expect: ErrValidation},
+		{name: "server error", code: http.StatusInternalServerError, expect: nil},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := fmt.Errorf("get detector: %w", &ResponseError{code: tc.code})
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tc.expect, errors.Is(err, sentinel), "Must match %v only when expected", sentinel)
+			}
+		})
+	}
+}
+
+func TestNewRequestErrorStructuredBody(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"X-Request-Id": []string{"req-123"}},
+		Request: &http.Request{
+			Method: http.MethodPost,
+			URL:    &url.URL{Host: "localhost", Path: "/v2/detector"},
+		},
+		Body: io.NopCloser(strings.NewReader(`{"code":400,"message":"Invalid detector","errors":[{"field":"name","message":"may not be empty"}]}`)),
+	}
+
+	re, ok := AsResponseError(newResponseError(resp, http.StatusOK))
+	if !assert.True(t, ok, "Must return a response error") {
+		return
+	}
+
+	assert.Equal(t, http.MethodPost, re.Method(), "Must match the request method")
+	assert.Equal(t, "req-123", re.RequestID(), "Must match the request id")
+	assert.Equal(t, "Invalid detector", re.Message(), "Must match the API message")
+	assert.Equal(t, []FieldError{{Field: "name", Message: "may not be empty"}}, re.FieldErrors(), "Must match the field errors")
+	assert.ErrorIs(t, re, ErrValidation, "Must be a validation error")
+
+	plain := &ResponseError{details: "not json"}
+	assert.Empty(t, plain.Message(), "Must not have a message without a JSON body")
+	assert.Nil(t, plain.FieldErrors(), "Must not have field errors without a JSON body")
+}
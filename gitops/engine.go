@@ -0,0 +1,245 @@
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/diff"
+)
+
+// Action is what a step does to an object.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Step is a change to one object.
+type Step struct {
+	Action Action
+	// Key of the manifest, such as `chart/latency`.
+	Key string
+	// ID of the object, empty for creations.
+	ID string
+	// Changes of an update. References to objects not created yet are
+	// shown as is.
+	Changes diff.Changes
+}
+
+func (s Step) String() string {
+	switch s.Action {
+	case Create:
+		return "+ " + s.Key
+	case Update:
+		return fmt.Sprintf("~ %s (%s)", s.Key, s.ID)
+	}
+	return fmt.Sprintf("- %s (%s)", s.Key, s.ID)
+}
+
+// Plan lists the steps turning the organization into the state of the
+// manifests, in the order they are applied.
+type Plan struct {
+	Steps []Step
+}
+
+// String returns the plan for humans, one step per line followed by its
+// changes.
+func (p *Plan) String() string {
+	if len(p.Steps) == 0 {
+		return "No changes."
+	}
+	var b strings.Builder
+	for _, s := range p.Steps {
+		b.WriteString(s.String() + "\n")
+		for _, c := range s.Changes {
+			b.WriteString("    " + c.String() + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Engine plans and applies the changes of a set of manifests.
+type Engine struct {
+	client    Client
+	state     *State
+	manifests []*Manifest
+	byKey     map[string]*Manifest
+}
+
+// New creates an engine for the manifests, whose objects are recorded in
+// state. It checks that the manifests are well formed and that their
+// references can be resolved in order.
+func New(client Client, manifests []*Manifest, state *State) (*Engine, error) {
+	e := &Engine{client: client, state: state, byKey: map[string]*Manifest{}}
+
+	var errs []error
+	for _, m := range manifests {
+		if _, ok := kinds[m.Kind]; !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown kind %q", m.Source, m.Kind))
+			continue
+		}
+		if m.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: missing name", m.Source))
+			continue
+		}
+		if other, ok := e.byKey[m.Key()]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s is already declared in %s", m.Source, m.Key(), other.Source))
+			continue
+		}
+		e.byKey[m.Key()] = m
+		e.manifests = append(e.manifests, m)
+	}
+	slices.SortStableFunc(e.manifests, func(a, b *Manifest) int {
+		return kindIndex(a.Kind) - kindIndex(b.Kind)
+	})
+
+	for _, m := range e.manifests {
+		for _, key := range refs(m.Spec) {
+			target, ok := e.byKey[key]
+			switch {
+			case !ok:
+				errs = append(errs, fmt.Errorf("%s: unknown reference to %s", m.Source, key))
+			case kindIndex(target.Kind) >= kindIndex(m.Kind):
+				errs = append(errs, fmt.Errorf("%s: %s cannot refer to %s, which is applied after it", m.Source, m.Key(), key))
+			}
+		}
+		if _, err := e.desired(m, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", m.Source, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func kindIndex(kind string) int {
+	return slices.Index(Kinds, kind)
+}
+
+// kindOfKey returns the kind of a key of the state.
+func kindOfKey(key string) (string, bool) {
+	prefix, _, _ := strings.Cut(key, "/")
+	for _, kind := range Kinds {
+		if strings.ToLower(kind) == prefix {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// desired returns the request of a manifest with its references resolved.
+// References to objects not created yet are an error if strict.
+func (e *Engine) desired(m *Manifest, strict bool) (any, error) {
+	spec, err := resolve(m.Spec, e.state.ID, !strict)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	req, err := kinds[m.Kind].decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding the spec of %s: %w", m.Key(), err)
+	}
+	return req, nil
+}
+
+// Plan compares the manifests with the objects of the organization. Objects
+// of the state without a manifest are deleted, and the ones deleted outside
+// of the engine are created again.
+func (e *Engine) Plan(ctx context.Context) (*Plan, error) {
+	plan := &Plan{}
+	for _, m := range e.manifests {
+		desired, err := e.desired(m, false)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := e.state.ID(m.Key())
+		if !ok {
+			plan.Steps = append(plan.Steps, Step{Action: Create, Key: m.Key()})
+			continue
+		}
+		changes, found, err := kinds[m.Kind].changes(ctx, e.client, id, desired)
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("reading %s (%s): %w", m.Key(), id, err)
+		case !found:
+			plan.Steps = append(plan.Steps, Step{Action: Create, Key: m.Key()})
+		case len(changes) > 0:
+			plan.Steps = append(plan.Steps, Step{Action: Update, Key: m.Key(), ID: id, Changes: changes})
+		}
+	}
+
+	var deleted []Step
+	for key, id := range e.state.Objects {
+		if _, ok := e.byKey[key]; ok {
+			continue
+		}
+		if _, ok := kindOfKey(key); !ok {
+			return nil, fmt.Errorf("unknown kind of %s in the state", key)
+		}
+		deleted = append(deleted, Step{Action: Delete, Key: key, ID: id})
+	}
+	slices.SortFunc(deleted, func(a, b Step) int {
+		ka, _ := kindOfKey(a.Key)
+		kb, _ := kindOfKey(b.Key)
+		if d := kindIndex(kb) - kindIndex(ka); d != 0 {
+			return d
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+	plan.Steps = append(plan.Steps, deleted...)
+	return plan, nil
+}
+
+// Apply runs the steps of a plan of the engine in order and records the
+// objects created and deleted in the state, which should be saved even when
+// Apply fails, since the steps before the failure took effect. References
+// are resolved again, to the objects created by the earlier steps.
+func (e *Engine) Apply(ctx context.Context, plan *Plan) error {
+	for _, step := range plan.Steps {
+		if err := e.apply(ctx, step); err != nil {
+			return fmt.Errorf("%s %s: %w", step.Action, step.Key, err)
+		}
+	}
+	return nil
+}
+
+func (e *Engine) apply(ctx context.Context, step Step) error {
+	if step.Action == Delete {
+		kind, _ := kindOfKey(step.Key)
+		err := kinds[kind].deleteObject(ctx, e.client, step.ID)
+		if err != nil && !errors.Is(err, signalfx.ErrNotFound) {
+			return err
+		}
+		delete(e.state.Objects, step.Key)
+		return nil
+	}
+
+	m, ok := e.byKey[step.Key]
+	if !ok {
+		return errors.New("no manifest")
+	}
+	desired, err := e.desired(m, true)
+	if err != nil {
+		return err
+	}
+	if step.Action == Update {
+		return kinds[m.Kind].updateObject(ctx, e.client, step.ID, desired)
+	}
+	id, err := kinds[m.Kind].createObject(ctx, e.client, desired)
+	if err != nil {
+		return err
+	}
+	e.state.Objects[step.Key] = id
+	return nil
+}
@@ -0,0 +1,267 @@
+// Package gitops manages SignalFx objects declared in a directory of
+// manifests. It plans the changes turning the organization into the declared
+// state, then applies them in dependency order. A state file maps the
+// manifests to the IDs of the objects they created.
+//
+// A manifest is a YAML or JSON document naming a kind, a local name and the
+// spec of the object, which is the body of its create and update requests:
+//
+//	kind: Dashboard
+//	name: payments-overview
+//	spec:
+//	  name: Payments overview
+//	  groupId: ${dashboardgroup.payments}
+//	  charts:
+//	    - chartId: ${chart.latency}
+//	      row: 0
+//	      column: 0
+//	      width: 6
+//	      height: 1
+//
+// A string of the form `${kind.name}` refers to the object of another
+// manifest and is replaced by its ID. Kinds are applied in the order of
+// Kinds, so a manifest may only refer to kinds listed before its own:
+// dashboards name their group, which is created without dashboards, rather
+// than dashboard groups listing their dashboards.
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/diff"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// Client is the part of the API used to manage the objects, implemented by
+// *signalfx.Client.
+type Client interface {
+	signalfx.TeamAPI
+	signalfx.ChartAPI
+	signalfx.DashboardAPI
+	signalfx.DashboardGroupAPI
+	signalfx.DetectorAPI
+	signalfx.AlertMutingRuleAPI
+	signalfx.DataLinkAPI
+}
+
+// Kinds are the kinds of objects of the manifests, in the order they are
+// created and updated. Deletions happen in the reverse order.
+var Kinds = []string{"Team", "Chart", "DashboardGroup", "Dashboard", "Detector", "AlertMutingRule", "DataLink"}
+
+// kind manages the objects of one kind through the client.
+type kind interface {
+	// decode returns the request of a spec encoded in JSON.
+	decode(spec []byte) (any, error)
+	// changes returns the changes turning the object into the desired one.
+	// found is false if the object does not exist.
+	changes(ctx context.Context, c Client, id string, desired any) (changes diff.Changes, found bool, err error)
+	createObject(ctx context.Context, c Client, desired any) (id string, err error)
+	updateObject(ctx context.Context, c Client, id string, desired any) error
+	deleteObject(ctx context.Context, c Client, id string) error
+}
+
+// resource is the kind of objects created from a Req and returned as an
+// Obj.
+type resource[Req, Obj any] struct {
+	get    func(c Client, ctx context.Context, id string) (*Obj, error)
+	create func(c Client, ctx context.Context, req *Req) (*Obj, error)
+	update func(c Client, ctx context.Context, id string, req *Req) (*Obj, error)
+	delete func(c Client, ctx context.Context, id string) error
+	id     func(obj *Obj) string
+	// request converts an object to its update request.
+	request func(obj *Obj) *Req
+	// computed are the fields the API sets when the spec leaves them out,
+	// such as diff.DetectorDefaults.
+	computed []string
+}
+
+var kinds = map[string]kind{
+	"Team": resource[team.CreateUpdateTeamRequest, team.Team]{
+		get:      Client.GetTeam,
+		create:   Client.CreateTeam,
+		update:   Client.UpdateTeam,
+		delete:   Client.DeleteTeam,
+		id:       func(t *team.Team) string { return t.Id },
+		request:  (*team.Team).ToUpdateRequest,
+		computed: diff.TeamDefaults,
+	},
+	"Chart": resource[chart.CreateUpdateChartRequest, chart.Chart]{
+		get:      Client.GetChart,
+		create:   Client.CreateChart,
+		update:   Client.UpdateChart,
+		delete:   Client.DeleteChart,
+		id:       func(ch *chart.Chart) string { return ch.Id },
+		request:  (*chart.Chart).ToUpdateRequest,
+		computed: diff.ChartDefaults,
+	},
+	"Dashboard": resource[dashboard.CreateUpdateDashboardRequest, dashboard.Dashboard]{
+		get:      Client.GetDashboard,
+		create:   Client.CreateDashboard,
+		update:   Client.UpdateDashboard,
+		delete:   Client.DeleteDashboard,
+		id:       func(d *dashboard.Dashboard) string { return d.Id },
+		request:  (*dashboard.Dashboard).ToUpdateRequest,
+		computed: diff.DashboardDefaults,
+	},
+	"DashboardGroup": resource[dashboard_group.CreateUpdateDashboardGroupRequest, dashboard_group.DashboardGroup]{
+		get: Client.GetDashboardGroup,
+		create: func(c Client, ctx context.Context, req *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error) {
+			return c.CreateDashboardGroup(ctx, req, true)
+		},
+		update:   Client.UpdateDashboardGroup,
+		delete:   Client.DeleteDashboardGroup,
+		id:       func(g *dashboard_group.DashboardGroup) string { return g.Id },
+		request:  (*dashboard_group.DashboardGroup).ToUpdateRequest,
+		computed: diff.DashboardGroupDefaults,
+	},
+	"Detector": resource[detector.CreateUpdateDetectorRequest, detector.Detector]{
+		get:      Client.GetDetector,
+		create:   Client.CreateDetector,
+		update:   Client.UpdateDetector,
+		delete:   Client.DeleteDetector,
+		id:       func(d *detector.Detector) string { return d.Id },
+		request:  (*detector.Detector).ToUpdateRequest,
+		computed: diff.DetectorDefaults,
+	},
+	"AlertMutingRule": resource[alertmuting.CreateUpdateAlertMutingRuleRequest, alertmuting.AlertMutingRule]{
+		get:     Client.GetAlertMutingRule,
+		create:  Client.CreateAlertMutingRule,
+		update:  Client.UpdateAlertMutingRule,
+		delete:  Client.DeleteAlertMutingRule,
+		id:      func(r *alertmuting.AlertMutingRule) string { return r.Id },
+		request: (*alertmuting.AlertMutingRule).ToUpdateRequest,
+	},
+	"DataLink": resource[datalink.CreateUpdateDataLinkRequest, datalink.DataLink]{
+		get:     Client.GetDataLink,
+		create:  Client.CreateDataLink,
+		update:  Client.UpdateDataLink,
+		delete:  Client.DeleteDataLink,
+		id:      func(l *datalink.DataLink) string { return l.Id },
+		request: (*datalink.DataLink).ToUpdateRequest,
+	},
+}
+
+func (r resource[Req, Obj]) decode(spec []byte) (any, error) {
+	var req Req
+	dec := json.NewDecoder(bytes.NewReader(spec))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (r resource[Req, Obj]) changes(ctx context.Context, c Client, id string, desired any) (diff.Changes, bool, error) {
+	obj, err := r.get(c, ctx, id)
+	if errors.Is(err, signalfx.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return diff.Values(desired, r.request(obj)).Except(r.computed), true, nil
+}
+
+func (r resource[Req, Obj]) createObject(ctx context.Context, c Client, desired any) (string, error) {
+	obj, err := r.create(c, ctx, desired.(*Req))
+	if err != nil {
+		return "", err
+	}
+	return r.id(obj), nil
+}
+
+func (r resource[Req, Obj]) updateObject(ctx context.Context, c Client, id string, desired any) error {
+	_, err := r.update(c, ctx, id, desired.(*Req))
+	return err
+}
+
+func (r resource[Req, Obj]) deleteObject(ctx context.Context, c Client, id string) error {
+	return r.delete(c, ctx, id)
+}
+
+// refPattern matches a reference to another manifest, `${kind.name}`, where
+// kind is lower case.
+var refPattern = regexp.MustCompile(`^\$\{([a-z]+)\.([^}]+)\}$`)
+
+// Key returns the key of an object in the state and in references, such as
+// `dashboardgroup/payments`.
+func Key(kind, name string) string {
+	return strings.ToLower(kind) + "/" + name
+}
+
+// parseRef returns the key referred to by s, if it is a reference.
+func parseRef(s string) (string, bool) {
+	m := refPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+	return m[1] + "/" + m[2], true
+}
+
+// refs returns the keys referred to in a spec.
+func refs(spec any) []string {
+	var keys []string
+	walk(spec, func(s string) string {
+		if key, ok := parseRef(s); ok {
+			keys = append(keys, key)
+		}
+		return s
+	})
+	return keys
+}
+
+// resolve returns the spec with the references replaced by the IDs of id.
+// References to unknown IDs are left as is when lenient, and are an error
+// otherwise.
+func resolve(spec any, id func(key string) (string, bool), lenient bool) (any, error) {
+	var err error
+	resolved := walk(spec, func(s string) string {
+		key, ok := parseRef(s)
+		if !ok {
+			return s
+		}
+		if v, ok := id(key); ok {
+			return v
+		}
+		if !lenient && err == nil {
+			err = fmt.Errorf("%s has no ID", key)
+		}
+		return s
+	})
+	return resolved, err
+}
+
+// walk returns a copy of v, decoded from YAML or JSON, with its strings
+// replaced by fn.
+func walk(v any, fn func(string) string) any {
+	switch v := v.(type) {
+	case string:
+		return fn(v)
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = walk(e, fn)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = walk(e, fn)
+		}
+		return s
+	}
+	return v
+}
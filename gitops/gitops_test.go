@@ -0,0 +1,277 @@
+package gitops_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/diff"
+	"github.com/signalfx/signalfx-go/gitops"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const teamManifest = `
+kind: Team
+name: payments
+spec:
+  name: Payments
+`
+
+const dashboardManifests = `
+kind: Chart
+name: latency
+spec:
+  name: Latency
+  programText: data('latency').publish()
+---
+kind: DashboardGroup
+name: payments
+spec:
+  name: Payments
+  teams: [ "${team.payments}" ]
+---
+kind: Dashboard
+name: overview
+spec:
+  name: Overview
+  groupId: ${dashboardgroup.payments}
+  charts:
+    - chartId: ${chart.latency}
+      width: 6
+      height: 1
+`
+
+const detectorManifest = `{
+	"kind": "Detector",
+	"name": "cpu",
+	"spec": {
+		"name": "CPU",
+		"programText": "detect(when(data('cpu') > 90)).publish('CPU')",
+		"teams": ["${team.payments}"],
+		"rules": [{"detectLabel": "CPU", "severity": "Critical"}]
+	}
+}`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if content == "" {
+			require.NoError(t, os.Remove(path), "Must remove %s", name)
+			continue
+		}
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "Must create the directory of %s", name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644), "Must write %s", name)
+	}
+}
+
+// run plans and applies the manifests of dir, returning the plan.
+func run(t *testing.T, c *signalfx.Client, dir, statePath string) *gitops.Plan {
+	ctx := context.Background()
+
+	manifests, err := gitops.Load(dir)
+	require.NoError(t, err, "Must load the manifests")
+	state, err := gitops.LoadState(statePath)
+	require.NoError(t, err, "Must load the state")
+	engine, err := gitops.New(c, manifests, state)
+	require.NoError(t, err, "Must accept the manifests")
+
+	plan, err := engine.Plan(ctx)
+	require.NoError(t, err, "Must plan the changes")
+	require.NoError(t, engine.Apply(ctx, plan), "Must apply the plan")
+	require.NoError(t, state.Save(statePath), "Must save the state")
+	return plan
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := signalfx.NewClient("token", signalfx.APIUrl(fake.URL))
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	writeFiles(t, dir, map[string]string{
+		"teams.yaml":          teamManifest,
+		"payments/dash.yml":   dashboardManifests,
+		"payments/cpu.json":   detectorManifest,
+		"payments/README.txt": "not a manifest",
+	})
+
+	plan := run(t, c, dir, statePath)
+	assert.Equal(t, `+ team/payments
++ chart/latency
++ dashboardgroup/payments
++ dashboard/overview
++ detector/cpu
+`, plan.String(), "Must create the objects in dependency order")
+
+	state, err := gitops.LoadState(statePath)
+	require.NoError(t, err, "Must load the state")
+	require.Len(t, state.Objects, 5, "Must record the objects")
+
+	dash, err := c.GetDashboard(ctx, state.Objects["dashboard/overview"])
+	require.NoError(t, err, "Must create the dashboard")
+	assert.Equal(t, state.Objects["chart/latency"], dash.Charts[0].ChartId, "Must resolve references to IDs")
+	assert.Equal(t, state.Objects["dashboardgroup/payments"], dash.GroupId, "Must create the dashboard in its group")
+	group, err := c.GetDashboardGroup(ctx, state.Objects["dashboardgroup/payments"])
+	require.NoError(t, err, "Must create the dashboard group")
+	assert.Equal(t, []string{dash.Id}, group.Dashboards, "Must only hold the declared dashboard")
+	assert.Len(t, fake.Objects(signalfx.DashboardGroupAPIURL), 1, "Must not create implicit dashboard groups")
+	det, err := c.GetDetector(ctx, state.Objects["detector/cpu"])
+	require.NoError(t, err, "Must create the detector")
+	assert.Equal(t, []string{state.Objects["team/payments"]}, det.Teams, "Must resolve references in lists")
+
+	assert.Equal(t, "No changes.", run(t, c, dir, statePath).String(), "Must not change up to date objects")
+
+	writeFiles(t, dir, map[string]string{
+		"payments/cpu.json": `{"kind": "Detector", "name": "cpu", "spec": {"name": "CPU", "programText": "detect(when(data('cpu') > 95)).publish('CPU')"}}`,
+		"payments/dash.yml": "",
+	})
+	plan = run(t, c, dir, statePath)
+	require.Len(t, plan.Steps, 4, "Must update the detector and delete the dashboard objects")
+	assert.Equal(t, gitops.Step{
+		Action: gitops.Update,
+		Key:    "detector/cpu",
+		ID:     state.Objects["detector/cpu"],
+		Changes: []diff.Change{
+			{Path: "programText", From: "detect(when(data('cpu') > 90)).publish('CPU')", To: "detect(when(data('cpu') > 95)).publish('CPU')"},
+			{Path: "rules", From: []any{map[string]any{"detectLabel": "CPU", "severity": "Critical"}}},
+			{Path: "teams", From: []any{state.Objects["team/payments"]}},
+		},
+	}, plan.Steps[0], "Must show the changes of the detector")
+	for i, key := range []string{"dashboard/overview", "dashboardgroup/payments", "chart/latency"} {
+		assert.Equal(t, gitops.Delete, plan.Steps[i+1].Action, "Must delete %s", key)
+		assert.Equal(t, key, plan.Steps[i+1].Key, "Must delete in reverse dependency order")
+	}
+	assert.Empty(t, fake.Objects(signalfx.ChartAPIURL), "Must delete the chart")
+	assert.Empty(t, fake.Objects(signalfx.DashboardGroupAPIURL), "Must delete the dashboard group")
+
+	state, err = gitops.LoadState(statePath)
+	require.NoError(t, err, "Must load the state")
+	assert.Len(t, state.Objects, 2, "Must forget the deleted objects")
+
+	// Objects deleted outside of the engine are created again.
+	require.NoError(t, c.DeleteTeam(ctx, state.Objects["team/payments"]), "Must delete the team")
+	plan = run(t, c, dir, statePath)
+	assert.Equal(t, "+ team/payments\n", plan.String(), "Must create the missing team")
+}
+
+func TestNewErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{name: "unknown kind", manifest: "kind: Widget\nname: w\n", expected: `unknown kind "Widget"`},
+		{name: "missing name", manifest: "kind: Team\nspec: {name: T}\n", expected: "missing name"},
+		{name: "duplicate", manifest: teamManifest + "---\n" + teamManifest, expected: "team/payments is already declared"},
+		{name: "unknown reference", manifest: "kind: Detector\nname: d\nspec:\n  teams: [ \"${team.search}\" ]\n", expected: "unknown reference to team/search"},
+		{name: "reference to a later kind", manifest: teamManifest + "---\nkind: Chart\nname: c\nspec:\n  description: ${detector.d}\n---\nkind: Detector\nname: d\n", expected: "chart/c cannot refer to detector/d"},
+		{name: "unknown field", manifest: "kind: Team\nname: t\nspec:\n  title: T\n", expected: `unknown field "title"`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			manifests, err := gitops.Parse([]byte(tc.manifest), "test.yaml")
+			require.NoError(t, err, "Must parse the manifests")
+			_, err = gitops.New(nil, manifests, gitops.NewState())
+			assert.ErrorContains(t, err, tc.expected, "Must reject the manifests")
+		})
+	}
+
+	_, err := gitops.Parse([]byte("kind: Team\nname: t\nlabels: {}\n"), "test.yaml")
+	assert.ErrorContains(t, err, "test.yaml#1", "Must reject unknown manifest fields")
+}
+
+// spec returns the request of the fixture of an object as a spec, without
+// the fields the API sets by default.
+func spec[Obj interface{ ToUpdateRequest() *Req }, Req any](t *testing.T, path string, defaults []string) json.RawMessage {
+	content, err := os.ReadFile(filepath.Join("..", "testdata", "fixtures", path))
+	require.NoError(t, err, "Must read %s", path)
+	var obj Obj
+	require.NoError(t, json.Unmarshal(content, &obj), "Must decode %s", path)
+	content, err = json.Marshal(obj.ToUpdateRequest())
+	require.NoError(t, err, "Must encode the request")
+	fields := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(content, &fields), "Must decode the request")
+	for _, field := range defaults {
+		delete(fields, field)
+	}
+	content, err = json.Marshal(fields)
+	require.NoError(t, err, "Must encode the spec")
+	return content
+}
+
+func TestPlanDefaults(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, err := os.ReadFile(filepath.Join("..", "testdata", "fixtures", strings.Split(r.URL.Path, "/")[2], "get_success.json"))
+		require.NoError(t, err, "Must read the fixture of %s", r.URL.Path)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+	c, _ := signalfx.NewClient("token", signalfx.APIUrl(server.URL))
+
+	var manifests []*gitops.Manifest
+	state := gitops.NewState()
+	for kind, content := range map[string]json.RawMessage{
+		"Detector":       spec[*detector.Detector](t, "detector/get_success.json", diff.DetectorDefaults),
+		"Chart":          spec[*chart.Chart](t, "chart/get_success.json", diff.ChartDefaults),
+		"Dashboard":      spec[*dashboard.Dashboard](t, "dashboard/get_success.json", diff.DashboardDefaults),
+		"DashboardGroup": spec[*dashboard_group.DashboardGroup](t, "dashboardgroup/get_success.json", diff.DashboardGroupDefaults),
+		"Team":           spec[*team.Team](t, "team/get_success.json", diff.TeamDefaults),
+	} {
+		m, err := gitops.Parse([]byte(fmt.Sprintf(`{"kind": %q, "name": "fixture", "spec": %s}`, kind, content)), kind+".json")
+		require.NoError(t, err, "Must parse the manifest of %s", kind)
+		manifests = append(manifests, m...)
+		state.Objects[m[0].Key()] = "string"
+	}
+	engine, err := gitops.New(c, manifests, state)
+	require.NoError(t, err, "Must accept the manifests")
+
+	plan, err := engine.Plan(context.Background())
+	require.NoError(t, err, "Must plan the changes")
+	assert.Equal(t, "No changes.", plan.String(), "Must ignore the fields the API sets by default")
+}
+
+func TestLoadStateNullObjects(t *testing.T) {
+	t.Parallel()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"version": 1, "objects": null}`), 0o644), "Must write the state")
+	state, err := gitops.LoadState(statePath)
+	require.NoError(t, err, "Must load the state")
+	require.NotNil(t, state.Objects, "Must initialise the objects")
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := signalfx.NewClient("token", signalfx.APIUrl(fake.URL))
+	manifests, err := gitops.Parse([]byte(teamManifest), "teams.yaml")
+	require.NoError(t, err, "Must parse the manifests")
+	engine, err := gitops.New(c, manifests, state)
+	require.NoError(t, err, "Must accept the manifests")
+	plan, err := engine.Plan(context.Background())
+	require.NoError(t, err, "Must plan the changes")
+	require.NoError(t, engine.Apply(context.Background(), plan), "Must apply the plan")
+	assert.Len(t, state.Objects, 1, "Must record the team")
+}
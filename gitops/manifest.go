@@ -0,0 +1,80 @@
+package gitops
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest declares an object.
+type Manifest struct {
+	// Kind is one of Kinds.
+	Kind string `yaml:"kind"`
+	// Name identifies the manifest among the ones of its kind. It is not the
+	// name of the object, which is part of the spec.
+	Name string `yaml:"name"`
+	// Spec is the body of the requests creating and updating the object.
+	Spec map[string]any `yaml:"spec"`
+	// Source is where the manifest was read from.
+	Source string `yaml:"-"`
+}
+
+// Key returns the key of the manifest in the state and in references.
+func (m *Manifest) Key() string {
+	return Key(m.Kind, m.Name)
+}
+
+// Load reads the manifests of the `.yaml`, `.yml` and `.json` files of a
+// directory and its subdirectories, in the order of their paths.
+func Load(dir string) ([]*Manifest, error) {
+	var manifests []*Manifest
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if !slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(path)) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		parsed, err := Parse(content, path)
+		if err != nil {
+			return err
+		}
+		manifests = append(manifests, parsed...)
+		return nil
+	})
+	return manifests, err
+}
+
+// Parse returns the manifests of the documents of content, a YAML stream or
+// a JSON object. source names the content in errors.
+func Parse(content []byte, source string) ([]*Manifest, error) {
+	var manifests []*Manifest
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	for i := 1; ; i++ {
+		m := &Manifest{Source: fmt.Sprintf("%s#%d", source, i)}
+		err := dec.Decode(m)
+		if errors.Is(err, io.EOF) {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", m.Source, err)
+		}
+		if m.Kind == "" && m.Name == "" && m.Spec == nil {
+			// An empty document, such as after a trailing `---`.
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+}
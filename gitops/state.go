@@ -0,0 +1,75 @@
+package gitops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const stateVersion = 1
+
+// State maps the keys of the manifests, such as `chart/latency`, to the IDs
+// of the objects they created.
+type State struct {
+	Version int               `json:"version"`
+	Objects map[string]string `json:"objects"`
+}
+
+// NewState returns an empty state.
+func NewState() *State {
+	return &State{Version: stateVersion, Objects: map[string]string{}}
+}
+
+// LoadState reads a state file, or returns an empty state if it does not
+// exist.
+func LoadState(path string) (*State, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewState(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := NewState()
+	if err := json.Unmarshal(content, s); err != nil {
+		return nil, fmt.Errorf("decoding state %s: %w", path, err)
+	}
+	if s.Objects == nil {
+		// The file may hold "objects": null.
+		s.Objects = map[string]string{}
+	}
+	if s.Version != stateVersion {
+		return nil, fmt.Errorf("state %s has version %d, expected %d", path, s.Version, stateVersion)
+	}
+	return s, nil
+}
+
+// Save writes the state to a file, replacing it atomically.
+func (s *State) Save(path string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ID returns the ID of the object of a key.
+func (s *State) ID(key string) (string, bool) {
+	id, ok := s.Objects[key]
+	return id, ok
+}
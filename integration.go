@@ -13,6 +13,10 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/signalfx/signalfx-go/integration"
 )
 
 // IntegrationAPIURL is the base URL for interacting with integrations.
@@ -30,6 +34,36 @@ func (c *Client) GetIntegration(ctx context.Context, id string) (map[string]inte
 	return out, nil
 }
 
+// SearchIntegrations searches for integrations given a query string in
+// `name` and their `integrationType`. Integrations are returned as maps,
+// since their fields depend on their type.
+func (c *Client) SearchIntegrations(ctx context.Context, limit int, name string, offset int, integrationType string) (*integration.SearchResults, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+	params.Add("name", name)
+	params.Add("offset", strconv.Itoa(offset))
+	params.Add("type", integrationType)
+
+	resp, err := c.doRequest(ctx, "GET", IntegrationAPIURL, params, nil)
+	if resp != nil {
+		//noinspection GoUnhandledErrorResult
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = newResponseError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	results := &integration.SearchResults{}
+	err = json.NewDecoder(resp.Body).Decode(results)
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	return results, err
+}
+
 // DeleteIntegration deletes an integration.
 func (c *Client) DeleteIntegration(ctx context.Context, id string) error {
 	return c.doIntegrationRequest(ctx, IntegrationAPIURL+"/"+id, "DELETE", http.StatusNoContent, nil, nil)
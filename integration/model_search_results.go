@@ -0,0 +1,9 @@
+package integration
+
+type SearchResults struct {
+	// Number of integrations that match the search query.
+	Count int32 `json:"count,omitempty"`
+	// The integrations that match the request criteria. Their fields depend
+	// on their `type`.
+	Results []map[string]interface{} `json:"results,omitempty"`
+}
@@ -0,0 +1,439 @@
+// Command apigen generates the per resource interfaces of the methods of
+// signalfx.Client, in api.gen.go, and their mock, in
+// signalfxmock/mock.gen.go. It is run from the root of the module by
+// `go generate`.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	rootPath = "github.com/signalfx/signalfx-go"
+
+	apiFile  = "api.gen.go"
+	mockFile = "signalfxmock/mock.gen.go"
+)
+
+// resource is a generated interface.
+type resource struct {
+	name string
+	// what completes the doc comment of the interface.
+	what string
+}
+
+var resources = []resource{
+	{"AlertMutingRuleAPI", "alert muting rules"},
+	{"APMAPI", "APM service topologies"},
+	{"AutomatedArchivalAPI", "automated archival settings"},
+	{"ChartAPI", "charts"},
+	{"DashboardAPI", "dashboards"},
+	{"DashboardGroupAPI", "dashboard groups"},
+	{"DataLinkAPI", "data links"},
+	{"DetectorAPI", "detectors"},
+	{"EmailTemplateAPI", "email templates"},
+	{"IncidentAPI", "incidents"},
+	{"IntegrationAPI", "integrations"},
+	{"MetricRulesetAPI", "metric rulesets"},
+	{"MetricsMetadataAPI", "metrics, dimensions, tags and time series metadata"},
+	{"NavigatorAPI", "navigators"},
+	{"OrganizationAPI", "the organization and its members"},
+	{"OrgTokenAPI", "org tokens"},
+	{"SessionTokenAPI", "session tokens"},
+	{"SLOAPI", "SLOs"},
+	{"TeamAPI", "teams"},
+}
+
+// files sets the interface of the methods of a source file. Every file
+// ending with `_integration.go` belongs to IntegrationAPI.
+var files = map[string]string{
+	"alertmuting.go":        "AlertMutingRuleAPI",
+	"apm.go":                "APMAPI",
+	"automated_archival.go": "AutomatedArchivalAPI",
+	"chart.go":              "ChartAPI",
+	"dashboard.go":          "DashboardAPI",
+	"dashboardgroup.go":     "DashboardGroupAPI",
+	"datalink.go":           "DataLinkAPI",
+	"detector.go":           "DetectorAPI",
+	"emailtemplate.go":      "EmailTemplateAPI",
+	"incident.go":           "IncidentAPI",
+	"integration.go":        "IntegrationAPI",
+	"metric_ruleset.go":     "MetricRulesetAPI",
+	"metrics_metadata.go":   "MetricsMetadataAPI",
+	"navigator.go":          "NavigatorAPI",
+	"organization.go":       "OrganizationAPI",
+	"orgtoken.go":           "OrgTokenAPI",
+	"sessiontoken.go":       "SessionTokenAPI",
+	"slo.go":                "SLOAPI",
+	"team.go":               "TeamAPI",
+}
+
+// methods sets the interface of the methods defined in files spanning
+// several resources.
+var methods = map[string]string{
+	"AllAlertMutingRules":    "AlertMutingRuleAPI",
+	"AllCharts":              "ChartAPI",
+	"AllDashboardGroups":     "DashboardGroupAPI",
+	"AllDashboards":          "DashboardAPI",
+	"AllDataLinks":           "DataLinkAPI",
+	"AllDetectors":           "DetectorAPI",
+	"AllDimensions":          "MetricsMetadataAPI",
+	"AllEmailTemplates":      "EmailTemplateAPI",
+	"AllIntegrations":        "IntegrationAPI",
+	"AllMetricRulesets":      "MetricRulesetAPI",
+	"AllMetricTimeSeries":    "MetricsMetadataAPI",
+	"AllMetrics":             "MetricsMetadataAPI",
+	"AllNavigators":          "NavigatorAPI",
+	"AllOrgTokens":           "OrgTokenAPI",
+	"AllOrganizationMembers": "OrganizationAPI",
+	"AllSlos":                "SLOAPI",
+	"AllTags":                "MetricsMetadataAPI",
+	"AllTeams":               "TeamAPI",
+	"EnsureChart":            "ChartAPI",
+	"EnsureDashboard":        "DashboardAPI",
+	"EnsureDashboardGroup":   "DashboardGroupAPI",
+	"EnsureDataLink":         "DataLinkAPI",
+	"EnsureDetector":         "DetectorAPI",
+	"EnsureTeam":             "TeamAPI",
+	"ModifyChart":            "ChartAPI",
+	"ModifyDashboard":        "DashboardAPI",
+	"ModifyDetector":         "DetectorAPI",
+	"ModifyMetricRuleset":    "MetricRulesetAPI",
+	"ModifyTeam":             "TeamAPI",
+}
+
+// ignored are the methods about the client itself rather than the API.
+var ignored = map[string]bool{
+	"Plan": true,
+}
+
+type method struct {
+	name string
+	doc  string
+	sig  *types.Signature
+}
+
+func main() {
+	out, err := generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, content := range out {
+		if err := os.WriteFile(name, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the content of the generated files, by path relative to
+// dir, the root of the module.
+func generate(dir string) (map[string][]byte, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("loading %s: found %d packages", rootPath, len(pkgs))
+	}
+	pkg := pkgs[0]
+	for _, e := range pkg.Errors {
+		// A stale api.gen.go must not prevent generating a new one.
+		if !strings.Contains(e.Pos, apiFile) {
+			return nil, fmt.Errorf("loading %s: %v", rootPath, pkg.Errors)
+		}
+	}
+
+	grouped, err := groupMethods(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := generateAPI(grouped)
+	if err != nil {
+		return nil, err
+	}
+	mock, err := generateMock(grouped)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{apiFile: api, filepath.FromSlash(mockFile): mock}, nil
+}
+
+// groupMethods returns the exported methods of Client by interface, in the
+// order of the source.
+func groupMethods(pkg *packages.Package) (map[string][]method, error) {
+	grouped := map[string][]method{}
+	var unassigned []string
+
+	for _, file := range pkg.Syntax {
+		filename := filepath.Base(pkg.Fset.Position(file.Pos()).Filename)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() || ignored[fn.Name.Name] || !isClient(fn.Recv) {
+				continue
+			}
+
+			iface := methods[fn.Name.Name]
+			if iface == "" {
+				iface = files[filename]
+			}
+			if iface == "" && strings.HasSuffix(filename, "_integration.go") {
+				iface = "IntegrationAPI"
+			}
+			if iface == "" {
+				unassigned = append(unassigned, filename+": "+fn.Name.Name)
+				continue
+			}
+
+			grouped[iface] = append(grouped[iface], method{
+				name: fn.Name.Name,
+				doc:  fn.Doc.Text(),
+				sig:  pkg.TypesInfo.Defs[fn.Name].Type().(*types.Signature),
+			})
+		}
+	}
+
+	if len(unassigned) > 0 {
+		return nil, fmt.Errorf("methods of Client missing from the tables of apigen:\n\t%s", strings.Join(unassigned, "\n\t"))
+	}
+	return grouped, nil
+}
+
+func isClient(recv *ast.FieldList) bool {
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Client"
+}
+
+func generateAPI(grouped map[string][]method) ([]byte, error) {
+	im := newImports("")
+	body := &bytes.Buffer{}
+
+	for _, res := range resources {
+		fmt.Fprintf(body, "\n// %s contains the methods of Client for %s.\n", res.name, res.what)
+		fmt.Fprintf(body, "type %s interface {\n", res.name)
+		for i, m := range grouped[res.name] {
+			if i > 0 {
+				body.WriteString("\n")
+			}
+			if doc := strings.TrimSpace(m.doc); doc != "" {
+				for _, line := range strings.Split(doc, "\n") {
+					fmt.Fprintf(body, "\t%s\n", strings.TrimRight("// "+line, " "))
+				}
+			}
+			params, results := signature(m.sig, im.qualifier)
+			fmt.Fprintf(body, "\t%s(%s) %s\n", m.name, strings.Join(params, ", "), results)
+		}
+		body.WriteString("}\n")
+	}
+
+	body.WriteString("\n// API contains every method of Client calling the API.\ntype API interface {\n")
+	for _, res := range resources {
+		fmt.Fprintf(body, "\t%s\n", res.name)
+	}
+	body.WriteString("}\n\nvar _ API = (*Client)(nil)\n")
+
+	return render("signalfx", im, body)
+}
+
+func generateMock(grouped map[string][]method) ([]byte, error) {
+	im := newImports("signalfx")
+	im.qualifier(types.NewPackage(rootPath, "signalfx"))
+	body := &bytes.Buffer{}
+
+	var all []method
+	for _, res := range resources {
+		all = append(all, grouped[res.name]...)
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].name < all[j].name })
+
+	body.WriteString("\n// Client is a mock of signalfx.Client. Each method calls the function of\n")
+	body.WriteString("// the field named after it, and fails with ErrNotMocked when it is nil.\n")
+	body.WriteString("type Client struct {\n")
+	for _, m := range all {
+		params, results := signature(m.sig, im.qualifier)
+		fmt.Fprintf(body, "\t%sFunc func(%s) %s\n", m.name, strings.Join(params, ", "), results)
+	}
+	body.WriteString("}\n\nvar _ signalfx.API = (*Client)(nil)\n")
+
+	for _, m := range all {
+		params, results := signature(m.sig, im.qualifier)
+		fmt.Fprintf(body, "\n// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(body, "func (m *Client) %s(%s) %s {\n", m.name, strings.Join(params, ", "), results)
+		fmt.Fprintf(body, "\tif m.%sFunc == nil {\n", m.name)
+		body.WriteString(notMocked(m, im.qualifier))
+		body.WriteString("\t}\n")
+		fmt.Fprintf(body, "\treturn m.%sFunc(%s)\n}\n", m.name, strings.Join(arguments(m.sig), ", "))
+	}
+
+	return render("signalfxmock", im, body)
+}
+
+// notMocked returns the statements run by a method without function.
+func notMocked(m method, qualifier types.Qualifier) string {
+	results := m.sig.Results()
+	if results.Len() == 1 {
+		if elem, ok := seq2Elem(results.At(0).Type()); ok {
+			return fmt.Sprintf("\t\treturn notMockedSeq[%s](%q)\n", types.TypeString(elem, qualifier), m.name)
+		}
+	}
+
+	var values []string
+	for i := range results.Len() {
+		t := results.At(i).Type()
+		if i == results.Len()-1 && types.Identical(t, types.Universe.Lookup("error").Type()) {
+			values = append(values, fmt.Sprintf("notMocked(%q)", m.name))
+			continue
+		}
+		values = append(values, zero(t, qualifier))
+	}
+	if len(values) == 0 || !strings.HasPrefix(values[len(values)-1], "notMocked(") {
+		return fmt.Sprintf("\t\tpanic(notMocked(%q))\n", m.name)
+	}
+	return "\t\treturn " + strings.Join(values, ", ") + "\n"
+}
+
+// seq2Elem returns T for `iter.Seq2[T, error]`.
+func seq2Elem(t types.Type) (types.Type, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "iter" || named.Obj().Name() != "Seq2" {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+func zero(t types.Type, qualifier types.Qualifier) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		}
+	case *types.Struct, *types.Array:
+		return types.TypeString(t, qualifier) + "{}"
+	}
+	return "nil"
+}
+
+// signature returns the parameters and results of sig as Go source.
+func signature(sig *types.Signature, qualifier types.Qualifier) ([]string, string) {
+	names := arguments(sig)
+	params := make([]string, sig.Params().Len())
+	for i := range params {
+		t := sig.Params().At(i).Type()
+		if sig.Variadic() && i == len(params)-1 {
+			params[i] = strings.TrimSuffix(names[i], "...") + " ..." + types.TypeString(t.(*types.Slice).Elem(), qualifier)
+			continue
+		}
+		params[i] = names[i] + " " + types.TypeString(t, qualifier)
+	}
+
+	results := make([]string, sig.Results().Len())
+	for i := range results {
+		results[i] = types.TypeString(sig.Results().At(i).Type(), qualifier)
+	}
+	switch len(results) {
+	case 0:
+		return params, ""
+	case 1:
+		return params, results[0]
+	}
+	return params, "(" + strings.Join(results, ", ") + ")"
+}
+
+// arguments returns the names of the parameters of sig, as passed to a call.
+func arguments(sig *types.Signature) []string {
+	names := make([]string, sig.Params().Len())
+	for i := range names {
+		names[i] = sig.Params().At(i).Name()
+		if names[i] == "" || names[i] == "_" {
+			names[i] = fmt.Sprintf("arg%d", i)
+		}
+		if sig.Variadic() && i == len(names)-1 {
+			names[i] += "..."
+		}
+	}
+	return names
+}
+
+// importSet collects the packages used by generated code.
+type importSet struct {
+	self  string
+	paths map[string]string
+}
+
+func newImports(self string) *importSet {
+	return &importSet{self: self, paths: map[string]string{}}
+}
+
+func (im *importSet) qualifier(pkg *types.Package) string {
+	if pkg.Path() == rootPath && im.self == "" {
+		return ""
+	}
+	im.paths[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func render(pkgName string, im *importSet, body *bytes.Buffer) ([]byte, error) {
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by apigen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkgName)
+
+	paths := make([]string, 0, len(im.paths))
+	for path := range im.paths {
+		paths = append(paths, path)
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		if sa, sb := isStd(a), isStd(b); sa != sb {
+			if sa {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			out.WriteString("\n")
+		}
+		if name := im.paths[path]; name != filepath.Base(path) {
+			fmt.Fprintf(out, "\t%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(out, "\t%q\n", path)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w\n%s", pkgName, err, out.Bytes())
+	}
+	return src, nil
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
@@ -0,0 +1,234 @@
+// Command requestgen generates the ToUpdateRequest methods converting the
+// models returned by the API to the requests updating them, in the
+// update_request.gen.go file of each model package. It is run from the root
+// of the module by `go generate`.
+//
+// Fields are matched by name. Generation fails if a field of the request has
+// no match in the model, or if a field of the model is neither converted nor
+// declared read-only, so that the conversions stay lossless as the models
+// change.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	rootPath = "github.com/signalfx/signalfx-go"
+
+	outFile = "update_request.gen.go"
+)
+
+// conversion is a generated ToUpdateRequest method.
+type conversion struct {
+	// pkg is the directory of the package, relative to the root.
+	pkg string
+	// model is the type returned by the API and request the type updating it.
+	model, request string
+	// readOnly are the fields of model that the request cannot set.
+	readOnly []string
+}
+
+// common are the fields managed by the API for most objects.
+var common = []string{"Id", "Created", "Creator", "LastUpdated", "LastUpdatedBy"}
+
+var conversions = []conversion{
+	{pkg: "alertmuting", model: "AlertMutingRule", request: "CreateUpdateAlertMutingRuleRequest", readOnly: common},
+	{pkg: "chart", model: "Chart", request: "CreateUpdateChartRequest", readOnly: append(common, "SloId")},
+	{pkg: "dashboard", model: "Dashboard", request: "CreateUpdateDashboardRequest", readOnly: append(common, "Locked")},
+	{pkg: "dashboard_group", model: "DashboardGroup", request: "CreateUpdateDashboardGroupRequest", readOnly: common},
+	{pkg: "datalink", model: "DataLink", request: "CreateUpdateDataLinkRequest", readOnly: common},
+	{pkg: "detector", model: "Detector", request: "CreateUpdateDetectorRequest", readOnly: append(common, "LabelResolutions", "Locked", "OverMTSLimit")},
+	{pkg: "metric_ruleset", model: "GetMetricRulesetResponse", request: "UpdateMetricRulesetRequest", readOnly: append(common, "CreatorName", "LastUpdatedByName")},
+	{pkg: "navigator", model: "Navigator", request: "UpdateNavigatorRequest", readOnly: []string{"Created", "Creator", "LastUpdated", "LastUpdatedBy", "AggregateDashboards", "NavigatorCode", "OwnerScope"}},
+	{pkg: "organization", model: "Member", request: "UpdateMemberRequest", readOnly: append(common, "UserId", "OrganizationId", "Email", "FullName", "Phone", "Title")},
+	{pkg: "orgtoken", model: "Token", request: "CreateUpdateTokenRequest", readOnly: append(common, "Secret", "LatestRotation", "Expiry")},
+	{pkg: "team", model: "Team", request: "CreateUpdateTeamRequest", readOnly: []string{"Id"}},
+}
+
+func main() {
+	out, err := generate(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, content := range out {
+		if err := os.WriteFile(name, content, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the content of the generated files, by path relative to
+// dir, the root of the module.
+func generate(dir string) (map[string][]byte, error) {
+	patterns := make([]string, len(conversions))
+	for i, conv := range conversions {
+		patterns[i] = "./" + conv.pkg
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	byPath := map[string]*packages.Package{}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			// A stale generated file must not prevent generating a new one.
+			if !strings.Contains(e.Pos, outFile) {
+				return nil, fmt.Errorf("loading %s: %v", pkg.PkgPath, pkg.Errors)
+			}
+		}
+		byPath[pkg.PkgPath] = pkg
+	}
+
+	out := map[string][]byte{}
+	for _, conv := range conversions {
+		pkg, ok := byPath[rootPath+"/"+conv.pkg]
+		if !ok {
+			return nil, fmt.Errorf("package %s not loaded", conv.pkg)
+		}
+		content, err := generateFile(pkg.Types, conv)
+		if err != nil {
+			return nil, err
+		}
+		out[filepath.Join(conv.pkg, outFile)] = content
+	}
+	return out, nil
+}
+
+func generateFile(pkg *types.Package, conv conversion) ([]byte, error) {
+	model, err := structType(pkg, conv.model)
+	if err != nil {
+		return nil, err
+	}
+	request, err := structType(pkg, conv.request)
+	if err != nil {
+		return nil, err
+	}
+
+	modelFields := map[string]*types.Var{}
+	for i := range model.NumFields() {
+		modelFields[model.Field(i).Name()] = model.Field(i)
+	}
+	for _, name := range conv.readOnly {
+		if _, ok := modelFields[name]; !ok {
+			return nil, fmt.Errorf("%s.%s: read-only field %s does not exist", conv.pkg, conv.model, name)
+		}
+	}
+
+	var usesJSON bool
+	var assigned, statements bytes.Buffer
+	used := map[string]bool{}
+	for i := range request.NumFields() {
+		field := request.Field(i)
+		name := field.Name()
+		from, ok := modelFields[name]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s: field %s has no match in %s", conv.pkg, conv.request, name, conv.model)
+		}
+		if slices.Contains(conv.readOnly, name) {
+			return nil, fmt.Errorf("%s.%s: field %s is read-only but the request has it", conv.pkg, conv.model, name)
+		}
+		used[name] = true
+
+		switch {
+		case types.Identical(from.Type(), field.Type()):
+			fmt.Fprintf(&assigned, "%s: o.%s,\n", name, name)
+		case isPointerTo(from.Type(), field.Type()):
+			fmt.Fprintf(&statements, "if o.%s != nil {\nreq.%s = *o.%s\n}\n", name, name, name)
+		case isPointerTo(from.Type(), types.Universe.Lookup("any").Type()) && types.Identical(field.Type(), types.Typ[types.String]):
+			// Custom properties are returned as JSON but sent as a string.
+			usesJSON = true
+			fmt.Fprintf(&statements, "req.%s = jsonString(o.%s)\n", name, name)
+		default:
+			return nil, fmt.Errorf("%s: cannot convert %s.%s from %s to %s", conv.pkg, conv.model, name, from.Type(), field.Type())
+		}
+	}
+	for i := range model.NumFields() {
+		name := model.Field(i).Name()
+		if !used[name] && !slices.Contains(conv.readOnly, name) {
+			return nil, fmt.Errorf("%s.%s: field %s is neither in %s nor read-only", conv.pkg, conv.model, name, conv.request)
+		}
+	}
+
+	body := &bytes.Buffer{}
+	fmt.Fprintf(body, "// Code generated by requestgen. DO NOT EDIT.\n\npackage %s\n\n", pkg.Name())
+	if usesJSON {
+		body.WriteString("import \"encoding/json\"\n\n")
+	}
+	body.WriteString(comment(fmt.Sprintf("ToUpdateRequest returns the request updating the %s to its current state, without the fields the request cannot set: %s. The request shares the slices, maps and pointers of o.", conv.model, strings.Join(conv.readOnly, ", "))))
+	fmt.Fprintf(body, "func (o *%s) ToUpdateRequest() *%s {\n", conv.model, conv.request)
+	fmt.Fprintf(body, "if o == nil {\nreturn nil\n}\n")
+	if statements.Len() == 0 {
+		fmt.Fprintf(body, "return &%s{\n%s}\n}\n", conv.request, assigned.Bytes())
+	} else {
+		fmt.Fprintf(body, "req := &%s{\n%s}\n%sreturn req\n}\n", conv.request, assigned.Bytes(), statements.Bytes())
+	}
+	if usesJSON {
+		body.WriteString(`
+// jsonString returns v encoded as JSON, or v itself if it is a string.
+func jsonString(v *interface{}) string {
+	if v == nil || *v == nil {
+		return ""
+	}
+	if s, ok := (*v).(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(*v)
+	return string(b)
+}
+`)
+	}
+
+	src, err := format.Source(body.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w\n%s", conv.pkg, err, body.Bytes())
+	}
+	return src, nil
+}
+
+// comment returns text as a doc comment wrapped at 80 columns.
+func comment(text string) string {
+	var b strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 80 {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+func structType(pkg *types.Package, name string) (*types.Struct, error) {
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("%s: type %s not found", pkg.Path(), name)
+	}
+	s, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is not a struct", pkg.Path(), name)
+	}
+	return s, nil
+}
+
+// isPointerTo reports whether ptr is a pointer to elem.
+func isPointerTo(ptr, elem types.Type) bool {
+	p, ok := ptr.(*types.Pointer)
+	return ok && types.Identical(p.Elem(), elem)
+}
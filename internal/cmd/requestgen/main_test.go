@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+
+	out, err := generate(root)
+	require.NoError(t, err, "Must generate the files")
+
+	for name, content := range out {
+		current, err := os.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err, "Must read %s", name)
+		assert.Equal(t, string(content), string(current), "Must match the models, run `go generate` to update %s", name)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/signalfx/signalfx-go/metric_ruleset"
 )
@@ -33,6 +35,29 @@ func (c *Client) GetMetricRuleset(ctx context.Context, id string) (*metric_rules
 	return metricRuleset, err
 }
 
+// SearchMetricRulesets lists the metric rulesets.
+func (c *Client) SearchMetricRulesets(ctx context.Context, limit int, offset int) (*metric_ruleset.GetMetricRulesetsResponse, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+	params.Add("offset", strconv.Itoa(offset))
+
+	resp, err := c.doRequest(ctx, http.MethodGet, MetricRulesetApiURL, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = newResponseError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	metricRulesets := &metric_ruleset.GetMetricRulesetsResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&metricRulesets)
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return metricRulesets, err
+}
+
 // CreateMetricRuleset creates a metric ruleset.
 func (c *Client) CreateMetricRuleset(ctx context.Context, metricRuleset *metric_ruleset.CreateMetricRulesetRequest) (*metric_ruleset.CreateMetricRulesetResponse, error) {
 	payload, err := json.Marshal(metricRuleset)
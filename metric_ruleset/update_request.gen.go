@@ -0,0 +1,21 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package metric_ruleset
+
+// ToUpdateRequest returns the request updating the GetMetricRulesetResponse to
+// its current state, without the fields the request cannot set: Id, Created,
+// Creator, LastUpdated, LastUpdatedBy, CreatorName, LastUpdatedByName. The
+// request shares the slices, maps and pointers of o.
+func (o *GetMetricRulesetResponse) ToUpdateRequest() *UpdateMetricRulesetRequest {
+	if o == nil {
+		return nil
+	}
+	return &UpdateMetricRulesetRequest{
+		AggregationRules: o.AggregationRules,
+		ExceptionRules:   o.ExceptionRules,
+		MetricName:       o.MetricName,
+		Description:      o.Description,
+		RoutingRule:      o.RoutingRule,
+		Version:          o.Version,
+	}
+}
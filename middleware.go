@@ -0,0 +1,124 @@
+package signalfx
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+)
+
+// clientMethodPrefix is the qualified name prefix of the methods of Client.
+const clientMethodPrefix = "github.com/signalfx/signalfx-go.(*Client)."
+
+// Operation is a call to the API, as seen by middlewares.
+type Operation struct {
+	// Name is the name of the Client method making the call, such as
+	// `CreateDetector`.
+	Name   string
+	Method string
+	Path   string
+	Params url.Values
+	// Request is the request about to be sent. Middlewares may change its
+	// headers.
+	Request *http.Request
+}
+
+// Doer sends an operation to the API. For responses with an error status,
+// the returned error is the decoded `*ResponseError`, and the response is
+// returned along with it.
+type Doer interface {
+	Do(op *Operation) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to use a function as a Doer.
+type DoerFunc func(op *Operation) (*http.Response, error)
+
+// Do calls f(op).
+func (f DoerFunc) Do(op *Operation) (*http.Response, error) {
+	return f(op)
+}
+
+// Middleware wraps a Doer, running code before and after the next one.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares around every call made by the client. The
+// first middleware is the outermost one. Middlewares run once per call, not
+// once per retry, and also see the requests captured in dry run mode.
+func WithMiddleware(middlewares ...Middleware) ClientParam {
+	return func(client *Client) error {
+		client.middlewares = append(client.middlewares, middlewares...)
+		return nil
+	}
+}
+
+// do sends the request through the middlewares.
+func (c *Client) do(req *http.Request, method, path string, params url.Values) (*http.Response, error) {
+	if len(c.middlewares) == 0 {
+		return c.send(req, path)
+	}
+
+	var doer Doer = DoerFunc(func(op *Operation) (*http.Response, error) {
+		resp, err := c.send(op.Request, path)
+		if err != nil || resp.StatusCode < http.StatusBadRequest {
+			return resp, err
+		}
+		return resp, decodeError(resp)
+	})
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		doer = c.middlewares[i](doer)
+	}
+
+	resp, err := doer.Do(&Operation{
+		Name:    operationName(),
+		Method:  method,
+		Path:    path,
+		Params:  params,
+		Request: req,
+	})
+	if _, ok := err.(*ResponseError); ok && resp != nil {
+		// The resource methods report errors based on the status they
+		// expect, so they get the response rather than the decoded error.
+		return resp, nil
+	}
+	return resp, err
+}
+
+// send captures the request in dry run mode, or sends it.
+func (c *Client) send(req *http.Request, path string) (*http.Response, error) {
+	if c.dryRun != nil && c.dryRun.captures(req.Method, path) {
+		return c.dryRun.capture(req, path)
+	}
+	return c.doWithRetry(req, path)
+}
+
+// decodeError returns the error of a failed response, leaving its body
+// readable.
+func decodeError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	err := newResponseError(resp, 0)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err
+}
+
+// operationName returns the name of the exported Client method in the call
+// stack, such as `CreateDetector`.
+func operationName() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, clientMethodPrefix); ok {
+			if name != "" && name[0] >= 'A' && name[0] <= 'Z' && !strings.Contains(name, ".") {
+				return name
+			}
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "audit", r.Header.Get("X-Audit"), "Must send headers set by middlewares")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"message":"detector not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":0,"results":[]}`))
+	}))
+	defer server.Close()
+
+	var (
+		calls  []string
+		errs   []error
+		params []string
+	)
+	named := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(op *Operation) (*http.Response, error) {
+				calls = append(calls, name+" "+op.Name+" "+op.Method+" "+op.Path)
+				return next.Do(op)
+			})
+		}
+	}
+	audit := func(next Doer) Doer {
+		return DoerFunc(func(op *Operation) (*http.Response, error) {
+			op.Request.Header.Set("X-Audit", "audit")
+			params = append(params, op.Params.Get("name"))
+			resp, err := next.Do(op)
+			errs = append(errs, err)
+			return resp, err
+		})
+	}
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL), WithMiddleware(named("outer"), named("inner")), WithMiddleware(audit))
+	ctx := context.Background()
+
+	_, err := c.SearchDetectors(ctx, 10, "cpu", 0, "")
+	require.NoError(t, err, "Must search detectors")
+
+	for _, err := range c.AllDetectors(ctx, SearchFilter{Name: "mem"}) {
+		require.NoError(t, err, "Must iterate detectors")
+	}
+
+	err = c.DeleteDetector(ctx, "ABC")
+	re, ok := AsResponseError(err)
+	require.True(t, ok, "Must still return the error of the method")
+	assert.Equal(t, http.StatusNotFound, re.Code(), "Must keep the status code")
+	assert.Equal(t, "detector not found", re.Message(), "Must keep the body readable")
+
+	assert.Equal(t, []string{
+		"outer SearchDetectors GET /v2/detector",
+		"inner SearchDetectors GET /v2/detector",
+		"outer SearchDetectors GET /v2/detector",
+		"inner SearchDetectors GET /v2/detector",
+		"outer DeleteDetector DELETE /v2/detector/ABC",
+		"inner DeleteDetector DELETE /v2/detector/ABC",
+	}, calls, "Must run middlewares in order with the operation")
+	assert.Equal(t, []string{"cpu", "mem", ""}, params, "Must pass the params")
+
+	require.Len(t, errs, 3, "Must run once per call")
+	assert.NoError(t, errs[0], "Must not report successful calls")
+	assert.True(t, errors.Is(errs[2], ErrNotFound), "Must give middlewares the decoded error")
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	blocked := errors.New("blocked")
+	c, _ := NewClient(TestToken, APIUrl("http://127.0.0.1:0"), WithMiddleware(func(next Doer) Doer {
+		return DoerFunc(func(op *Operation) (*http.Response, error) {
+			if op.Method != http.MethodGet {
+				return nil, blocked
+			}
+			return next.Do(op)
+		})
+	}))
+
+	_, err := c.CreateDetector(context.Background(), &detector.CreateUpdateDetectorRequest{Name: "cpu"})
+	assert.ErrorIs(t, err, blocked, "Must return errors of middlewares")
+}
@@ -0,0 +1,136 @@
+package signalfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// ModifyAttempts is the number of times the Modify helpers run the whole
+// read, mutate and write cycle before giving up on an object that keeps
+// changing.
+const ModifyAttempts = 3
+
+// modifier is a Modify helper for one type of object.
+type modifier[Req, Obj any] struct {
+	get    func(ctx context.Context, id string) (*Obj, error)
+	update func(ctx context.Context, id string, req *Req) (*Obj, error)
+	// request converts an object to the request updating it unchanged.
+	request func(obj *Obj) *Req
+	// version identifies a revision of an object.
+	version func(obj *Obj) string
+}
+
+// modify reads the object, applies mutate to its update request and writes
+// it back if the object has not changed in the meantime. A concurrent change
+// restarts the cycle, up to ModifyAttempts times, after which an error
+// matching ErrConflict is returned.
+func (m modifier[Req, Obj]) modify(ctx context.Context, id string, mutate func(req *Req) error) (*Obj, error) {
+	for range ModifyAttempts {
+		obj, err := m.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		// The request shares its slices, maps and pointers with obj, which
+		// mutate may change in place, so read the version first.
+		version := m.version(obj)
+		req := m.request(obj)
+		if err := mutate(req); err != nil {
+			return nil, err
+		}
+
+		// The API has no conditional updates, so check the revision as late
+		// as possible to keep the window for lost updates small.
+		current, err := m.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if m.version(current) != version {
+			continue
+		}
+
+		updated, err := m.update(ctx, id, req)
+		if errors.Is(err, ErrConflict) {
+			continue
+		}
+		return updated, err
+	}
+	return nil, fmt.Errorf("%w: %s changed concurrently %d times", ErrConflict, id, ModifyAttempts)
+}
+
+// lastUpdated is the version of the objects that have a `lastUpdated` time.
+func lastUpdated(t int64) string {
+	return strconv.FormatInt(t, 10)
+}
+
+// ModifyDetector reads a detector, applies mutate to its update request and
+// writes it back unless the detector was updated meanwhile, in which case the
+// cycle is retried. mutate may thus run several times, each time with a
+// fresh request. An error returned by mutate aborts the modification.
+func (c *Client) ModifyDetector(ctx context.Context, id string, mutate func(req *detector.CreateUpdateDetectorRequest) error) (*detector.Detector, error) {
+	return modifier[detector.CreateUpdateDetectorRequest, detector.Detector]{
+		get:     c.GetDetector,
+		update:  c.UpdateDetector,
+		request: (*detector.Detector).ToUpdateRequest,
+		version: func(d *detector.Detector) string { return lastUpdated(d.LastUpdated) },
+	}.modify(ctx, id, mutate)
+}
+
+// ModifyChart is like ModifyDetector for charts.
+func (c *Client) ModifyChart(ctx context.Context, id string, mutate func(req *chart.CreateUpdateChartRequest) error) (*chart.Chart, error) {
+	return modifier[chart.CreateUpdateChartRequest, chart.Chart]{
+		get:     c.GetChart,
+		update:  c.UpdateChart,
+		request: (*chart.Chart).ToUpdateRequest,
+		version: func(ch *chart.Chart) string { return lastUpdated(ch.LastUpdated) },
+	}.modify(ctx, id, mutate)
+}
+
+// ModifyDashboard is like ModifyDetector for dashboards.
+func (c *Client) ModifyDashboard(ctx context.Context, id string, mutate func(req *dashboard.CreateUpdateDashboardRequest) error) (*dashboard.Dashboard, error) {
+	return modifier[dashboard.CreateUpdateDashboardRequest, dashboard.Dashboard]{
+		get:     c.GetDashboard,
+		update:  c.UpdateDashboard,
+		request: (*dashboard.Dashboard).ToUpdateRequest,
+		version: func(d *dashboard.Dashboard) string { return lastUpdated(d.LastUpdated) },
+	}.modify(ctx, id, mutate)
+}
+
+// ModifyTeam is like ModifyDetector for teams. Teams have no `lastUpdated`
+// time, so any difference in the team counts as a concurrent change.
+func (c *Client) ModifyTeam(ctx context.Context, id string, mutate func(req *team.CreateUpdateTeamRequest) error) (*team.Team, error) {
+	return modifier[team.CreateUpdateTeamRequest, team.Team]{
+		get:     c.GetTeam,
+		update:  c.UpdateTeam,
+		request: (*team.Team).ToUpdateRequest,
+		version: func(t *team.Team) string {
+			b, _ := json.Marshal(t)
+			return string(b)
+		},
+	}.modify(ctx, id, mutate)
+}
+
+// ModifyMetricRuleset is like ModifyDetector for metric rulesets, whose
+// revisions are tracked by their version. The request carries the version
+// read, so the API also rejects the update if the ruleset changed in
+// between.
+func (c *Client) ModifyMetricRuleset(ctx context.Context, id string, mutate func(req *metric_ruleset.UpdateMetricRulesetRequest) error) (*metric_ruleset.UpdateMetricRulesetResponse, error) {
+	updated, err := modifier[metric_ruleset.UpdateMetricRulesetRequest, metric_ruleset.GetMetricRulesetResponse]{
+		get: c.GetMetricRuleset,
+		update: func(ctx context.Context, id string, req *metric_ruleset.UpdateMetricRulesetRequest) (*metric_ruleset.GetMetricRulesetResponse, error) {
+			resp, err := c.UpdateMetricRuleset(ctx, id, req)
+			return (*metric_ruleset.GetMetricRulesetResponse)(resp), err
+		},
+		request: (*metric_ruleset.GetMetricRulesetResponse).ToUpdateRequest,
+		version: func(r *metric_ruleset.GetMetricRulesetResponse) string { return lastUpdated(r.GetVersion()) },
+	}.modify(ctx, id, mutate)
+	return (*metric_ruleset.UpdateMetricRulesetResponse)(updated), err
+}
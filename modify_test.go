@@ -0,0 +1,162 @@
+package signalfx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyDetector(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	created, err := c.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{
+		Name:             "cpu",
+		ProgramText:      "detect(when(data('cpu') > 90)).publish('CPU')",
+		Tags:             []string{"team:payments"},
+		CustomProperties: `{"owner":"pipeline"}`,
+	})
+	require.NoError(t, err, "Must create the detector")
+
+	calls := 0
+	modified, err := c.ModifyDetector(ctx, created.Id, func(req *detector.CreateUpdateDetectorRequest) error {
+		calls++
+		if calls == 1 {
+			// Another writer renames the detector before this one writes.
+			_, err := c.UpdateDetector(ctx, created.Id, &detector.CreateUpdateDetectorRequest{
+				Name:             "cpu renamed",
+				ProgramText:      req.ProgramText,
+				Tags:             req.Tags,
+				CustomProperties: req.CustomProperties,
+			})
+			require.NoError(t, err, "Must update the detector")
+		}
+		req.Tags = append(req.Tags, "tier:1")
+		return nil
+	})
+	require.NoError(t, err, "Must modify the detector")
+	assert.Equal(t, 2, calls, "Must retry after a concurrent change")
+	assert.Equal(t, "cpu renamed", modified.Name, "Must keep the concurrent change")
+	assert.Equal(t, []string{"team:payments", "tier:1"}, modified.Tags, "Must apply the mutation")
+	assert.Equal(t, "pipeline", propertyValue(modified.CustomProperties, "owner"), "Must keep the custom properties")
+
+	_, err = c.ModifyDetector(ctx, created.Id, func(req *detector.CreateUpdateDetectorRequest) error {
+		_, err := c.UpdateDetector(ctx, created.Id, req)
+		return err
+	})
+	assert.ErrorIs(t, err, ErrConflict, "Must give up on a detector that keeps changing")
+
+	stop := errors.New("stop")
+	_, err = c.ModifyDetector(ctx, created.Id, func(*detector.CreateUpdateDetectorRequest) error { return stop })
+	assert.ErrorIs(t, err, stop, "Must return the error of the mutation")
+
+	_, err = c.ModifyDetector(ctx, "missing", func(*detector.CreateUpdateDetectorRequest) error { return nil })
+	assert.ErrorIs(t, err, ErrNotFound, "Must return the error of the read")
+}
+
+func TestModifyTeam(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	created, err := c.CreateTeam(ctx, &team.CreateUpdateTeamRequest{Name: "payments", Members: []string{"A"}})
+	require.NoError(t, err, "Must create the team")
+
+	calls := 0
+	modified, err := c.ModifyTeam(ctx, created.Id, func(req *team.CreateUpdateTeamRequest) error {
+		calls++
+		if calls == 1 {
+			_, err := c.UpdateTeam(ctx, created.Id, &team.CreateUpdateTeamRequest{Name: req.Name, Members: []string{"A", "B"}})
+			require.NoError(t, err, "Must update the team")
+		}
+		req.Description = "Payments"
+		return nil
+	})
+	require.NoError(t, err, "Must modify the team")
+	assert.Equal(t, 2, calls, "Must retry after a concurrent change")
+	assert.Equal(t, []string{"A", "B"}, modified.Members, "Must keep the concurrent change")
+	assert.Equal(t, "Payments", modified.Description, "Must apply the mutation")
+}
+
+func TestModifyTeamInPlace(t *testing.T) {
+	t.Parallel()
+
+	fake := signalfxtest.NewServer()
+	defer fake.Close()
+	c, _ := NewClient(TestToken, APIUrl(fake.URL))
+	ctx := context.Background()
+
+	created, err := c.CreateTeam(ctx, &team.CreateUpdateTeamRequest{Name: "payments", Members: []string{"A", "B"}})
+	require.NoError(t, err, "Must create the team")
+
+	calls := 0
+	modified, err := c.ModifyTeam(ctx, created.Id, func(req *team.CreateUpdateTeamRequest) error {
+		calls++
+		req.Members[0] = "C"
+		return nil
+	})
+	require.NoError(t, err, "Must modify the team")
+	assert.Equal(t, 1, calls, "Must not mistake a mutation in place for a concurrent change")
+	assert.Equal(t, []string{"C", "B"}, modified.Members, "Must apply the mutation")
+}
+
+func TestModifyMetricRuleset(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	ruleset := map[string]any{"id": "R1", "metricName": "cpu", "version": 1}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPut {
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "Must send JSON")
+			if req["version"] != float64(ruleset["version"].(int)) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			req["id"] = ruleset["id"]
+			req["version"] = ruleset["version"].(int) + 1
+			ruleset = req
+		}
+		_ = json.NewEncoder(w).Encode(ruleset)
+	}))
+	defer server.Close()
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+	calls := 0
+	modified, err := c.ModifyMetricRuleset(context.Background(), "R1", func(req *metric_ruleset.UpdateMetricRulesetRequest) error {
+		calls++
+		if calls == 1 {
+			// Another writer bumps the version before this one writes.
+			mu.Lock()
+			defer mu.Unlock()
+			ruleset["version"] = 2
+		}
+		req.SetDescription("CPU")
+		return nil
+	})
+	require.NoError(t, err, "Must modify the ruleset")
+	assert.Equal(t, 2, calls, "Must retry after a concurrent change")
+	assert.Equal(t, int64(3), modified.GetVersion(), "Must write the latest version")
+	assert.Equal(t, "CPU", modified.GetDescription(), "Must apply the mutation")
+}
@@ -0,0 +1,136 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// MultiClient holds the clients of several organizations, by name, and runs
+// operations across all of them.
+type MultiClient struct {
+	clients map[string]*Client
+	names   []string
+}
+
+// OrgResult is a value returned by the client of an organization.
+type OrgResult[T any] struct {
+	Org   string
+	Value T
+}
+
+// OrgError is the error of an operation for one organization. It unwraps to
+// the error of the client, so `AsResponseError` and `errors.Is` see it.
+type OrgError struct {
+	Org string
+	Err error
+}
+
+func (e *OrgError) Error() string {
+	return fmt.Sprintf("org %q: %v", e.Org, e.Err)
+}
+
+func (e *OrgError) Unwrap() error {
+	return e.Err
+}
+
+// NewMultiClient creates a MultiClient from clients keyed by organization
+// name.
+func NewMultiClient(clients map[string]*Client) (*MultiClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("no clients")
+	}
+	m := &MultiClient{clients: make(map[string]*Client, len(clients))}
+	for name, client := range clients {
+		if client == nil {
+			return nil, fmt.Errorf("nil client for org %q", name)
+		}
+		m.clients[name] = client
+		m.names = append(m.names, name)
+	}
+	slices.Sort(m.names)
+	return m, nil
+}
+
+// Orgs returns the names of the organizations, sorted.
+func (m *MultiClient) Orgs() []string {
+	return slices.Clone(m.names)
+}
+
+// Client returns the client of an organization.
+func (m *MultiClient) Client(org string) (*Client, bool) {
+	c, ok := m.clients[org]
+	return c, ok
+}
+
+// FanOut runs fn concurrently with the client of every organization. The
+// results of the organizations that succeeded are returned sorted by
+// organization, along with the errors of the others, each an `*OrgError`,
+// joined with [errors.Join].
+//
+//	tokens, err := signalfx.FanOut(ctx, orgs, func(ctx context.Context, c *signalfx.Client) (*orgtoken.SearchResults, error) {
+//		return c.SearchOrgTokens(ctx, 100, "", 0)
+//	})
+func FanOut[T any](ctx context.Context, m *MultiClient, fn func(ctx context.Context, c *Client) (T, error)) ([]OrgResult[T], error) {
+	values, errs := fanOut(ctx, m, fn)
+
+	var results []OrgResult[T]
+	for i, org := range m.names {
+		if errs[i] == nil {
+			results = append(results, OrgResult[T]{Org: org, Value: values[i]})
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// FanOutAll consumes the iterator returned by fn for every organization,
+// such as the ones of AllDetectors, and returns every value tagged with its
+// organization. The values read before an organization fails are kept.
+//
+//	detectors, err := signalfx.FanOutAll(ctx, orgs, func(ctx context.Context, c *signalfx.Client) iter.Seq2[*detector.Detector, error] {
+//		return c.AllDetectors(ctx, signalfx.SearchFilter{Tags: "team:payments"})
+//	})
+func FanOutAll[T any](ctx context.Context, m *MultiClient, fn func(ctx context.Context, c *Client) iter.Seq2[T, error]) ([]OrgResult[T], error) {
+	values, errs := fanOut(ctx, m, func(ctx context.Context, c *Client) ([]T, error) {
+		var values []T
+		for v, err := range fn(ctx, c) {
+			if err != nil {
+				return values, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	})
+
+	var results []OrgResult[T]
+	for i, org := range m.names {
+		for _, v := range values[i] {
+			results = append(results, OrgResult[T]{Org: org, Value: v})
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// fanOut runs fn for every organization and returns the values and errors
+// in the order of the organization names.
+func fanOut[T any](ctx context.Context, m *MultiClient, fn func(ctx context.Context, c *Client) (T, error)) ([]T, []error) {
+	values := make([]T, len(m.names))
+	errs := make([]error, len(m.names))
+
+	var wg sync.WaitGroup
+	for i, org := range m.names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if values[i], errs[i] = fn(ctx, m.clients[org]); errs[i] != nil {
+				errs[i] = &OrgError{Org: org, Err: errs[i]}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return values, errs
+}
@@ -0,0 +1,68 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	clients := map[string]*Client{}
+	fakes := map[string]*signalfxtest.Server{}
+	for _, org := range []string{"prod", "dev", "lab"} {
+		fake := signalfxtest.NewServer()
+		t.Cleanup(fake.Close)
+		fakes[org] = fake
+		clients[org], _ = NewClient(TestToken, APIUrl(fake.URL))
+
+		for _, tag := range []string{"team:payments", "team:search"} {
+			_, err := clients[org].CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{Name: org + " " + tag, Tags: []string{tag}})
+			require.NoError(t, err, "Must create the detector")
+		}
+	}
+
+	m, err := NewMultiClient(clients)
+	require.NoError(t, err, "Must create the multi client")
+	assert.Equal(t, []string{"dev", "lab", "prod"}, m.Orgs(), "Must sort the orgs")
+
+	fakes["lab"].FailNext(http.MethodGet, DetectorAPIURL, http.StatusForbidden)
+
+	detectors, err := FanOutAll(ctx, m, func(ctx context.Context, c *Client) iter.Seq2[*detector.Detector, error] {
+		return c.AllDetectors(ctx, SearchFilter{Tags: "team:payments"})
+	})
+	require.Len(t, detectors, 2, "Must return the detectors of the other orgs")
+	assert.Equal(t, OrgResult[*detector.Detector]{Org: "dev", Value: detectors[0].Value}, detectors[0], "Must tag results with the org")
+	assert.Equal(t, "prod team:payments", detectors[1].Value.Name, "Must filter the detectors")
+
+	re, ok := AsResponseError(err)
+	require.True(t, ok, "Must keep the response error")
+	assert.Equal(t, http.StatusForbidden, re.Code(), "Must return the error of the org")
+	assert.True(t, errors.Is(err, ErrForbidden), "Must match sentinel errors")
+
+	var oe *OrgError
+	require.True(t, errors.As(err, &oe), "Must tag errors with the org")
+	assert.Equal(t, "lab", oe.Org, "Must name the failing org")
+
+	counts, err := FanOut(ctx, m, func(ctx context.Context, c *Client) (int32, error) {
+		results, err := c.SearchDetectors(ctx, 1, "", 0, "")
+		if err != nil {
+			return 0, err
+		}
+		return results.Count, nil
+	})
+	require.NoError(t, err, "Must search every org")
+	assert.Equal(t, []OrgResult[int32]{{"dev", 2}, {"lab", 2}, {"prod", 2}}, counts, "Must return a result per org")
+
+	_, err = NewMultiClient(nil)
+	assert.Error(t, err, "Must require clients")
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/signalfx/signalfx-go/navigator"
 )
@@ -39,6 +40,29 @@ func (c *Client) UpdateNavigator(ctx context.Context, id string, navigatorReques
 	return c.executeNavigatorUpdateRequest(ctx, NavigatorAPIURL+"/"+id, http.MethodPut, http.StatusOK, navigatorRequest, nil)
 }
 
+// SearchNavigators searches for navigators given a query string in `name`.
+func (c *Client) SearchNavigators(ctx context.Context, limit int, name string, offset int) (*navigator.GetNavigatorsResponse, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+	params.Add("name", name)
+	params.Add("offset", strconv.Itoa(offset))
+
+	resp, err := c.doRequest(ctx, http.MethodGet, NavigatorAPIURL, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := newResponseError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	results := &navigator.GetNavigatorsResponse{}
+	err = json.NewDecoder(resp.Body).Decode(results)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return results, err
+}
+
 func (c *Client) executeNavigatorRequest(ctx context.Context, url string, method string, expectedValidStatus int, navigatorRequest *navigator.CreateNavigatorRequest, params url.Values) (*navigator.Navigator, error) {
 	var body io.Reader
 	if navigatorRequest != nil {
@@ -0,0 +1,32 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package navigator
+
+// ToUpdateRequest returns the request updating the Navigator to its current
+// state, without the fields the request cannot set: Created, Creator,
+// LastUpdated, LastUpdatedBy, AggregateDashboards, NavigatorCode, OwnerScope.
+// The request shares the slices, maps and pointers of o.
+func (o *Navigator) ToUpdateRequest() *UpdateNavigatorRequest {
+	if o == nil {
+		return nil
+	}
+	return &UpdateNavigatorRequest{
+		AlertQuery:                 o.AlertQuery,
+		Categories:                 o.Categories,
+		DefaultGroupBy:             o.DefaultGroupBy,
+		DisplayName:                o.DisplayName,
+		EntityMetrics:              o.EntityMetrics,
+		Id:                         o.Id,
+		IdDisplayName:              o.IdDisplayName,
+		InstanceDashboards:         o.InstanceDashboards,
+		InstanceDisplayText:        o.InstanceDisplayText,
+		InstanceLabel:              o.InstanceLabel,
+		ListColumns:                o.ListColumns,
+		PropertyIdentifierTemplate: o.PropertyIdentifierTemplate,
+		RequiredProperties:         o.RequiredProperties,
+		SystemTypes:                o.SystemTypes,
+		SummaryMetricLabel:         o.SummaryMetricLabel,
+		SummaryMetricProgramText:   o.SummaryMetricProgramText,
+		TooltipKeyList:             o.TooltipKeyList,
+	}
+}
@@ -0,0 +1,16 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package organization
+
+// ToUpdateRequest returns the request updating the Member to its current state,
+// without the fields the request cannot set: Id, Created, Creator, LastUpdated,
+// LastUpdatedBy, UserId, OrganizationId, Email, FullName, Phone, Title. The
+// request shares the slices, maps and pointers of o.
+func (o *Member) ToUpdateRequest() *UpdateMemberRequest {
+	if o == nil {
+		return nil
+	}
+	return &UpdateMemberRequest{
+		Admin: o.Admin,
+	}
+}
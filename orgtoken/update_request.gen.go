@@ -0,0 +1,21 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package orgtoken
+
+// ToUpdateRequest returns the request updating the Token to its current state,
+// without the fields the request cannot set: Id, Created, Creator, LastUpdated,
+// LastUpdatedBy, Secret, LatestRotation, Expiry. The request shares the slices,
+// maps and pointers of o.
+func (o *Token) ToUpdateRequest() *CreateUpdateTokenRequest {
+	if o == nil {
+		return nil
+	}
+	return &CreateUpdateTokenRequest{
+		Name:          o.Name,
+		AuthScopes:    o.AuthScopes,
+		Description:   o.Description,
+		Limits:        o.Limits,
+		Notifications: o.Notifications,
+		Disabled:      o.Disabled,
+	}
+}
@@ -0,0 +1,456 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/emailtemplate"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/organization"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// MaxSearchOffset is the largest `offset` + `limit` accepted by the search
+// endpoints of the API.
+const MaxSearchOffset = 10000
+
+// DefaultPageSize is the number of results requested per page by the
+// iterators when `SearchFilter.PageSize` is not set.
+const DefaultPageSize = 100
+
+// createdOrderBy is the metadata field used to continue a search past
+// MaxSearchOffset.
+const createdOrderBy = "sf_createdOnMs"
+
+// ErrOffsetLimit is returned by an iterator when a search matches more
+// results than can be reached with offsets and the endpoint offers no
+// cursor to continue from. Only the metadata searches of AllDimensions,
+// AllMetrics, AllMetricTimeSeries and AllTags continue past
+// MaxSearchOffset, from the creation time of their results: the searches
+// of detectors, charts, dashboards and the other objects can neither sort
+// nor filter on it, so they stop there. Narrow the search filter, such as
+// its Name or Tags, to see the rest.
+var ErrOffsetLimit = errors.New("search exceeds the maximum offset")
+
+// SearchFilter narrows the results returned by the `All*` iterators. Each
+// iterator only uses the fields supported by its endpoint.
+type SearchFilter struct {
+	// Name filters on a substring of the object name.
+	Name string
+	// Tags filters on the object tags.
+	Tags string
+	// Query is a search query, for endpoints that accept one.
+	Query string
+	// OrderBy sets the sort order, for endpoints that accept one. Metadata
+	// searches default to the creation time so that they can continue past
+	// MaxSearchOffset.
+	OrderBy string
+	// Include selects the alert muting rules to return.
+	Include string
+	// Context filters data links on their context.
+	Context string
+	// Type filters integrations on their type.
+	Type string
+	// PageSize is the number of results requested at once.
+	PageSize int
+}
+
+// pager drives a search endpoint page by page.
+type pager[T any] struct {
+	size int
+	// fetch returns a page and the total number of matching results. When
+	// after is not zero, only results created at or after it are wanted.
+	fetch func(ctx context.Context, limit, offset int, after int64) ([]T, int, error)
+	// created returns the creation time used as a cursor once the offset
+	// limit is reached. It is nil for endpoints that can not filter on it.
+	created func(T) int64
+}
+
+func (p pager[T]) all(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var (
+			zero        T
+			size        = min(max(p.size, 0), MaxSearchOffset)
+			offset      int
+			after       int64
+			skip        int
+			lastCreated int64
+			ties        int
+		)
+		if size == 0 {
+			size = DefaultPageSize
+		}
+
+		for {
+			if offset >= MaxSearchOffset {
+				if p.created == nil || (after != 0 && lastCreated == after) {
+					yield(zero, ErrOffsetLimit)
+					return
+				}
+				// Continue from the last creation time seen, skipping the
+				// results sharing it that were already returned.
+				offset, after, skip = 0, lastCreated, ties
+			}
+
+			limit := min(size, MaxSearchOffset-offset)
+			items, count, err := p.fetch(ctx, limit, offset, after)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if p.created != nil {
+					created := p.created(item)
+					if skip > 0 && created == after {
+						skip--
+						continue
+					}
+					skip = 0
+					if created == lastCreated {
+						ties++
+					} else {
+						lastCreated, ties = created, 1
+					}
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(items)
+			if len(items) < limit || (count > 0 && offset >= count) {
+				return
+			}
+		}
+	}
+}
+
+// createdQuery narrows a metadata query to the results created at or after
+// the given time.
+func createdQuery(query string, after int64) string {
+	if after == 0 {
+		return query
+	}
+	cursor := fmt.Sprintf("%s:[%d TO *]", createdOrderBy, after)
+	if query == "" {
+		return cursor
+	}
+	return "(" + query + ") AND " + cursor
+}
+
+// metadataOrderBy returns the sort order for a metadata search and whether
+// results can be continued from their creation time.
+func metadataOrderBy(orderBy string) (string, bool) {
+	if orderBy == "" || orderBy == createdOrderBy {
+		return createdOrderBy, true
+	}
+	return orderBy, false
+}
+
+func pointers[T any](items []T) []*T {
+	out := make([]*T, len(items))
+	for i := range items {
+		out[i] = &items[i]
+	}
+	return out
+}
+
+// AllDetectors iterates over every detector matching the filter's Name and
+// Tags.
+func (c *Client) AllDetectors(ctx context.Context, filter SearchFilter) iter.Seq2[*detector.Detector, error] {
+	return pager[*detector.Detector]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*detector.Detector, int, error) {
+			res, err := c.SearchDetectors(ctx, limit, filter.Name, offset, filter.Tags)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllCharts iterates over every chart matching the filter's Name and Tags.
+func (c *Client) AllCharts(ctx context.Context, filter SearchFilter) iter.Seq2[*chart.Chart, error] {
+	return pager[*chart.Chart]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*chart.Chart, int, error) {
+			res, err := c.SearchCharts(ctx, limit, filter.Name, offset, filter.Tags)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllDashboards iterates over every dashboard matching the filter's Name and
+// Tags.
+func (c *Client) AllDashboards(ctx context.Context, filter SearchFilter) iter.Seq2[*dashboard.Dashboard, error] {
+	return pager[*dashboard.Dashboard]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*dashboard.Dashboard, int, error) {
+			res, err := c.SearchDashboard(ctx, limit, filter.Name, offset, filter.Tags)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllDashboardGroups iterates over every dashboard group matching the
+// filter's Name.
+func (c *Client) AllDashboardGroups(ctx context.Context, filter SearchFilter) iter.Seq2[*dashboard_group.DashboardGroup, error] {
+	return pager[*dashboard_group.DashboardGroup]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*dashboard_group.DashboardGroup, int, error) {
+			res, err := c.SearchDashboardGroups(ctx, limit, filter.Name, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllTeams iterates over every team matching the filter's Name and Tags.
+func (c *Client) AllTeams(ctx context.Context, filter SearchFilter) iter.Seq2[*team.Team, error] {
+	return pager[*team.Team]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*team.Team, int, error) {
+			res, err := c.SearchTeam(ctx, limit, filter.Name, offset, filter.Tags)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllOrgTokens iterates over every org token matching the filter's Name.
+func (c *Client) AllOrgTokens(ctx context.Context, filter SearchFilter) iter.Seq2[*orgtoken.Token, error] {
+	return pager[*orgtoken.Token]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*orgtoken.Token, int, error) {
+			res, err := c.SearchOrgTokens(ctx, limit, filter.Name, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllAlertMutingRules iterates over every alert muting rule matching the
+// filter's Query and Include.
+func (c *Client) AllAlertMutingRules(ctx context.Context, filter SearchFilter) iter.Seq2[*alertmuting.AlertMutingRule, error] {
+	return pager[*alertmuting.AlertMutingRule]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*alertmuting.AlertMutingRule, int, error) {
+			res, err := c.SearchAlertMutingRules(ctx, filter.Include, limit, filter.Query, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllDataLinks iterates over every data link matching the filter's Context.
+func (c *Client) AllDataLinks(ctx context.Context, filter SearchFilter) iter.Seq2[*datalink.DataLink, error] {
+	return pager[*datalink.DataLink]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*datalink.DataLink, int, error) {
+			res, err := c.SearchDataLinks(ctx, limit, filter.Context, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllSlos iterates over every SLO matching the filter's Name.
+func (c *Client) AllSlos(ctx context.Context, filter SearchFilter) iter.Seq2[*slo.SloObject, error] {
+	return pager[*slo.SloObject]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*slo.SloObject, int, error) {
+			res, err := c.SearchSlos(ctx, limit, filter.Name, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllNavigators iterates over every navigator matching the filter's Name.
+func (c *Client) AllNavigators(ctx context.Context, filter SearchFilter) iter.Seq2[*navigator.GetNavigatorsResult, error] {
+	return pager[*navigator.GetNavigatorsResult]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*navigator.GetNavigatorsResult, int, error) {
+			res, err := c.SearchNavigators(ctx, limit, filter.Name, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.GetCount()), nil
+		},
+	}.all(ctx)
+}
+
+// AllMetricRulesets iterates over every metric ruleset.
+func (c *Client) AllMetricRulesets(ctx context.Context, filter SearchFilter) iter.Seq2[*metric_ruleset.MetricRuleset, error] {
+	return pager[*metric_ruleset.MetricRuleset]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*metric_ruleset.MetricRuleset, int, error) {
+			res, err := c.SearchMetricRulesets(ctx, limit, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.GetCount()), nil
+		},
+	}.all(ctx)
+}
+
+// AllIntegrations iterates over every integration matching the filter's Name
+// and Type.
+func (c *Client) AllIntegrations(ctx context.Context, filter SearchFilter) iter.Seq2[map[string]interface{}, error] {
+	return pager[map[string]interface{}]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]map[string]interface{}, int, error) {
+			res, err := c.SearchIntegrations(ctx, limit, filter.Name, offset, filter.Type)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllEmailTemplates iterates over every email template matching the filter's
+// Name and OrderBy.
+func (c *Client) AllEmailTemplates(ctx context.Context, filter SearchFilter) iter.Seq2[*emailtemplate.EmailTemplate, error] {
+	return pager[*emailtemplate.EmailTemplate]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*emailtemplate.EmailTemplate, int, error) {
+			res, err := c.SearchEmailTemplates(ctx, limit, filter.Name, offset, filter.OrderBy)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, res.Count, nil
+		},
+	}.all(ctx)
+}
+
+// AllOrganizationMembers iterates over every organization member matching
+// the filter's Query and OrderBy.
+func (c *Client) AllOrganizationMembers(ctx context.Context, filter SearchFilter) iter.Seq2[*organization.Member, error] {
+	return pager[*organization.Member]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, _ int64) ([]*organization.Member, int, error) {
+			res, err := c.GetOrganizationMembers(ctx, limit, filter.Query, offset, filter.OrderBy)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}.all(ctx)
+}
+
+// AllDimensions iterates over every dimension matching the filter's Query.
+// Results past MaxSearchOffset are reached through their creation time
+// unless another OrderBy is set.
+func (c *Client) AllDimensions(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Dimension, error] {
+	orderBy, cursor := metadataOrderBy(filter.OrderBy)
+	p := pager[*metrics_metadata.Dimension]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, after int64) ([]*metrics_metadata.Dimension, int, error) {
+			res, err := c.SearchDimension(ctx, createdQuery(filter.Query, after), orderBy, limit, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}
+	if cursor {
+		p.created = func(d *metrics_metadata.Dimension) int64 { return d.Created }
+	}
+	return p.all(ctx)
+}
+
+// AllMetrics iterates over every metric matching the filter's Query.
+// Results past MaxSearchOffset are reached through their creation time
+// unless another OrderBy is set.
+func (c *Client) AllMetrics(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Metric, error] {
+	orderBy, cursor := metadataOrderBy(filter.OrderBy)
+	p := pager[*metrics_metadata.Metric]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, after int64) ([]*metrics_metadata.Metric, int, error) {
+			res, err := c.SearchMetric(ctx, createdQuery(filter.Query, after), orderBy, limit, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}
+	if cursor {
+		p.created = func(m *metrics_metadata.Metric) int64 { return m.Created }
+	}
+	return p.all(ctx)
+}
+
+// AllMetricTimeSeries iterates over every metric time series matching the
+// filter's Query. Results past MaxSearchOffset are reached through their
+// creation time unless another OrderBy is set.
+func (c *Client) AllMetricTimeSeries(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.MetricTimeSeries, error] {
+	orderBy, cursor := metadataOrderBy(filter.OrderBy)
+	p := pager[*metrics_metadata.MetricTimeSeries]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, after int64) ([]*metrics_metadata.MetricTimeSeries, int, error) {
+			res, err := c.SearchMetricTimeSeries(ctx, createdQuery(filter.Query, after), orderBy, limit, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return pointers(res.Results), int(res.Count), nil
+		},
+	}
+	if cursor {
+		p.created = func(m *metrics_metadata.MetricTimeSeries) int64 { return m.Created }
+	}
+	return p.all(ctx)
+}
+
+// AllTags iterates over every tag matching the filter's Query. Results past
+// MaxSearchOffset are reached through their creation time unless another
+// OrderBy is set.
+func (c *Client) AllTags(ctx context.Context, filter SearchFilter) iter.Seq2[*metrics_metadata.Tag, error] {
+	orderBy, cursor := metadataOrderBy(filter.OrderBy)
+	p := pager[*metrics_metadata.Tag]{
+		size: filter.PageSize,
+		fetch: func(ctx context.Context, limit, offset int, after int64) ([]*metrics_metadata.Tag, int, error) {
+			res, err := c.SearchTag(ctx, createdQuery(filter.Query, after), orderBy, limit, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			return res.Results, int(res.Count), nil
+		},
+	}
+	if cursor {
+		p.created = func(t *metrics_metadata.Tag) int64 { return t.Created }
+	}
+	return p.all(ctx)
+}
@@ -0,0 +1,243 @@
+package signalfx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pageRequest struct {
+	limit, offset int
+	query         string
+}
+
+// pagedServer serves `total` results by offset, building each one with
+// `item`, and records the requested pages.
+func pagedServer(t *testing.T, total int, item func(i int) any) (*httptest.Server, func() []pageRequest) {
+	var (
+		mu       sync.Mutex
+		requests []pageRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		mu.Lock()
+		requests = append(requests, pageRequest{limit: limit, offset: offset, query: q.Get("query")})
+		mu.Unlock()
+
+		results := []any{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			results = append(results, item(i))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": total, "results": results})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []pageRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]pageRequest(nil), requests...)
+	}
+}
+
+func TestAllDetectors(t *testing.T) {
+	t.Parallel()
+
+	server, requests := pagedServer(t, 25, func(i int) any {
+		return detector.Detector{Id: strconv.Itoa(i)}
+	})
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+	var ids []string
+	for d, err := range c.AllDetectors(context.Background(), SearchFilter{Name: "cpu", PageSize: 10}) {
+		require.NoError(t, err, "Must not error iterating")
+		ids = append(ids, d.Id)
+	}
+
+	assert.Len(t, ids, 25, "Must return every detector")
+	assert.Equal(t, "24", ids[24], "Must return detectors in order")
+	assert.Equal(t, []pageRequest{{10, 0, ""}, {10, 10, ""}, {10, 20, ""}}, requests(), "Must request each page once")
+}
+
+func TestAllListedResources(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		item func(i int) any
+		ids  func(c *Client, filter SearchFilter) ([]string, error)
+	}{
+		{
+			name: "slos",
+			item: func(i int) any { return map[string]any{"id": strconv.Itoa(i), "type": "RequestBased"} },
+			ids: func(c *Client, filter SearchFilter) ([]string, error) {
+				return collect(c.AllSlos(context.Background(), filter), func(s *slo.SloObject) string { return s.Id })
+			},
+		},
+		{
+			name: "navigators",
+			item: func(i int) any { return map[string]any{"id": strconv.Itoa(i)} },
+			ids: func(c *Client, filter SearchFilter) ([]string, error) {
+				return collect(c.AllNavigators(context.Background(), filter), func(n *navigator.GetNavigatorsResult) string { return n.GetId() })
+			},
+		},
+		{
+			name: "metric rulesets",
+			item: func(i int) any { return map[string]any{"id": strconv.Itoa(i)} },
+			ids: func(c *Client, filter SearchFilter) ([]string, error) {
+				return collect(c.AllMetricRulesets(context.Background(), filter), func(r *metric_ruleset.MetricRuleset) string { return r.GetId() })
+			},
+		},
+		{
+			name: "integrations",
+			item: func(i int) any { return map[string]any{"id": strconv.Itoa(i), "type": "Slack"} },
+			ids: func(c *Client, filter SearchFilter) ([]string, error) {
+				return collect(c.AllIntegrations(context.Background(), filter), func(m map[string]interface{}) string { return m["id"].(string) })
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := pagedServer(t, 5, tc.item)
+			c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+			ids, err := tc.ids(c, SearchFilter{PageSize: 2})
+			require.NoError(t, err, "Must not error iterating")
+			assert.Equal(t, []string{"0", "1", "2", "3", "4"}, ids, "Must return every %s in order", tc.name)
+			assert.Len(t, requests(), 3, "Must request each page once")
+		})
+	}
+}
+
+// collect returns the IDs of the items of seq, up to the first error.
+func collect[T any](seq iter.Seq2[T, error], id func(T) string) ([]string, error) {
+	var ids []string
+	for item, err := range seq {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id(item))
+	}
+	return ids, nil
+}
+
+func TestAllDetectorsStopsEarly(t *testing.T) {
+	t.Parallel()
+
+	server, requests := pagedServer(t, 100, func(i int) any {
+		return detector.Detector{Id: strconv.Itoa(i)}
+	})
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+	seen := 0
+	for _, err := range c.AllDetectors(context.Background(), SearchFilter{PageSize: 10}) {
+		require.NoError(t, err, "Must not error iterating")
+		if seen++; seen == 15 {
+			break
+		}
+	}
+	assert.Len(t, requests(), 2, "Must not fetch pages that are not consumed")
+}
+
+func TestAllDetectorsOffsetLimit(t *testing.T) {
+	t.Parallel()
+
+	server, requests := pagedServer(t, MaxSearchOffset+10, func(i int) any {
+		return detector.Detector{Id: strconv.Itoa(i)}
+	})
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+	var (
+		seen    int
+		lastErr error
+	)
+	for _, err := range c.AllDetectors(context.Background(), SearchFilter{PageSize: 3000}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		seen++
+	}
+
+	assert.ErrorIs(t, lastErr, ErrOffsetLimit, "Must report the offset limit")
+	assert.Equal(t, MaxSearchOffset, seen, "Must return every reachable detector")
+	assert.Equal(t, pageRequest{1000, 9000, ""}, requests()[3], "Must shrink the last page to the offset limit")
+}
+
+func TestAllMetricTimeSeriesCursor(t *testing.T) {
+	t.Parallel()
+
+	// Every two time series share the same creation time.
+	created := func(i int) int64 { return int64(1000 + i/2) }
+
+	var (
+		mu       sync.Mutex
+		requests []pageRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		assert.Equal(t, "sf_createdOnMs", q.Get("orderBy"), "Must order by creation time")
+
+		mu.Lock()
+		requests = append(requests, pageRequest{limit: limit, offset: offset, query: q.Get("query")})
+		mu.Unlock()
+
+		// Emulate the offset limit on a dataset larger than it.
+		start := 0
+		var after int64
+		if _, err := fmt.Sscanf(q.Get("query"), "(sf_metric:cpu) AND sf_createdOnMs:[%d TO *]", &after); err == nil {
+			for created(start) < after {
+				start++
+			}
+		}
+		total := MaxSearchOffset + 5 - start
+
+		results := []metrics_metadata.MetricTimeSeries{}
+		for i := start + offset; i < min(start+offset+limit, MaxSearchOffset+5); i++ {
+			results = append(results, metrics_metadata.MetricTimeSeries{Metric: strconv.Itoa(i), Created: created(i)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": total, "results": results})
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL))
+
+	seen := map[string]bool{}
+	for mts, err := range c.AllMetricTimeSeries(context.Background(), SearchFilter{Query: "sf_metric:cpu", PageSize: 5000}) {
+		require.NoError(t, err, "Must not error iterating")
+		assert.False(t, seen[mts.Metric], "Must not return %s twice", mts.Metric)
+		seen[mts.Metric] = true
+	}
+
+	assert.Len(t, seen, MaxSearchOffset+5, "Must return results past the offset limit")
+	require.Len(t, requests, 3, "Must continue with a cursor")
+	assert.Equal(t, pageRequest{5000, 0, "(sf_metric:cpu) AND sf_createdOnMs:[5999 TO *]"}, requests[2], "Must narrow the query to the last creation time")
+}
+
+func TestCreatedQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "sf_metric:cpu", createdQuery("sf_metric:cpu", 0), "Must not change the query without a cursor")
+	assert.Equal(t, "sf_createdOnMs:[5 TO *]", createdQuery("", 5), "Must only use the cursor on an empty query")
+	assert.Equal(t, "(a OR b) AND sf_createdOnMs:[5 TO *]", createdQuery("a OR b", 5), "Must group the original query")
+}
@@ -0,0 +1,182 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket budget: requests are allowed at PerSecond on
+// average, with bursts of up to Burst requests.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// RateLimitStats reports how long callers waited for capacity.
+type RateLimitStats struct {
+	// Requests is the number of requests that went through the bucket.
+	Requests int64
+	// Delayed is the number of requests that had to wait for capacity.
+	Delayed int64
+	// TotalWait is the accumulated time spent waiting.
+	TotalWait time.Duration
+	// MaxWait is the longest single wait.
+	MaxWait time.Duration
+}
+
+// RateLimiter throttles the requests made by one or more clients so that
+// they stay within the organization's API quotas. Requests draw from the
+// global bucket unless a budget was configured for their route.
+type RateLimiter struct {
+	global *bucket
+	routes map[string]*bucket
+}
+
+// NewRateLimiter creates a limiter with a global budget and optional budgets
+// for route families, keyed on the API base constants such as
+// `DetectorAPIURL` or `ChartAPIURL`. A route budget applies to every path
+// below it and replaces the global budget for those requests; the most
+// specific route wins.
+func NewRateLimiter(global RateLimit, routes map[string]RateLimit) (*RateLimiter, error) {
+	g, err := newBucket(global)
+	if err != nil {
+		return nil, err
+	}
+	l := &RateLimiter{
+		global: g,
+		routes: make(map[string]*bucket, len(routes)),
+	}
+	for route, limit := range routes {
+		b, err := newBucket(limit)
+		if err != nil {
+			return nil, err
+		}
+		l.routes[strings.TrimRight(route, "/")] = b
+	}
+	return l, nil
+}
+
+// Limiter installs a rate limiter on the client. Every attempt of every
+// request, including retries, blocks until the limiter has capacity or the
+// request context is done. The same limiter can be shared between clients
+// that use the same organization token.
+func Limiter(limiter *RateLimiter) ClientParam {
+	return func(client *Client) error {
+		if limiter == nil {
+			return errors.New("rate limiter must not be nil")
+		}
+		client.limiter = limiter
+		return nil
+	}
+}
+
+// Wait blocks until a request to path is allowed to proceed.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	if l == nil {
+		return nil
+	}
+	return l.bucketFor(path).wait(ctx)
+}
+
+// Stats returns the statistics for every bucket, keyed on the route it was
+// configured for. The global bucket is keyed on the empty string.
+func (l *RateLimiter) Stats() map[string]RateLimitStats {
+	stats := make(map[string]RateLimitStats, len(l.routes)+1)
+	stats[""] = l.global.snapshot()
+	for route, b := range l.routes {
+		stats[route] = b.snapshot()
+	}
+	return stats
+}
+
+func (l *RateLimiter) bucketFor(path string) *bucket {
+	var (
+		found   = l.global
+		longest = -1
+	)
+	for route, b := range l.routes {
+		if len(route) <= longest {
+			continue
+		}
+		if path == route || strings.HasPrefix(path, route+"/") {
+			found, longest = b, len(route)
+		}
+	}
+	return found
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  RateLimitStats
+}
+
+func newBucket(limit RateLimit) (*bucket, error) {
+	if limit.PerSecond <= 0 {
+		return nil, errors.New("rate limit must be positive")
+	}
+	burst := max(limit.Burst, 1)
+	return &bucket{
+		rate:   limit.PerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	b.stats.Requests++
+	if b.tokens >= 0 {
+		return 0
+	}
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.stats.Delayed++
+	b.stats.TotalWait += wait
+	b.stats.MaxWait = max(b.stats.MaxWait, wait)
+	return wait
+}
+
+// cancel returns a token that was reserved but not used.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	wait := b.reserve(time.Now())
+	if wait == 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
+
+func (b *bucket) snapshot() RateLimitStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.stats
+}
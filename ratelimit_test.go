@@ -0,0 +1,118 @@
+package signalfx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiterValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRateLimiter(RateLimit{}, nil)
+	assert.Error(t, err, "Must reject an empty global limit")
+
+	_, err = NewRateLimiter(RateLimit{PerSecond: 1}, map[string]RateLimit{DetectorAPIURL: {PerSecond: -1}})
+	assert.Error(t, err, "Must reject an invalid route limit")
+
+	_, err = NewRateLimiter(RateLimit{PerSecond: 1}, map[string]RateLimit{DetectorAPIURL: {PerSecond: 5, Burst: 5}})
+	assert.NoError(t, err, "Must accept valid limits")
+}
+
+func TestRateLimiterRoutes(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(RateLimit{PerSecond: 1}, map[string]RateLimit{
+		ChartAPIURL:          {PerSecond: 1},
+		CreateSloChartAPIURL: {PerSecond: 1},
+		DetectorAPIURL + "/": {PerSecond: 1},
+	})
+	require.NoError(t, err, "Must create the limiter")
+
+	for path, expect := range map[string]string{
+		ChartAPIURL:                      ChartAPIURL,
+		ChartAPIURL + "/abc":             ChartAPIURL,
+		CreateSloChartAPIURL:             CreateSloChartAPIURL,
+		DetectorAPIURL + "/abc/events":   DetectorAPIURL,
+		DetectorAPIURL + "extra":         "",
+		TeamAPIURL + "/abc":              "",
+		OrganizationMemberAPIURL + "/id": "",
+	} {
+		b := limiter.bucketFor(path)
+		if expect == "" {
+			assert.Same(t, limiter.global, b, "Path %q must use the global bucket", path)
+		} else {
+			assert.Same(t, limiter.routes[expect], b, "Path %q must use the %q bucket", path, expect)
+		}
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(RateLimit{PerSecond: 50, Burst: 2}, nil)
+	require.NoError(t, err, "Must create the limiter")
+
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, limiter.Wait(context.Background(), DetectorAPIURL), "Must not error waiting")
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond, "Must have waited for capacity")
+
+	stats := limiter.Stats()[""]
+	assert.EqualValues(t, 4, stats.Requests, "Must count every request")
+	assert.EqualValues(t, 2, stats.Delayed, "Must count the requests over the burst")
+	assert.Greater(t, stats.TotalWait, time.Duration(0), "Must record the wait time")
+	assert.LessOrEqual(t, stats.MaxWait, stats.TotalWait, "Max wait must not exceed total wait")
+}
+
+func TestRateLimiterHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	limiter, err := NewRateLimiter(RateLimit{PerSecond: 0.01}, nil)
+	require.NoError(t, err, "Must create the limiter")
+
+	require.NoError(t, limiter.Wait(context.Background(), ChartAPIURL), "Must use the burst")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, limiter.Wait(ctx, ChartAPIURL), context.DeadlineExceeded, "Must return the context error")
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(canceled, ChartAPIURL), context.Canceled, "Must not wait on a done context")
+}
+
+func TestClientRateLimited(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	limiter, err := NewRateLimiter(RateLimit{PerSecond: 1000}, map[string]RateLimit{
+		DetectorAPIURL: {PerSecond: 0.01},
+	})
+	require.NoError(t, err, "Must create the limiter")
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL), Limiter(limiter))
+
+	require.NoError(t, c.DeleteDetector(context.Background(), "a"), "Must use the route burst")
+	require.NoError(t, c.DeleteTeam(context.Background(), "a"), "Must not be limited by the detector budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, c.DeleteDetector(ctx, "b"), context.DeadlineExceeded, "Must block on the detector budget")
+
+	stats := limiter.Stats()
+	assert.EqualValues(t, 2, stats[DetectorAPIURL].Requests, "Must count the detector requests")
+	assert.EqualValues(t, 1, stats[""].Requests, "Must count the other requests globally")
+}
@@ -0,0 +1,128 @@
+package realm
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// SignalFxDomain is the domain of the SignalFx hosts of every realm.
+	SignalFxDomain = "signalfx.com"
+	// SplunkDomain is the domain of the Splunk Observability Cloud hosts.
+	SplunkDomain = "observability.splunkcloud.com"
+)
+
+// ErrUnknownRealm is returned for realm names missing from the registry.
+var ErrUnknownRealm = errors.New("unknown realm")
+
+// namePattern matches well formed realm names, such as `us1`.
+var namePattern = regexp.MustCompile(`^[a-z]+[0-9]+$`)
+
+var registry = struct {
+	sync.RWMutex
+	names []string
+}{
+	names: []string{"au0", "eu0", "eu1", "eu2", "jp0", "sg0", "us0", "us1", "us2", "us3"},
+}
+
+// Endpoints are the base URLs of the services of a realm.
+type Endpoints struct {
+	Realm  string
+	Domain string
+	// API is the base URL of the REST API.
+	API string
+	// Ingest is the base URL for sending datapoints and events.
+	Ingest string
+	// Stream is the base URL of the SignalFlow streaming API.
+	Stream string
+	// Backfill is the base URL for backfilling datapoints.
+	Backfill string
+	// Trace is the endpoint to which spans in the Zipkin format are sent.
+	Trace string
+	// OTLP is the endpoint to which traces in the OTLP HTTP format are sent.
+	OTLP string
+}
+
+// Names returns the known realm names, sorted.
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return slices.Clone(registry.names)
+}
+
+// Valid reports whether realm is a known realm name.
+func Valid(realm string) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	_, found := slices.BinarySearch(registry.names, normalize(realm))
+	return found
+}
+
+// Register adds realm names to the registry, for realms opened after this
+// package was released.
+func Register(realms ...string) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	for _, realm := range realms {
+		realm = normalize(realm)
+		if !namePattern.MatchString(realm) {
+			return fmt.Errorf("invalid realm name %q", realm)
+		}
+		if i, found := slices.BinarySearch(registry.names, realm); !found {
+			registry.names = slices.Insert(registry.names, i, realm)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the SignalFx endpoints of a known realm.
+func Lookup(realm string) (Endpoints, error) {
+	return LookupDomain(realm, SignalFxDomain)
+}
+
+// LookupDomain returns the endpoints of a realm under domain. The realm must
+// be known for the SignalFx and Splunk Observability domains, and well
+// formed for custom domains.
+func LookupDomain(realm, domain string) (Endpoints, error) {
+	realm = normalize(realm)
+	domain = strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	switch domain {
+	case SignalFxDomain, SplunkDomain:
+		if !Valid(realm) {
+			return Endpoints{}, fmt.Errorf("%w %q, expected one of %s", ErrUnknownRealm, realm, strings.Join(Names(), ", "))
+		}
+	case "":
+		return Endpoints{}, errors.New("empty domain")
+	default:
+		if !namePattern.MatchString(realm) {
+			return Endpoints{}, fmt.Errorf("invalid realm name %q", realm)
+		}
+	}
+
+	host := func(service string) string {
+		return fmt.Sprintf("https://%s.%s.%s", service, realm, domain)
+	}
+	ingest := host("ingest")
+	return Endpoints{
+		Realm:    realm,
+		Domain:   domain,
+		API:      host("api"),
+		Ingest:   ingest,
+		Stream:   host("stream"),
+		Backfill: host("backfill"),
+		Trace:    ingest + "/v2/trace",
+		OTLP:     ingest + "/v2/trace/otlp",
+	}, nil
+}
+
+func normalize(realm string) string {
+	return strings.ToLower(strings.TrimSpace(realm))
+}
@@ -1,6 +1,7 @@
 package realm
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,3 +15,49 @@ func TestEventURLs(t *testing.T) {
 	require.Equal(t, EventEndpointForRealm("us9"), "https://ingest.us9.signalfx.com/v2/event")
 	require.Equal(t, EventEndpointForIngestURL("https://ingest.us9.signalfx.com/"), "https://ingest.us9.signalfx.com/v2/event")
 }
+
+func TestLookup(t *testing.T) {
+	endpoints, err := Lookup(" EU0 ")
+	require.NoError(t, err)
+	require.Equal(t, Endpoints{
+		Realm:    "eu0",
+		Domain:   SignalFxDomain,
+		API:      "https://api.eu0.signalfx.com",
+		Ingest:   "https://ingest.eu0.signalfx.com",
+		Stream:   "https://stream.eu0.signalfx.com",
+		Backfill: "https://backfill.eu0.signalfx.com",
+		Trace:    "https://ingest.eu0.signalfx.com/v2/trace",
+		OTLP:     "https://ingest.eu0.signalfx.com/v2/trace/otlp",
+	}, endpoints)
+
+	_, err = Lookup("us9")
+	require.ErrorIs(t, err, ErrUnknownRealm)
+}
+
+func TestLookupDomain(t *testing.T) {
+	endpoints, err := LookupDomain("us1", SplunkDomain)
+	require.NoError(t, err)
+	require.Equal(t, "https://api.us1.observability.splunkcloud.com", endpoints.API)
+
+	_, err = LookupDomain("us9", SplunkDomain)
+	require.ErrorIs(t, err, ErrUnknownRealm)
+
+	endpoints, err = LookupDomain("lab0", "example.com.")
+	require.NoError(t, err)
+	require.Equal(t, "https://stream.lab0.example.com", endpoints.Stream)
+
+	_, err = LookupDomain("not a realm", "example.com")
+	require.Error(t, err)
+	_, err = LookupDomain("us1", "")
+	require.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	require.False(t, Valid("zz7"))
+	require.NoError(t, Register("zz7"))
+	require.True(t, Valid("zz7"))
+	require.Contains(t, Names(), "zz7")
+	require.True(t, slices.IsSorted(Names()))
+
+	require.Error(t, Register("../evil"))
+}
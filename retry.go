@@ -0,0 +1,173 @@
+package signalfx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how the client retries requests that failed with a
+// transient error, such as being rate limited by the API.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a single request,
+	// including the first one. Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry. Each subsequent retry
+	// doubles the delay, up to MaxBackoff.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay between two attempts.
+	MaxBackoff time.Duration
+	// RetryableStatusCodes lists the response codes that are retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent allows retrying POST and PATCH requests. By default
+	// only idempotent methods (GET, HEAD, OPTIONS, PUT and DELETE) are retried
+	// since the API may have acted on a request before failing.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy that retries idempotent requests up to
+// four times when the API responds with 429, 502, 503 or 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Retry sets the policy used to retry failed requests. Retries are applied to
+// every request made by the client and honor the `Retry-After` header sent
+// along with 429 and 503 responses.
+func Retry(policy RetryPolicy) ClientParam {
+	return func(client *Client) error {
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("retry backoff must not be negative")
+		}
+		client.retry = &policy
+		return nil
+	}
+}
+
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// The body has been consumed and can not be sent again.
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+}
+
+// backoff returns how long to wait before the next attempt, preferring the
+// delay requested by the server when one is provided.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff << (attempt - 1)
+	if wait <= 0 || (p.MaxBackoff > 0 && wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Equal jitter keeps at least half of the delay while spreading
+	// concurrent clients apart.
+	half := wait / 2
+	return half + rand.N(wait-half+1)
+}
+
+// parseRetryAfter decodes a `Retry-After` header which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// doWithRetry sends the request, retrying it according to the client's
+// retry policy. Each attempt waits for the rate limiter of the API path.
+func (c *Client) doWithRetry(req *http.Request, path string) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.Wait(req.Context(), path); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if !c.retry.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
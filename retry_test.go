@@ -0,0 +1,189 @@
+package signalfx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, fixture("detector/get_success.json"))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(TestToken, APIUrl(server.URL), Retry(testRetryPolicy()))
+	require.NoError(t, err, "Must not error creating client")
+
+	result, err := c.GetDetector(context.Background(), "string")
+	assert.NoError(t, err, "Must succeed after retrying")
+	assert.Equal(t, "string", result.Name, "Name does not match")
+	assert.EqualValues(t, 3, calls.Load(), "Must have made three attempts")
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL), Retry(testRetryPolicy()))
+
+	_, err := c.GetDetector(context.Background(), "string")
+	re, ok := AsResponseError(err)
+	require.True(t, ok, "Must return a response error")
+	assert.Equal(t, http.StatusTooManyRequests, re.Code(), "Must report the last status code")
+	assert.EqualValues(t, 4, calls.Load(), "Must stop after MaxAttempts")
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls  atomic.Int32
+		bodies = make(chan string, 2)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies <- string(b)
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = io.WriteString(w, fixture("detector/update_success.json"))
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL), Retry(testRetryPolicy()))
+
+	_, err := c.UpdateDetector(context.Background(), "string", &detector.CreateUpdateDetectorRequest{Name: "string"})
+	require.NoError(t, err, "Must succeed after retrying")
+
+	first, second := <-bodies, <-bodies
+	assert.NotEmpty(t, first, "Must have sent a body")
+	assert.Equal(t, first, second, "Must send the same body on every attempt")
+}
+
+func TestRetrySkipsNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		policy func(p *RetryPolicy)
+		calls  int32
+	}{
+		{name: "default policy", policy: func(p *RetryPolicy) {}, calls: 1},
+		{name: "non idempotent allowed", policy: func(p *RetryPolicy) { p.RetryNonIdempotent = true }, calls: 4},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			policy := testRetryPolicy()
+			tc.policy(&policy)
+			c, _ := NewClient(TestToken, APIUrl(server.URL), Retry(policy))
+
+			_, err := c.CreateDetector(context.Background(), &detector.CreateUpdateDetectorRequest{Name: "string"})
+			assert.Error(t, err, "Must return an error")
+			assert.Equal(t, tc.calls, calls.Load(), "Must match the expected number of attempts")
+		})
+	}
+}
+
+func TestRetryHonorsContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(TestToken, APIUrl(server.URL), Retry(testRetryPolicy()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.GetDetector(ctx, "string")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Must return the context error")
+	assert.Less(t, time.Since(start), 5*time.Second, "Must not wait for the full Retry-After")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		value  string
+		expect time.Duration
+		ok     bool
+	}{
+		{value: "", expect: 0, ok: false},
+		{value: "3", expect: 3 * time.Second, ok: true},
+		{value: "-1", expect: 0, ok: false},
+		{value: "soon", expect: 0, ok: false},
+		{value: now.Add(10 * time.Second).Format(http.TimeFormat), expect: 10 * time.Second, ok: true},
+		{value: now.Add(-10 * time.Second).Format(http.TimeFormat), expect: 0, ok: true},
+	} {
+		wait, ok := parseRetryAfter(tc.value, now)
+		assert.Equal(t, tc.ok, ok, "Must match the expected parse result for %q", tc.value)
+		assert.Equal(t, tc.expect, wait, "Must match the expected wait for %q", tc.value)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, upper := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		5:  time.Second,
+		70: time.Second,
+	} {
+		wait := policy.backoff(attempt, nil)
+		assert.GreaterOrEqual(t, wait, upper/2, "Must keep at least half of the backoff")
+		assert.LessOrEqual(t, wait, upper, "Must not exceed the backoff")
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	assert.Equal(t, 2*time.Second, policy.backoff(1, resp), "Must prefer Retry-After")
+}
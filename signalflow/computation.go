@@ -0,0 +1,291 @@
+package signalflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/signalfx/signalfx-go/idtool"
+)
+
+// ErrEnded is returned for the information a computation ended without
+// reporting, such as its handle.
+var ErrEnded = errors.New("signalflow: computation ended")
+
+// Computation is the output of a program executed by the client. Its
+// channels are closed when the computation ends, whether the program
+// completes, fails, is stopped or detached, or the client is closed. The
+// data must be drained, or the computation stalls. The metadata and events
+// need not be: once their buffers are full, further ones are dropped. The
+// metadata of a time series remains available from TSIDMetadata.
+//
+// When the connection is lost, the computation is executed again once the
+// client reconnects, from the start of its request.
+type Computation struct {
+	client  *Client
+	channel string
+	request *request
+
+	data     chan *DataBatch
+	metadata chan *Metadata
+	events   chan *Event
+	errors   chan error
+
+	// stop is closed to end the computation before its messages are
+	// processed, and done once its channels are closed.
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+
+	mu              sync.Mutex
+	pending         []*message
+	wake            chan struct{}
+	tsids           map[idtool.ID]*Metadata
+	handle          string
+	handleKnown     chan struct{}
+	resolution      time.Duration
+	resolutionKnown chan struct{}
+}
+
+// bufferSize is the capacity of the channels of a computation.
+const bufferSize = 100
+
+func newComputation(c *Client, channel string, req *request) *Computation {
+	comp := &Computation{
+		client:          c,
+		channel:         channel,
+		request:         req,
+		data:            make(chan *DataBatch, bufferSize),
+		metadata:        make(chan *Metadata, bufferSize),
+		events:          make(chan *Event, bufferSize),
+		errors:          make(chan error, 1),
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+		wake:            make(chan struct{}, 1),
+		tsids:           map[idtool.ID]*Metadata{},
+		handleKnown:     make(chan struct{}),
+		resolutionKnown: make(chan struct{}),
+	}
+	go comp.run()
+	return comp
+}
+
+// Data returns the data of the computation, in batches by timestamp.
+func (comp *Computation) Data() <-chan *DataBatch {
+	return comp.data
+}
+
+// Metadata returns the metadata of the time series of the computation,
+// streamed before their data.
+func (comp *Computation) Metadata() <-chan *Metadata {
+	return comp.metadata
+}
+
+// Events returns the events of the computation.
+func (comp *Computation) Events() <-chan *Event {
+	return comp.events
+}
+
+// Errors returns the error ending the computation, if any.
+func (comp *Computation) Errors() <-chan error {
+	return comp.errors
+}
+
+// Done returns a channel closed once the computation has ended.
+func (comp *Computation) Done() <-chan struct{} {
+	return comp.done
+}
+
+// TSIDMetadata returns the metadata streamed for a time series, or nil if
+// there is none.
+func (comp *Computation) TSIDMetadata(tsid idtool.ID) *Metadata {
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	return comp.tsids[tsid]
+}
+
+// Handle returns the handle of the computation, waiting for the stream to
+// report it.
+func (comp *Computation) Handle(ctx context.Context) (string, error) {
+	if err := comp.wait(ctx, comp.handleKnown); err != nil {
+		return "", err
+	}
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	return comp.handle, nil
+}
+
+// Resolution returns the resolution of the data of the computation, waiting
+// for the stream to report it.
+func (comp *Computation) Resolution(ctx context.Context) (time.Duration, error) {
+	if err := comp.wait(ctx, comp.resolutionKnown); err != nil {
+		return 0, err
+	}
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	return comp.resolution, nil
+}
+
+func (comp *Computation) wait(ctx context.Context, known <-chan struct{}) error {
+	select {
+	case <-known:
+		return nil
+	case <-comp.done:
+		// The information may have been reported with the end.
+		select {
+		case <-known:
+			return nil
+		default:
+			return ErrEnded
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop stops the computation, which ends once the stream confirms it.
+func (comp *Computation) Stop(ctx context.Context, reason string) error {
+	handle, err := comp.Handle(ctx)
+	if err != nil {
+		return err
+	}
+	return comp.client.Stop(ctx, &StopRequest{Handle: handle, Reason: reason})
+}
+
+// Detach stops streaming the output of the computation and ends it. The
+// computation keeps running on the server when it was started elsewhere.
+func (comp *Computation) Detach(ctx context.Context, reason string) error {
+	if !comp.client.forget(comp) {
+		return nil
+	}
+	defer comp.end()
+
+	return comp.client.send(ctx, &request{Type: "detach", Channel: comp.channel, Reason: reason})
+}
+
+// deliver queues a message of the stream for the computation.
+func (comp *Computation) deliver(m *message) {
+	comp.mu.Lock()
+	comp.pending = append(comp.pending, m)
+	comp.mu.Unlock()
+
+	select {
+	case comp.wake <- struct{}{}:
+	default:
+	}
+}
+
+// end ends the computation, dropping the messages it did not process.
+func (comp *Computation) end() {
+	comp.stopOnce.Do(func() { close(comp.stop) })
+}
+
+// run processes the messages of the computation, so that a computation
+// whose channels are not drained does not stall the others.
+func (comp *Computation) run() {
+	defer func() {
+		close(comp.data)
+		close(comp.metadata)
+		close(comp.events)
+		close(comp.errors)
+		close(comp.done)
+	}()
+	for {
+		select {
+		case <-comp.wake:
+		case <-comp.stop:
+			return
+		}
+		comp.mu.Lock()
+		pending := comp.pending
+		comp.pending = nil
+		comp.mu.Unlock()
+
+		for _, m := range pending {
+			if !comp.process(m) {
+				comp.client.forget(comp)
+				return
+			}
+		}
+	}
+}
+
+// process handles a message, and returns whether the computation goes on.
+func (comp *Computation) process(m *message) bool {
+	switch m.Type {
+	case "data":
+		return send(comp, comp.data, m.data)
+	case "metadata":
+		md := &Metadata{TSID: idtool.IDFromString(m.TSID), Properties: m.Properties}
+		comp.mu.Lock()
+		comp.tsids[md.TSID] = md
+		comp.mu.Unlock()
+		offer(comp.metadata, md)
+	case "event":
+		offer(comp.events, &Event{
+			TSID:       idtool.IDFromString(m.TSID),
+			Timestamp:  time.UnixMilli(m.TimestampMs),
+			Metadata:   m.Metadata,
+			Properties: m.Properties,
+		})
+	case "control-message":
+		switch m.Event {
+		case "JOB_START":
+			comp.mu.Lock()
+			comp.handle = m.Handle
+			comp.mu.Unlock()
+			closeOnce(comp.handleKnown)
+		case "END_OF_CHANNEL", "CHANNEL_ABORT":
+			return false
+		}
+	case "message":
+		var i info
+		if json.Unmarshal(m.Message, &i) == nil && i.MessageCode == "JOB_RUNNING_RESOLUTION" {
+			if ms, ok := i.Contents["resolutionMs"].(float64); ok {
+				comp.mu.Lock()
+				comp.resolution = time.Duration(ms) * time.Millisecond
+				comp.mu.Unlock()
+				closeOnce(comp.resolutionKnown)
+			}
+		}
+	case "error":
+		comp.errors <- m.streamError()
+		return false
+	}
+	return true
+}
+
+// closeOnce closes a channel unless it is closed. Only the goroutine of the
+// computation closes its channels.
+func closeOnce(ch chan struct{}) {
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// send sends a value to a channel of a computation, unless the computation
+// ends first.
+func send[T any](comp *Computation, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-comp.stop:
+		return false
+	}
+}
+
+// offer sends a value to a channel unless its buffer is full, in which case
+// the value is dropped.
+func offer[T any](ch chan<- T, v T) {
+	select {
+	case ch <- v:
+	default:
+	}
+}
@@ -0,0 +1,283 @@
+package signalflow
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/signalfx/signalfx-go/idtool"
+)
+
+// ExecuteRequest executes a program and streams its output.
+type ExecuteRequest struct {
+	Program string
+	// Start and Stop bound the computation. A zero Start starts it now, and
+	// a zero Stop keeps it running until it is stopped or detached.
+	Start time.Time
+	Stop  time.Time
+	// Resolution of the data, picked by the API when zero.
+	Resolution time.Duration
+	// MaxDelay is how long the computation waits for late data, picked by
+	// the API when zero.
+	MaxDelay time.Duration
+	// Immediate streams the data of a historical computation as soon as it
+	// is computed, rather than at the pace of its resolution.
+	Immediate bool
+	Timezone  string
+}
+
+// PreflightRequest computes how many times the detector of a program would
+// have fired over a period, and streams the events it would have
+// generated.
+type PreflightRequest struct {
+	Program string
+	Start   time.Time
+	Stop    time.Time
+}
+
+// StartRequest starts a program in the background, without streaming its
+// output, as for the programs publishing events.
+type StartRequest struct {
+	Program    string
+	Start      time.Time
+	Stop       time.Time
+	Resolution time.Duration
+	MaxDelay   time.Duration
+}
+
+// StopRequest stops a computation, identified by its handle.
+type StopRequest struct {
+	Handle string
+	Reason string
+}
+
+// request is a message sent to the stream endpoint.
+type request struct {
+	Type       string `json:"type"`
+	Channel    string `json:"channel,omitempty"`
+	Token      string `json:"token,omitempty"`
+	Program    string `json:"program,omitempty"`
+	Start      int64  `json:"start,omitempty"`
+	Stop       int64  `json:"stop,omitempty"`
+	Resolution int64  `json:"resolution,omitempty"`
+	MaxDelay   int64  `json:"maxDelay,omitempty"`
+	Immediate  bool   `json:"immediate,omitempty"`
+	Timezone   string `json:"timezone,omitempty"`
+	Handle     string `json:"handle,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// millis converts a time to milliseconds since the epoch, the zero time
+// being 0.
+func millis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// DataBatch is the data of a computation at a point in time.
+type DataBatch struct {
+	Timestamp time.Time
+	// MaxDelay is the delay the computation waited for late data, when the
+	// stream reports it.
+	MaxDelay time.Duration
+	Points   []DataPoint
+}
+
+// DataPoint is the value of a time series, whose metadata the computation
+// streamed before.
+type DataPoint struct {
+	TSID idtool.ID
+	// Value is an int64 or a float64.
+	Value any
+}
+
+// Metadata describes a time series of a computation.
+type Metadata struct {
+	TSID idtool.ID
+	// Properties are the dimensions, properties and tags of the time series,
+	// along with fields of the API such as sf_metric.
+	Properties map[string]any
+}
+
+// Dimensions returns the dimensions of the time series, listed by the
+// sf_key property.
+func (m *Metadata) Dimensions() map[string]string {
+	dims := map[string]string{}
+	keys, _ := m.Properties["sf_key"].([]any)
+	for _, k := range keys {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		if v, ok := m.Properties[key].(string); ok {
+			dims[key] = v
+		}
+	}
+	return dims
+}
+
+// Event is an event of a computation, such as an alert of a detector.
+type Event struct {
+	TSID       idtool.ID
+	Timestamp  time.Time
+	Metadata   map[string]any
+	Properties map[string]any
+}
+
+// StreamError is an error reported by the stream endpoint, which ends the
+// computation it is reported for.
+type StreamError struct {
+	Code    int
+	Type    string
+	Message string
+}
+
+func (e *StreamError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("signalflow: %d %s: %s", e.Code, e.Type, e.Message)
+	}
+	return fmt.Sprintf("signalflow: %d: %s", e.Code, e.Message)
+}
+
+// message is a message of the stream, decoded from JSON or, for data, from
+// the binary encoding.
+type message struct {
+	Type        string          `json:"type"`
+	Channel     string          `json:"channel"`
+	Event       string          `json:"event"`
+	Handle      string          `json:"handle"`
+	TSID        string          `json:"tsId"`
+	TimestampMs int64           `json:"timestampMs"`
+	Properties  map[string]any  `json:"properties"`
+	Metadata    map[string]any  `json:"metadata"`
+	Error       int             `json:"error"`
+	ErrorType   string          `json:"errorType"`
+	Message     json.RawMessage `json:"message"`
+
+	data *DataBatch
+	// err is the error of the client ending the computation, for "error"
+	// messages that do not come from the stream.
+	err error
+}
+
+// info is the content of the "message" messages, such as the resolution
+// of a computation.
+type info struct {
+	MessageCode string         `json:"messageCode"`
+	Contents    map[string]any `json:"contents"`
+}
+
+// streamError returns the error of an "error" message.
+func (m *message) streamError() error {
+	if m.err != nil {
+		return m.err
+	}
+	e := &StreamError{Code: m.Error, Type: m.ErrorType}
+	if err := json.Unmarshal(m.Message, &e.Message); err != nil {
+		e.Message = string(m.Message)
+	}
+	return e
+}
+
+// Binary messages start with a header: the version of the encoding, the
+// type of the message, flags and a reserved byte, followed by the name of
+// the channel, padded with zeros.
+const (
+	channelSize = 16
+	headerSize  = 4 + channelSize
+
+	dataType = 5
+
+	compressedFlag = 1 << 0
+	jsonFlag       = 1 << 1
+)
+
+// Types of the values of data points.
+const (
+	longValue   = 1
+	doubleValue = 2
+	intValue    = 3
+)
+
+var errShortMessage = errors.New("truncated binary message")
+
+// decodeBinary decodes a binary message: either data, or a compressed JSON
+// message.
+func decodeBinary(b []byte) (*message, error) {
+	if len(b) < headerSize {
+		return nil, errShortMessage
+	}
+	version, typ, flags := b[0], b[1], b[2]
+	channel := string(bytes.TrimRight(b[4:headerSize], "\x00"))
+	payload := b[headerSize:]
+	if flags&compressedFlag != 0 {
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if payload, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+	if flags&jsonFlag != 0 {
+		return decodeJSON(payload)
+	}
+	if typ != dataType {
+		return nil, fmt.Errorf("unknown binary message type %d", typ)
+	}
+
+	// Data starts with its timestamp, then, from version 2, the max delay,
+	// then the number of points, each a type, a TSID and a value.
+	batch := &DataBatch{}
+	size := 12
+	if version >= 2 {
+		size = 20
+	}
+	if len(payload) < size {
+		return nil, errShortMessage
+	}
+	batch.Timestamp = time.UnixMilli(int64(binary.BigEndian.Uint64(payload)))
+	if version >= 2 {
+		batch.MaxDelay = time.Duration(binary.BigEndian.Uint64(payload[8:])) * time.Millisecond
+	}
+	count := int(binary.BigEndian.Uint32(payload[size-4:]))
+	points := payload[size:]
+	if len(points) < count*17 {
+		return nil, errShortMessage
+	}
+	batch.Points = make([]DataPoint, count)
+	for i := range batch.Points {
+		p := points[i*17 : (i+1)*17]
+		tsid := idtool.ID(binary.BigEndian.Uint64(p[1:]))
+		raw := binary.BigEndian.Uint64(p[9:])
+		var value any
+		switch p[0] {
+		case longValue:
+			value = int64(raw)
+		case doubleValue:
+			value = math.Float64frombits(raw)
+		case intValue:
+			value = int64(int32(raw))
+		default:
+			return nil, fmt.Errorf("unknown value type %d", p[0])
+		}
+		batch.Points[i] = DataPoint{TSID: tsid, Value: value}
+	}
+	return &message{Type: "data", Channel: channel, data: batch}, nil
+}
+
+func decodeJSON(b []byte) (*message, error) {
+	m := &message{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
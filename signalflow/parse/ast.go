@@ -0,0 +1,335 @@
+package parse
+
+import "fmt"
+
+// Pos is a position in the text of a program. Lines and columns start at 1,
+// and columns count characters.
+type Pos struct {
+	Line   int
+	Column int
+}
+
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Node is a node of the syntax tree of a program.
+type Node interface {
+	Pos() Pos
+}
+
+// Stmt is a statement.
+type Stmt interface {
+	Node
+	stmt()
+}
+
+// Expr is an expression.
+type Expr interface {
+	Node
+	expr()
+}
+
+// Program is a parsed program.
+type Program struct {
+	Statements []Stmt
+}
+
+// Statements.
+type (
+	// Assign assigns a value to one or more variables, as in `A, B = x`.
+	Assign struct {
+		Targets []*Ident
+		Value   Expr
+	}
+
+	// ExprStmt is an expression used as a statement, as a published stream.
+	ExprStmt struct {
+		X Expr
+	}
+
+	// Import imports a module, as in `import m as n`, or names from a
+	// module, as in `from m import a, b`.
+	Import struct {
+		At     Pos
+		Module string
+		// Names are the imported names, as bound in the program. Modules
+		// bind their first component, or their alias.
+		Names []*Ident
+	}
+
+	// FuncDef defines a function.
+	FuncDef struct {
+		At     Pos
+		Name   *Ident
+		Params []*Param
+		Body   []Stmt
+	}
+
+	// Return returns from a function.
+	Return struct {
+		At    Pos
+		Value Expr
+	}
+)
+
+// Param is a parameter of a function, with its default value if any.
+type Param struct {
+	Name    *Ident
+	Default Expr
+}
+
+// Expressions.
+type (
+	// Ident is a name, such as a variable or a function.
+	Ident struct {
+		At   Pos
+		Name string
+	}
+
+	// String is a string literal, with its escapes decoded.
+	String struct {
+		At    Pos
+		Value string
+	}
+
+	// Number is a number literal, as written.
+	Number struct {
+		At    Pos
+		Value string
+	}
+
+	// List is a list literal.
+	List struct {
+		At    Pos
+		Elems []Expr
+	}
+
+	// Tuple is a tuple, as in `(a, b)`.
+	Tuple struct {
+		At    Pos
+		Elems []Expr
+	}
+
+	// Dict is a dictionary literal.
+	Dict struct {
+		At     Pos
+		Keys   []Expr
+		Values []Expr
+	}
+
+	// Call calls a function, as in `data('cpu')`, or a method, when Func is
+	// an Attribute, as in `A.mean(by=['host'])`.
+	Call struct {
+		Func Expr
+		Args []*Arg
+	}
+
+	// Attribute is an attribute of a value, such as a method of a stream.
+	Attribute struct {
+		X    Expr
+		Name *Ident
+	}
+
+	// Index indexes a value, as in `x[0]`.
+	Index struct {
+		X     Expr
+		Index Expr
+	}
+
+	// Unary is a unary operation, as in `-x` or `not x`.
+	Unary struct {
+		At Pos
+		Op string
+		X  Expr
+	}
+
+	// Binary is a binary operation, including comparisons and boolean
+	// operations, as in `A > 5` or `x and y`.
+	Binary struct {
+		Op string
+		X  Expr
+		Y  Expr
+	}
+
+	// Conditional is a conditional expression, as in `a if c else b`.
+	Conditional struct {
+		Then Expr
+		Cond Expr
+		Else Expr
+	}
+
+	// Lambda is an anonymous function.
+	Lambda struct {
+		At     Pos
+		Params []*Param
+		Body   Expr
+	}
+)
+
+// Arg is an argument of a call, keyword arguments having a name.
+type Arg struct {
+	Name  *Ident
+	Value Expr
+}
+
+func (s *Assign) Pos() Pos   { return s.Targets[0].At }
+func (s *ExprStmt) Pos() Pos { return s.X.Pos() }
+func (s *Import) Pos() Pos   { return s.At }
+func (s *FuncDef) Pos() Pos  { return s.At }
+func (s *Return) Pos() Pos   { return s.At }
+
+func (e *Ident) Pos() Pos       { return e.At }
+func (e *String) Pos() Pos      { return e.At }
+func (e *Number) Pos() Pos      { return e.At }
+func (e *List) Pos() Pos        { return e.At }
+func (e *Tuple) Pos() Pos       { return e.At }
+func (e *Dict) Pos() Pos        { return e.At }
+func (e *Call) Pos() Pos        { return e.Func.Pos() }
+func (e *Attribute) Pos() Pos   { return e.X.Pos() }
+func (e *Index) Pos() Pos       { return e.X.Pos() }
+func (e *Unary) Pos() Pos       { return e.At }
+func (e *Binary) Pos() Pos      { return e.X.Pos() }
+func (e *Conditional) Pos() Pos { return e.Then.Pos() }
+func (e *Lambda) Pos() Pos      { return e.At }
+
+func (*Assign) stmt()   {}
+func (*ExprStmt) stmt() {}
+func (*Import) stmt()   {}
+func (*FuncDef) stmt()  {}
+func (*Return) stmt()   {}
+
+func (*Ident) expr()       {}
+func (*String) expr()      {}
+func (*Number) expr()      {}
+func (*List) expr()        {}
+func (*Tuple) expr()       {}
+func (*Dict) expr()        {}
+func (*Call) expr()        {}
+func (*Attribute) expr()   {}
+func (*Index) expr()       {}
+func (*Unary) expr()       {}
+func (*Binary) expr()      {}
+func (*Conditional) expr() {}
+func (*Lambda) expr()      {}
+
+// Name returns the name of the function or method called, such as "data"
+// or "publish", or "" when the callee is an expression.
+func (c *Call) Name() string {
+	switch f := c.Func.(type) {
+	case *Ident:
+		return f.Name
+	case *Attribute:
+		return f.Name.Name
+	}
+	return ""
+}
+
+// Receiver returns the value a method is called on, such as the stream of
+// `A.publish()`, or nil for function calls.
+func (c *Call) Receiver() Expr {
+	if a, ok := c.Func.(*Attribute); ok {
+		return a.X
+	}
+	return nil
+}
+
+// Arg returns the argument of a parameter, passed by keyword or at a
+// position, or nil if it is not passed. A negative position matches
+// keyword arguments only.
+func (c *Call) Arg(name string, position int) Expr {
+	for _, a := range c.Args {
+		if a.Name != nil && a.Name.Name == name {
+			return a.Value
+		}
+	}
+	if position < 0 {
+		return nil
+	}
+	for i, a := range c.Args {
+		if a.Name != nil {
+			break
+		}
+		if i == position {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// Inspect traverses the tree of node in depth-first order, calling f for
+// each node, and for its children unless f returns false.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+	switch n := node.(type) {
+	case *Assign:
+		for _, t := range n.Targets {
+			Inspect(t, f)
+		}
+		Inspect(n.Value, f)
+	case *ExprStmt:
+		Inspect(n.X, f)
+	case *Import:
+		for _, name := range n.Names {
+			Inspect(name, f)
+		}
+	case *FuncDef:
+		Inspect(n.Name, f)
+		inspectParams(n.Params, f)
+		for _, s := range n.Body {
+			Inspect(s, f)
+		}
+	case *Return:
+		if n.Value != nil {
+			Inspect(n.Value, f)
+		}
+	case *List:
+		inspectExprs(n.Elems, f)
+	case *Tuple:
+		inspectExprs(n.Elems, f)
+	case *Dict:
+		for i := range n.Keys {
+			Inspect(n.Keys[i], f)
+			Inspect(n.Values[i], f)
+		}
+	case *Call:
+		Inspect(n.Func, f)
+		for _, a := range n.Args {
+			Inspect(a.Value, f)
+		}
+	case *Attribute:
+		Inspect(n.X, f)
+	case *Index:
+		Inspect(n.X, f)
+		Inspect(n.Index, f)
+	case *Unary:
+		Inspect(n.X, f)
+	case *Binary:
+		Inspect(n.X, f)
+		Inspect(n.Y, f)
+	case *Conditional:
+		Inspect(n.Then, f)
+		Inspect(n.Cond, f)
+		Inspect(n.Else, f)
+	case *Lambda:
+		inspectParams(n.Params, f)
+		Inspect(n.Body, f)
+	}
+}
+
+func inspectExprs(exprs []Expr, f func(Node) bool) {
+	for _, e := range exprs {
+		Inspect(e, f)
+	}
+}
+
+func inspectParams(params []*Param, f func(Node) bool) {
+	for _, p := range params {
+		Inspect(p.Name, f)
+		if p.Default != nil {
+			Inspect(p.Default, f)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of a token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNewline
+	tokIndent
+	tokDedent
+	tokName
+	tokNumber
+	tokString
+	tokOp
+)
+
+func (k tokenKind) String() string {
+	return [...]string{"end of program", "end of line", "indent", "dedent", "name", "number", "string", "operator"}[k]
+}
+
+// token is a token of a program. The value of strings is decoded.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   Pos
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokName, tokNumber, tokOp:
+		return fmt.Sprintf("%q", t.value)
+	case tokString:
+		return "string"
+	}
+	return t.kind.String()
+}
+
+// operators lists the operators, longest first.
+var operators = []string{
+	"**", "//", "==", "!=", "<=", ">=",
+	"<", ">", "+", "-", "*", "/", "%", "=", "(", ")", "[", "]", "{", "}", ",", ":", ".",
+}
+
+// lexer splits a program into tokens, with the indentation of the lines as
+// indent and dedent tokens, the way Python does.
+type lexer struct {
+	src    []rune
+	off    int
+	line   int
+	col    int
+	depth  int
+	indent []int
+	tokens []token
+	// bol is whether the lexer is at the beginning of a logical line.
+	bol bool
+}
+
+func tokenize(src string) ([]token, error) {
+	l := &lexer{src: []rune(src), line: 1, col: 1, indent: []int{0}, bol: true}
+	for {
+		if err := l.next(); err != nil {
+			return nil, err
+		}
+		if n := len(l.tokens); n > 0 && l.tokens[n-1].kind == tokEOF {
+			return l.tokens, nil
+		}
+	}
+}
+
+func (l *lexer) pos() Pos {
+	return Pos{Line: l.line, Column: l.col}
+}
+
+func (l *lexer) peek(n int) rune {
+	if l.off+n < len(l.src) {
+		return l.src[l.off+n]
+	}
+	return 0
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.off]
+	l.off++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) emit(kind tokenKind, value string, pos Pos) {
+	l.tokens = append(l.tokens, token{kind: kind, value: value, pos: pos})
+}
+
+func (l *lexer) errorf(pos Pos, format string, args ...any) error {
+	return &SyntaxError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next emits the next tokens.
+func (l *lexer) next() error {
+	if l.bol {
+		return l.lineStart()
+	}
+	// Skip blanks, comments and continued lines.
+skip:
+	for l.off < len(l.src) {
+		switch r := l.peek(0); {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\f':
+			l.advance()
+		case r == '#':
+			for l.off < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		case r == '\\' && l.peek(1) == '\n':
+			l.advance()
+			l.advance()
+		case r == '\n' && l.depth > 0:
+			// Lines break freely within brackets.
+			l.advance()
+		default:
+			break skip
+		}
+	}
+	pos := l.pos()
+	if l.off >= len(l.src) {
+		l.emit(tokNewline, "", pos)
+		l.bol = true
+		return nil
+	}
+	r := l.peek(0)
+	switch {
+	case r == '\n':
+		l.advance()
+		l.emit(tokNewline, "", pos)
+		l.bol = true
+	case r == '_' || unicode.IsLetter(r):
+		if l.stringPrefix() {
+			return l.string()
+		}
+		start := l.off
+		for l.off < len(l.src) && (l.peek(0) == '_' || unicode.IsLetter(l.peek(0)) || unicode.IsDigit(l.peek(0))) {
+			l.advance()
+		}
+		l.emit(tokName, string(l.src[start:l.off]), pos)
+	case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+		return l.number()
+	case r == '\'' || r == '"':
+		return l.string()
+	default:
+		for _, op := range operators {
+			if l.hasPrefix(op) {
+				for range op {
+					l.advance()
+				}
+				switch op {
+				case "(", "[", "{":
+					l.depth++
+				case ")", "]", "}":
+					if l.depth == 0 {
+						return l.errorf(pos, "unmatched %q", op)
+					}
+					l.depth--
+				}
+				l.emit(tokOp, op, pos)
+				return nil
+			}
+		}
+		return l.errorf(pos, "unexpected character %q", r)
+	}
+	return nil
+}
+
+// lineStart measures the indentation of the next non blank line, and emits
+// the indent or dedent tokens it implies, or the end of the program.
+func (l *lexer) lineStart() error {
+	for {
+		width := 0
+		for l.off < len(l.src) && (l.peek(0) == ' ' || l.peek(0) == '\t') {
+			if l.advance() == '\t' {
+				width += 8 - width%8
+			} else {
+				width++
+			}
+		}
+		if l.peek(0) == '#' {
+			for l.off < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+		}
+		if l.off >= len(l.src) {
+			for len(l.indent) > 1 {
+				l.indent = l.indent[:len(l.indent)-1]
+				l.emit(tokDedent, "", l.pos())
+			}
+			l.emit(tokEOF, "", l.pos())
+			return nil
+		}
+		switch l.peek(0) {
+		case '\n', '\r':
+			l.advance()
+			continue
+		}
+		l.bol = false
+		pos := l.pos()
+		current := l.indent[len(l.indent)-1]
+		switch {
+		case width > current:
+			l.indent = append(l.indent, width)
+			l.emit(tokIndent, "", pos)
+		case width < current:
+			for width < l.indent[len(l.indent)-1] {
+				l.indent = l.indent[:len(l.indent)-1]
+				l.emit(tokDedent, "", pos)
+			}
+			if width != l.indent[len(l.indent)-1] {
+				return l.errorf(pos, "unindent does not match any outer indentation level")
+			}
+		}
+		return nil
+	}
+}
+
+func (l *lexer) hasPrefix(s string) bool {
+	for i, r := range s {
+		if l.peek(i) != r {
+			return false
+		}
+	}
+	return true
+}
+
+// stringPrefix returns whether a string with a prefix, such as r'x', is
+// next.
+func (l *lexer) stringPrefix() bool {
+	switch l.peek(0) {
+	case 'r', 'R', 'u', 'U':
+		q := l.peek(1)
+		return q == '\'' || q == '"'
+	}
+	return false
+}
+
+func (l *lexer) number() error {
+	pos := l.pos()
+	start := l.off
+	digits := func() {
+		for unicode.IsDigit(l.peek(0)) {
+			l.advance()
+		}
+	}
+	digits()
+	if l.peek(0) == '.' {
+		l.advance()
+		digits()
+	}
+	if r := l.peek(0); r == 'e' || r == 'E' {
+		l.advance()
+		if r := l.peek(0); r == '+' || r == '-' {
+			l.advance()
+		}
+		if !unicode.IsDigit(l.peek(0)) {
+			return l.errorf(l.pos(), "malformed number")
+		}
+		digits()
+	}
+	if r := l.peek(0); r == '_' || unicode.IsLetter(r) {
+		return l.errorf(l.pos(), "malformed number")
+	}
+	l.emit(tokNumber, string(l.src[start:l.off]), pos)
+	return nil
+}
+
+// escapes are the escape sequences of strings.
+var escapes = map[rune]string{
+	'\\': `\`, '\'': `'`, '"': `"`, 'n': "\n", 't': "\t", 'r': "\r", '0': "\x00", '\n': "",
+}
+
+func (l *lexer) string() error {
+	pos := l.pos()
+	raw := false
+	if r := l.peek(0); r != '\'' && r != '"' {
+		raw = r == 'r' || r == 'R'
+		l.advance()
+	}
+	quote := l.advance()
+	triple := l.peek(0) == quote && l.peek(1) == quote
+	if triple {
+		l.advance()
+		l.advance()
+	}
+	var b strings.Builder
+	for {
+		if l.off >= len(l.src) || (!triple && l.peek(0) == '\n') {
+			return l.errorf(pos, "unterminated string")
+		}
+		r := l.advance()
+		switch {
+		case r == quote && (!triple || (l.peek(0) == quote && l.peek(1) == quote)):
+			if triple {
+				l.advance()
+				l.advance()
+			}
+			l.emit(tokString, b.String(), pos)
+			return nil
+		case r == '\\' && l.off < len(l.src):
+			next := l.advance()
+			if s, ok := escapes[next]; ok && !raw {
+				b.WriteString(s)
+			} else {
+				b.WriteRune('\\')
+				b.WriteRune(next)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package parse
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+)
+
+// The rules of the linter.
+const (
+	// RuleUndefined reports the names that are neither defined by the
+	// program nor built in.
+	RuleUndefined = "undefined-variable"
+	// RuleDuplicateLabel reports the labels published more than once, whose
+	// streams cannot be told apart.
+	RuleDuplicateLabel = "duplicate-label"
+	// RuleUnusedStream reports the streams assigned to variables that are
+	// never used nor published.
+	RuleUnusedStream = "unused-stream"
+	// RuleDeprecated reports the calls of deprecated functions.
+	RuleDeprecated = "deprecated-function"
+)
+
+// Issue is a problem found in a program.
+type Issue struct {
+	Pos     Pos
+	Rule    string
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Pos, i.Message, i.Rule)
+}
+
+// builtins are the names programs can use without defining them: the
+// functions and constants of SignalFlow and the Python builtins it
+// supports.
+var builtins = map[string]bool{
+	"True": true, "False": true, "None": true,
+
+	"abs": true, "alerts": true, "ceil": true, "combine": true, "const": true,
+	"count": true, "data": true, "detect": true, "duration": true, "events": true,
+	"exp": true, "filter": true, "floor": true, "graphite": true, "log": true,
+	"log10": true, "max": true, "mean": true, "median": true, "min": true,
+	"newrelic": true, "partition_filter": true, "percentile": true, "pow": true,
+	"sqrt": true, "stddev": true, "sum": true, "threshold": true, "union": true,
+	"variance": true, "when": true,
+
+	"bool": true, "dict": true, "float": true, "int": true, "len": true,
+	"list": true, "map": true, "print": true, "range": true, "str": true,
+	"tuple": true, "zip": true,
+}
+
+// IsBuiltin returns whether programs can use a name without defining it.
+func IsBuiltin(name string) bool {
+	return builtins[name]
+}
+
+// streamFunctions are the functions returning streams.
+var streamFunctions = map[string]bool{
+	"alerts": true, "combine": true, "const": true, "data": true, "detect": true,
+	"events": true, "graphite": true, "newrelic": true, "threshold": true, "union": true,
+}
+
+// Linter checks programs. It is safe for concurrent use.
+type Linter struct {
+	builtins   map[string]bool
+	deprecated map[string]string
+}
+
+// LinterOption is an option for NewLinter.
+type LinterOption func(*Linter)
+
+// Builtin makes the linter accept names programs use without defining
+// them, in addition to those of SignalFlow, such as the names of the
+// functions of an environment.
+func Builtin(names ...string) LinterOption {
+	return func(lt *Linter) {
+		for _, name := range names {
+			lt.builtins[name] = true
+		}
+	}
+}
+
+// Deprecate makes the linter report the calls of a function, with advice,
+// such as the function replacing it.
+func Deprecate(name, advice string) LinterOption {
+	return func(lt *Linter) {
+		lt.deprecated[name] = advice
+	}
+}
+
+// NewLinter returns a linter accepting the builtins of SignalFlow, and
+// reporting the calls of the functions deprecated with options.
+func NewLinter(options ...LinterOption) *Linter {
+	lt := &Linter{builtins: maps.Clone(builtins), deprecated: map[string]string{}}
+	for _, option := range options {
+		option(lt)
+	}
+	return lt
+}
+
+// defaultLinter is the linter of Lint.
+var defaultLinter = NewLinter()
+
+// Lint checks a program with the default linter, which reports no
+// deprecated functions.
+func Lint(prog *Program) []Issue {
+	return defaultLinter.Lint(prog)
+}
+
+// Lint checks a program for undefined variables, duplicate publish labels,
+// unused streams and deprecated functions, and returns the issues found in
+// the order of the program.
+func (lt *Linter) Lint(prog *Program) []Issue {
+	l := &linter{Linter: lt, labels: map[string]Pos{}}
+	l.statements(prog.Statements, newScope(nil, true))
+	// Function bodies run once the module is defined, and see all of it.
+	for len(l.funcs) > 0 {
+		f := l.funcs[0]
+		l.funcs = l.funcs[1:]
+		f()
+	}
+	for _, b := range l.bindings {
+		if b.stream && !b.used {
+			l.report(b.ident.At, RuleUnusedStream, "stream %q is never used nor published", b.ident.Name)
+		}
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i].Pos, l.issues[j].Pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return l.issues
+}
+
+// linter holds the state of a run of a Linter.
+type linter struct {
+	*Linter
+	issues   []Issue
+	bindings []*binding
+	labels   map[string]Pos
+	funcs    []func()
+}
+
+// binding is a definition of a variable.
+type binding struct {
+	ident *Ident
+	// stream is whether the variable is an unpublished stream.
+	stream bool
+	used   bool
+}
+
+// scope is the module or a function. Names are defined as statements run
+// in the module, and all at once in functions.
+type scope struct {
+	parent     *scope
+	sequential bool
+	names      map[string][]*binding
+}
+
+func newScope(parent *scope, sequential bool) *scope {
+	return &scope{parent: parent, sequential: sequential, names: map[string][]*binding{}}
+}
+
+func (l *linter) report(pos Pos, rule, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Pos: pos, Rule: rule, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) define(s *scope, id *Ident, stream bool) {
+	b := &binding{ident: id, stream: stream}
+	s.names[id.Name] = append(s.names[id.Name], b)
+	l.bindings = append(l.bindings, b)
+}
+
+// find returns the binding of a name in effect in a scope, if any.
+func (s *scope) find(name string) (*scope, *binding) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if bs := sc.names[name]; len(bs) > 0 {
+			return sc, bs[len(bs)-1]
+		}
+	}
+	return nil, nil
+}
+
+// use marks the definitions a name refers to as used: the last one when
+// the name is used where it runs in order, or all of them otherwise.
+func (s *scope) use(name string) bool {
+	sc, b := s.find(name)
+	if b == nil {
+		return false
+	}
+	if sc == s && sc.sequential {
+		b.used = true
+		return true
+	}
+	for _, b := range sc.names[name] {
+		b.used = true
+	}
+	return true
+}
+
+// defines lists the names a statement defines in its scope.
+func defines(stmt Stmt) []*Ident {
+	switch s := stmt.(type) {
+	case *Assign:
+		return s.Targets
+	case *Import:
+		return s.Names
+	case *FuncDef:
+		return []*Ident{s.Name}
+	}
+	return nil
+}
+
+func (l *linter) statements(stmts []Stmt, s *scope) {
+	if !s.sequential {
+		for _, stmt := range stmts {
+			for _, id := range defines(stmt) {
+				l.define(s, id, isUnpublished(stmt, s))
+			}
+		}
+	}
+	for _, stmt := range stmts {
+		switch st := stmt.(type) {
+		case *Assign:
+			l.expr(st.Value, s)
+		case *ExprStmt:
+			l.expr(st.X, s)
+		case *Return:
+			if st.Value != nil {
+				l.expr(st.Value, s)
+			}
+		case *FuncDef:
+			for _, p := range st.Params {
+				if p.Default != nil {
+					l.expr(p.Default, s)
+				}
+			}
+			l.funcs = append(l.funcs, func() {
+				body := newScope(s, false)
+				for _, p := range st.Params {
+					l.define(body, p.Name, false)
+				}
+				l.statements(st.Body, body)
+			})
+		}
+		if s.sequential {
+			for _, id := range defines(stmt) {
+				l.define(s, id, isUnpublished(stmt, s))
+			}
+		}
+	}
+}
+
+func (l *linter) expr(e Expr, s *scope) {
+	Inspect(e, func(n Node) bool {
+		switch n := n.(type) {
+		case *Ident:
+			if !s.use(n.Name) && !l.builtins[n.Name] {
+				l.report(n.At, RuleUndefined, "undefined variable %q", n.Name)
+			}
+		case *Call:
+			l.call(n, s)
+		case *Lambda:
+			body := newScope(s, false)
+			for _, p := range n.Params {
+				if p.Default != nil {
+					l.expr(p.Default, s)
+				}
+				l.define(body, p.Name, false)
+			}
+			l.expr(n.Body, body)
+			return false
+		}
+		return true
+	})
+}
+
+func (l *linter) call(c *Call, s *scope) {
+	if f, ok := c.Func.(*Ident); ok {
+		if _, b := s.find(f.Name); b == nil {
+			if advice, ok := l.deprecated[f.Name]; ok {
+				l.report(f.At, RuleDeprecated, "%s() is deprecated: %s", f.Name, advice)
+			}
+		}
+	}
+	if c.Name() != "publish" || c.Receiver() == nil {
+		return
+	}
+	label, ok := c.Arg("label", 0).(*String)
+	if !ok {
+		return
+	}
+	if pos, ok := l.labels[label.Value]; ok {
+		l.report(label.At, RuleDuplicateLabel, "publish label %q is already used at line %d", label.Value, pos.Line)
+		return
+	}
+	l.labels[label.Value] = label.At
+}
+
+// isUnpublished returns whether a statement assigns a stream it does not
+// publish to a single variable.
+func isUnpublished(stmt Stmt, s *scope) bool {
+	a, ok := stmt.(*Assign)
+	return ok && len(a.Targets) == 1 && isStream(a.Value, s) && !publishes(a.Value)
+}
+
+// isStream returns whether an expression evaluates to a stream.
+func isStream(e Expr, s *scope) bool {
+	switch e := e.(type) {
+	case *Ident:
+		_, b := s.find(e.Name)
+		return b != nil && b.stream
+	case *Call:
+		if r := e.Receiver(); r != nil {
+			return isStream(r, s)
+		}
+		f, ok := e.Func.(*Ident)
+		if !ok {
+			return false
+		}
+		_, b := s.find(f.Name)
+		return b == nil && streamFunctions[f.Name]
+	case *Attribute:
+		return isStream(e.X, s)
+	case *Unary:
+		return isStream(e.X, s)
+	case *Binary:
+		return isStream(e.X, s) || isStream(e.Y, s)
+	case *Conditional:
+		return isStream(e.Then, s) || isStream(e.Else, s)
+	}
+	return false
+}
+
+// publishes returns whether the method chain of an expression publishes its
+// stream.
+func publishes(e Expr) bool {
+	for {
+		c, ok := e.(*Call)
+		if !ok {
+			return false
+		}
+		if c.Name() == "publish" && c.Receiver() != nil {
+			return true
+		}
+		if e = c.Receiver(); e == nil {
+			return false
+		}
+	}
+}
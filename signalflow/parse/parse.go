@@ -0,0 +1,635 @@
+// Package parse parses SignalFlow programs, such as the programs of
+// detectors and charts, into a syntax tree, and lints them without calling
+// the API:
+//
+//	prog, err := parse.Parse(d.ProgramText)
+//	if err != nil {
+//		return err // a *parse.SyntaxError
+//	}
+//	for _, issue := range parse.Lint(prog) {
+//		fmt.Println(issue)
+//	}
+//
+// NewLinter returns a linter that also accepts the builtins of an
+// environment and reports the functions an organization deprecates.
+//
+// SignalFlow follows the syntax of Python: the parser supports the
+// statements programs use, namely assignments, expressions, imports and
+// function definitions, and the expressions of Python but comprehensions
+// and starred arguments.
+package parse
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SyntaxError is the error returned for programs that do not parse.
+type SyntaxError struct {
+	Pos Pos
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// keywords are the names that cannot be used as variables.
+var keywords = []string{
+	"and", "as", "def", "elif", "else", "from", "if", "import", "in", "is",
+	"lambda", "not", "or", "return",
+}
+
+// Parse parses the text of a program.
+func Parse(src string) (*Program, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	prog := &Program{}
+	for p.tok().kind != tokEOF {
+		if p.tok().kind == tokNewline {
+			p.pos++
+			continue
+		}
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		prog.Statements = append(prog.Statements, s)
+	}
+	return prog, nil
+}
+
+// parser is a recursive descent parser.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) tok() token {
+	return p.tokens[p.pos]
+}
+
+// is returns whether the current token is an operator or keyword.
+func (p *parser) is(values ...string) bool {
+	t := p.tok()
+	return (t.kind == tokOp || t.kind == tokName) && slices.Contains(values, t.value)
+}
+
+// accept consumes the current token if it is an operator or keyword.
+func (p *parser) accept(values ...string) bool {
+	if p.is(values...) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) (token, error) {
+	t := p.tok()
+	if !p.accept(value) {
+		return t, p.unexpected(fmt.Sprintf("%q", value))
+	}
+	return t, nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.tok()
+	return &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected %s, found %s", expected, t)}
+}
+
+func (p *parser) ident() (*Ident, error) {
+	t := p.tok()
+	if t.kind != tokName || slices.Contains(keywords, t.value) {
+		return nil, p.unexpected("name")
+	}
+	p.pos++
+	return &Ident{At: t.pos, Name: t.value}, nil
+}
+
+// endStatement consumes the end of a simple statement.
+func (p *parser) endStatement() error {
+	if p.tok().kind == tokEOF {
+		return nil
+	}
+	if p.tok().kind != tokNewline {
+		return p.unexpected("end of line")
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) statement() (Stmt, error) {
+	t := p.tok()
+	if t.kind == tokIndent {
+		return nil, &SyntaxError{Pos: t.pos, Msg: "unexpected indent"}
+	}
+	switch {
+	case p.accept("def"):
+		return p.funcDef(t.pos)
+	case p.accept("return"):
+		s := &Return{At: t.pos}
+		if p.tok().kind != tokNewline && p.tok().kind != tokEOF {
+			v, err := p.exprList()
+			if err != nil {
+				return nil, err
+			}
+			s.Value = v
+		}
+		return s, p.endStatement()
+	case p.accept("import"):
+		return p.importModule(t.pos)
+	case p.accept("from"):
+		return p.importFrom(t.pos)
+	}
+
+	x, err := p.exprList()
+	if err != nil {
+		return nil, err
+	}
+	if !p.is("=") {
+		return &ExprStmt{X: x}, p.endStatement()
+	}
+	s := &Assign{}
+	for p.accept("=") {
+		targets, err := assignTargets(x)
+		if err != nil {
+			return nil, err
+		}
+		s.Targets = append(s.Targets, targets...)
+		if x, err = p.exprList(); err != nil {
+			return nil, err
+		}
+	}
+	s.Value = x
+	return s, p.endStatement()
+}
+
+// assignTargets returns the variables an expression assigns to.
+func assignTargets(x Expr) ([]*Ident, error) {
+	switch x := x.(type) {
+	case *Ident:
+		return []*Ident{x}, nil
+	case *Tuple:
+		var targets []*Ident
+		for _, e := range x.Elems {
+			t, err := assignTargets(e)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, t...)
+		}
+		return targets, nil
+	}
+	return nil, &SyntaxError{Pos: x.Pos(), Msg: "cannot assign to expression"}
+}
+
+func (p *parser) funcDef(at Pos) (Stmt, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("("); err != nil {
+		return nil, err
+	}
+	params, err := p.params(")")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	def := &FuncDef{At: at, Name: name, Params: params}
+	if p.tok().kind != tokNewline {
+		// A body on the line of the definition.
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		def.Body = []Stmt{s}
+		return def, nil
+	}
+	p.pos++
+	if p.tok().kind != tokIndent {
+		return nil, p.unexpected("indented block")
+	}
+	p.pos++
+	for p.tok().kind != tokDedent && p.tok().kind != tokEOF {
+		if p.tok().kind == tokNewline {
+			p.pos++
+			continue
+		}
+		s, err := p.statement()
+		if err != nil {
+			return nil, err
+		}
+		def.Body = append(def.Body, s)
+	}
+	if p.tok().kind == tokDedent {
+		p.pos++
+	}
+	return def, nil
+}
+
+// params parses parameters, up to the end token.
+func (p *parser) params(end string) ([]*Param, error) {
+	var params []*Param
+	for !p.is(end) {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		param := &Param{Name: name}
+		if p.accept("=") {
+			if param.Default, err = p.expr(); err != nil {
+				return nil, err
+			}
+		}
+		params = append(params, param)
+		if !p.accept(",") {
+			break
+		}
+	}
+	return params, nil
+}
+
+// dotted parses a dotted module name.
+func (p *parser) dotted() (*Ident, error) {
+	first, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	name := first.Name
+	for p.accept(".") {
+		next, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		name += "." + next.Name
+		first = &Ident{At: next.At, Name: name}
+	}
+	return first, nil
+}
+
+func (p *parser) importModule(at Pos) (Stmt, error) {
+	module, err := p.dotted()
+	if err != nil {
+		return nil, err
+	}
+	s := &Import{At: at, Module: module.Name}
+	bound := &Ident{At: module.At, Name: module.Name}
+	if i := strings.IndexByte(module.Name, '.'); i >= 0 {
+		bound.Name = module.Name[:i]
+	}
+	if p.accept("as") {
+		if bound, err = p.ident(); err != nil {
+			return nil, err
+		}
+	}
+	s.Names = []*Ident{bound}
+	return s, p.endStatement()
+}
+
+func (p *parser) importFrom(at Pos) (Stmt, error) {
+	module, err := p.dotted()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("import"); err != nil {
+		return nil, err
+	}
+	s := &Import{At: at, Module: module.Name}
+	parens := p.accept("(")
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if p.accept("as") {
+			if name, err = p.ident(); err != nil {
+				return nil, err
+			}
+		}
+		s.Names = append(s.Names, name)
+		if !p.accept(",") || (parens && p.is(")")) {
+			break
+		}
+	}
+	if parens {
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	return s, p.endStatement()
+}
+
+// exprList parses an expression, or a tuple without parentheses.
+func (p *parser) exprList() (Expr, error) {
+	first, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.is(",") {
+		return first, nil
+	}
+	t := &Tuple{At: first.Pos(), Elems: []Expr{first}}
+	for p.accept(",") {
+		if p.is("=", ")") || p.tok().kind == tokNewline || p.tok().kind == tokEOF {
+			break
+		}
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		t.Elems = append(t.Elems, e)
+	}
+	return t, nil
+}
+
+// expr parses an expression, including lambdas and conditionals.
+func (p *parser) expr() (Expr, error) {
+	if t := p.tok(); p.accept("lambda") {
+		params, err := p.params(":")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		body, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		return &Lambda{At: t.pos, Params: params, Body: body}, nil
+	}
+	x, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("if") {
+		return x, nil
+	}
+	cond, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect("else"); err != nil {
+		return nil, err
+	}
+	y, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	return &Conditional{Then: x, Cond: cond, Else: y}, nil
+}
+
+func (p *parser) or() (Expr, error) {
+	return p.binary(p.and, "or")
+}
+
+func (p *parser) and() (Expr, error) {
+	return p.binary(p.not, "and")
+}
+
+func (p *parser) not() (Expr, error) {
+	if t := p.tok(); p.accept("not") {
+		x, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{At: t.pos, Op: "not", X: x}, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (Expr, error) {
+	x, err := p.arith()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch {
+		case p.is("<", ">", "==", "!=", "<=", ">=", "in"):
+			op = p.tok().value
+			p.pos++
+		case p.accept("not"):
+			if _, err := p.expect("in"); err != nil {
+				return nil, err
+			}
+			op = "not in"
+		case p.accept("is"):
+			op = "is"
+			if p.accept("not") {
+				op = "is not"
+			}
+		default:
+			return x, nil
+		}
+		y, err := p.arith()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) arith() (Expr, error) {
+	return p.binary(p.term, "+", "-")
+}
+
+func (p *parser) term() (Expr, error) {
+	return p.binary(p.factor, "*", "/", "//", "%")
+}
+
+// binary parses left associative binary operations.
+func (p *parser) binary(operand func() (Expr, error), ops ...string) (Expr, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.is(ops...) {
+		op := p.tok().value
+		p.pos++
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) factor() (Expr, error) {
+	if t := p.tok(); p.accept("-", "+") {
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{At: t.pos, Op: t.value, X: x}, nil
+	}
+	x, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("**") {
+		y, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &Binary{Op: "**", X: x, Y: y}, nil
+	}
+	return x, nil
+}
+
+// postfix parses an atom followed by calls, indexes and attributes, such
+// as the method chains of streams.
+func (p *parser) postfix() (Expr, error) {
+	x, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.accept("("):
+			args, err := p.args()
+			if err != nil {
+				return nil, err
+			}
+			x = &Call{Func: x, Args: args}
+		case p.accept("["):
+			index, err := p.exprList()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = &Index{X: x, Index: index}
+		case p.accept("."):
+			name, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			x = &Attribute{X: x, Name: name}
+		default:
+			return x, nil
+		}
+	}
+}
+
+// args parses the arguments of a call, up to the closing parenthesis.
+func (p *parser) args() ([]*Arg, error) {
+	var args []*Arg
+	named := false
+	for !p.accept(")") {
+		arg := &Arg{}
+		if p.tok().kind == tokName && p.tokens[p.pos+1].kind == tokOp && p.tokens[p.pos+1].value == "=" {
+			name, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			p.pos++
+			arg.Name = name
+			named = true
+		} else if named {
+			return nil, &SyntaxError{Pos: p.tok().pos, Msg: "positional argument follows keyword argument"}
+		}
+		v, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		arg.Value = v
+		args = append(args, arg)
+		if !p.accept(",") {
+			if _, err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return args, nil
+}
+
+func (p *parser) atom() (Expr, error) {
+	t := p.tok()
+	switch t.kind {
+	case tokNumber:
+		p.pos++
+		return &Number{At: t.pos, Value: t.value}, nil
+	case tokString:
+		s := &String{At: t.pos}
+		// Adjacent strings are concatenated.
+		for p.tok().kind == tokString {
+			s.Value += p.tok().value
+			p.pos++
+		}
+		return s, nil
+	case tokName:
+		return p.ident()
+	}
+	switch {
+	case p.accept("("):
+		if p.accept(")") {
+			return &Tuple{At: t.pos}, nil
+		}
+		x, err := p.exprList()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		if tuple, ok := x.(*Tuple); ok {
+			tuple.At = t.pos
+		}
+		return x, nil
+	case p.accept("["):
+		l := &List{At: t.pos}
+		for !p.accept("]") {
+			e, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			l.Elems = append(l.Elems, e)
+			if !p.accept(",") {
+				if _, err := p.expect("]"); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		return l, nil
+	case p.accept("{"):
+		d := &Dict{At: t.pos}
+		for !p.accept("}") {
+			k, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			v, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			d.Keys = append(d.Keys, k)
+			d.Values = append(d.Values, v)
+			if !p.accept(",") {
+				if _, err := p.expect("}"); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		return d, nil
+	}
+	return nil, p.unexpected("expression")
+}
@@ -0,0 +1,202 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	prog, err := Parse(`# CPU of the hosts
+A = data('cpu.utilization', filter=filter('env', 'prod') and not filter('host', 'db*')).mean(by=['host'])
+detect(when(A > 90, lasting='5m'), off=when(A < 80)).publish(label='CPU high')
+`)
+	require.NoError(t, err, "Must parse the program")
+	require.Len(t, prog.Statements, 2, "Must parse the statements")
+
+	assign, ok := prog.Statements[0].(*Assign)
+	require.True(t, ok, "Must parse the assignment")
+	assert.Equal(t, []*Ident{{At: Pos{Line: 2, Column: 1}, Name: "A"}}, assign.Targets, "Must parse the target")
+	mean, ok := assign.Value.(*Call)
+	require.True(t, ok, "Must parse the method chain")
+	assert.Equal(t, "mean", mean.Name(), "Must parse the method")
+	assert.Equal(t, &List{At: Pos{Line: 2, Column: 97}, Elems: []Expr{&String{At: Pos{Line: 2, Column: 98}, Value: "host"}}}, mean.Arg("by", -1), "Must parse the keyword argument")
+	data, ok := mean.Receiver().(*Call)
+	require.True(t, ok, "Must parse the receiver")
+	assert.Equal(t, "data", data.Name(), "Must parse the function")
+	assert.Nil(t, data.Receiver(), "Must tell functions from methods")
+	assert.Equal(t, &String{At: Pos{Line: 2, Column: 10}, Value: "cpu.utilization"}, data.Arg("metric", 0), "Must parse the positional argument")
+	and, ok := data.Arg("filter", 1).(*Binary)
+	require.True(t, ok, "Must parse the filter")
+	assert.Equal(t, "and", and.Op, "Must parse the boolean operation")
+	assert.Equal(t, "not", and.Y.(*Unary).Op, "Must parse the negation")
+
+	publish := prog.Statements[1].(*ExprStmt).X.(*Call)
+	assert.Equal(t, "publish", publish.Name(), "Must parse the publication")
+	assert.Equal(t, &String{At: Pos{Line: 3, Column: 68}, Value: "CPU high"}, publish.Arg("label", 0), "Must parse the label")
+	detect := publish.Receiver().(*Call)
+	on := detect.Arg("on", 0).(*Call)
+	assert.Equal(t, "when", on.Name(), "Must parse the condition")
+	assert.Equal(t, ">", on.Args[0].Value.(*Binary).Op, "Must parse the comparison")
+	assert.Equal(t, "5m", on.Arg("lasting", -1).(*String).Value, "Must parse the duration")
+	assert.Equal(t, Pos{Line: 3, Column: 1}, publish.Pos(), "Must report the position of the chain")
+}
+
+func TestParseStatements(t *testing.T) {
+	t.Parallel()
+
+	prog, err := Parse(`from signalfx.detectors.against_recent import against_recent as ar
+import signalfx.detectors.countdown
+
+def high(stream, threshold=90):
+    s = stream.mean()
+    return when(s > threshold)
+
+def low(stream): return when(stream < 10)
+
+A, B = data('cpu'), \
+    data('memory')
+C = A if B else (A, B)
+D = {'a': -A ** 2, "b": """x
+y"""}
+E = A.map(lambda x: x * 2)[0]
+`)
+	require.NoError(t, err, "Must parse the program")
+	require.Len(t, prog.Statements, 8, "Must parse the statements")
+
+	imp := prog.Statements[0].(*Import)
+	assert.Equal(t, "signalfx.detectors.against_recent", imp.Module, "Must parse the module")
+	assert.Equal(t, "ar", imp.Names[0].Name, "Must bind the alias")
+	assert.Equal(t, "signalfx", prog.Statements[1].(*Import).Names[0].Name, "Must bind the package")
+
+	def := prog.Statements[2].(*FuncDef)
+	assert.Equal(t, "high", def.Name.Name, "Must parse the name of the function")
+	require.Len(t, def.Params, 2, "Must parse the parameters")
+	assert.Equal(t, &Number{At: Pos{Line: 4, Column: 28}, Value: "90"}, def.Params[1].Default, "Must parse the default")
+	require.Len(t, def.Body, 2, "Must parse the indented body")
+	assert.IsType(t, &Return{}, def.Body[1], "Must parse the return")
+	assert.Len(t, prog.Statements[3].(*FuncDef).Body, 1, "Must parse the inline body")
+
+	assign := prog.Statements[4].(*Assign)
+	assert.Len(t, assign.Targets, 2, "Must parse the tuple targets")
+	assert.Len(t, assign.Value.(*Tuple).Elems, 2, "Must parse the continued tuple")
+	assert.IsType(t, &Conditional{}, prog.Statements[5].(*Assign).Value, "Must parse the conditional")
+	dict := prog.Statements[6].(*Assign).Value.(*Dict)
+	assert.Equal(t, "x\ny", dict.Values[1].(*String).Value, "Must parse the triple quoted string")
+	assert.Equal(t, "-", dict.Values[0].(*Unary).Op, "Must bind the power tighter than the negation")
+	index := prog.Statements[7].(*Assign).Value.(*Index)
+	assert.IsType(t, &Lambda{}, index.X.(*Call).Args[0].Value, "Must parse the lambda")
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "unclosed call", src: "A = data('cpu'\n", want: "2:1: expected \")\", found end of line"},
+		{name: "unmatched bracket", src: "A = data('cpu'))", want: "1:16: unmatched \")\""},
+		{name: "unterminated string", src: "data('cpu)", want: "1:6: unterminated string"},
+		{name: "unexpected indent", src: "A = 1\n  B = 2", want: "2:3: unexpected indent"},
+		{name: "inconsistent dedent", src: "def f():\n    return 1\n  A = 2", want: "3:3: unindent does not match any outer indentation level"},
+		{name: "positional after keyword", src: "data(metric='cpu', 5)", want: "1:20: positional argument follows keyword argument"},
+		{name: "assignment to a call", src: "data('cpu') = 1", want: "1:1: cannot assign to expression"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tc.src)
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr, "Must report a syntax error")
+			assert.Equal(t, tc.want, err.Error(), "Must report the position of the error")
+		})
+	}
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		src  string
+		want []Issue
+	}{
+		{
+			name: "used before definition",
+			src: `from signalfx.detectors.against_periods import against_periods
+A = data('cpu').mean(by=['host'])
+def above(s, t=90):
+    return when(s > t)
+detect(above(A), off=when(A < B)).publish('high')
+B = const(80)
+A.publish(label='cpu')
+`,
+			want: []Issue{
+				{Pos: Pos{Line: 5, Column: 31}, Rule: RuleUndefined, Message: `undefined variable "B"`},
+				{Pos: Pos{Line: 6, Column: 1}, Rule: RuleUnusedStream, Message: `stream "B" is never used nor published`},
+			},
+		},
+		{
+			name: "undefined",
+			src:  "A = data('cpu')\nB = mean(A, C)\nf = lambda x: x + y\nB.publish()",
+			want: []Issue{
+				{Pos: Pos{Line: 2, Column: 13}, Rule: RuleUndefined, Message: `undefined variable "C"`},
+				{Pos: Pos{Line: 3, Column: 19}, Rule: RuleUndefined, Message: `undefined variable "y"`},
+			},
+		},
+		{
+			name: "functions see the whole module",
+			src:  "def f():\n    return A\nA = data('cpu')\nf().publish()",
+		},
+		{
+			name: "duplicate label",
+			src:  "data('cpu').publish('A')\ndata('memory').publish(label='A')\ndata('disk').publish('B')",
+			want: []Issue{{Pos: Pos{Line: 2, Column: 30}, Rule: RuleDuplicateLabel, Message: `publish label "A" is already used at line 1`}},
+		},
+		{
+			name: "unused stream",
+			src:  "A = data('cpu')\nB = A.mean()\nC = data('memory').publish()\nD = 5\nA = data('disk')\nA.publish()",
+			want: []Issue{{Pos: Pos{Line: 2, Column: 1}, Rule: RuleUnusedStream, Message: `stream "B" is never used nor published`}},
+		},
+		{
+			name: "no deprecated functions by default",
+			src:  "graphite('cpu.*').publish('A')\nnewrelic('cpu').publish('B')",
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			prog, err := Parse(tc.src)
+			require.NoError(t, err, "Must parse the program")
+			assert.Equal(t, tc.want, Lint(prog), "Must report the issues")
+		})
+	}
+}
+
+func TestLinter(t *testing.T) {
+	t.Parallel()
+
+	prog, err := Parse("graphite('cpu.*').publish()\ndef graphite(x):\n    return data(x)\ngraphite('cpu').publish()\nlookup('cpu').publish('a')")
+	require.NoError(t, err, "Must parse the program")
+	lt := NewLinter(Deprecate("graphite", "ask the platform team first"), Builtin("lookup"))
+	assert.Equal(t, []Issue{
+		{Pos: Pos{Line: 1, Column: 1}, Rule: RuleDeprecated, Message: "graphite() is deprecated: ask the platform team first"},
+	}, lt.Lint(prog), "Must report the calls of the functions callers deprecate, unless redefined, and accept their builtins")
+	assert.Equal(t, []Issue{
+		{Pos: Pos{Line: 5, Column: 1}, Rule: RuleUndefined, Message: `undefined variable "lookup"`},
+	}, Lint(prog), "Must not share the options of linters")
+}
+
+func TestIssueString(t *testing.T) {
+	t.Parallel()
+
+	issue := Issue{Pos: Pos{Line: 3, Column: 7}, Rule: RuleUndefined, Message: `undefined variable "x"`}
+	assert.Equal(t, `3:7: undefined variable "x" (undefined-variable)`, issue.String(), "Must format the issue")
+}
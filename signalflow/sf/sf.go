@@ -0,0 +1,310 @@
+// Package sf builds SignalFlow programs, such as the programs of detectors
+// and charts, writing them in a canonical form:
+//
+//	p := sf.NewProgram()
+//	cpu := p.Assign("A", sf.Data("cpu.utilization", sf.Filter("env", "prod")).Mean(sf.By("host")))
+//	p.Add(cpu.Publish("A"))
+//	p.Add(sf.Detect(sf.When(cpu.Gt(90), sf.Lasting(5*time.Minute))).Publish("CPU high"))
+//
+//	req := &detector.CreateUpdateDetectorRequest{ProgramText: p.String()}
+//
+// Strings are single quoted, durations are written in the largest unit
+// dividing them, such as '5m', and the imports of the library functions
+// used are added to the program.
+//
+// The arguments of functions and methods are streams, conditions, filters,
+// nil, durations, or strings, booleans, numbers and slices of them, of any
+// type. Other values panic.
+package sf
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The precedences of expressions, from the loosest to the tightest.
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precCompare
+	precSum
+	precProduct
+	precUnary
+	precAtom
+)
+
+// expr is the text of an expression, with the imports it requires.
+type expr struct {
+	text    string
+	prec    int
+	imports []string
+}
+
+// at returns the text of the expression as an operand of the given
+// precedence, in parentheses when it binds looser.
+func (e expr) at(prec int) string {
+	if e.prec < prec {
+		return "(" + e.text + ")"
+	}
+	return e.text
+}
+
+func merge(exprs ...expr) []string {
+	var imports []string
+	for _, e := range exprs {
+		for _, imp := range e.imports {
+			if !slices.Contains(imports, imp) {
+				imports = append(imports, imp)
+			}
+		}
+	}
+	return imports
+}
+
+func binary(x expr, op string, y expr, prec int) expr {
+	left := x.at(prec)
+	if prec == precCompare {
+		// Python chains comparisons: a < b < c is not (a < b) < c.
+		left = x.at(prec + 1)
+	}
+	return expr{text: left + " " + op + " " + y.at(prec+1), prec: prec, imports: merge(x, y)}
+}
+
+// Arg is a keyword argument of a function or method, such as By("host").
+type Arg interface {
+	keyword() (string, expr)
+}
+
+type keyword struct {
+	name  string
+	value expr
+}
+
+func (k keyword) keyword() (string, expr) {
+	return k.name, k.value
+}
+
+// Keyword returns a keyword argument, for the arguments without a
+// function of their own.
+func Keyword(name string, v any) Arg {
+	return keyword{name: name, value: value(v)}
+}
+
+// By groups aggregations by dimensions or properties.
+func By(keys ...string) Arg {
+	return Keyword("by", keys)
+}
+
+// Over makes an aggregation a transformation over a moving window.
+func Over(window time.Duration) Arg {
+	return Keyword("over", window)
+}
+
+// Lasting requires conditions to hold for a duration.
+func Lasting(d time.Duration) Arg {
+	return Keyword("lasting", d)
+}
+
+// AtLeast requires conditions to hold for a fraction of their Lasting
+// duration.
+func AtLeast(fraction float64) Arg {
+	return Keyword("at_least", fraction)
+}
+
+// Off sets the condition clearing a detect.
+func Off(c Condition) Arg {
+	return Keyword("off", c)
+}
+
+// Mode sets the mode of a detect, such as "split".
+func Mode(mode string) Arg {
+	return Keyword("mode", mode)
+}
+
+// AutoResolveAfter clears the alerts of a detect after its input stops
+// reporting for a duration.
+func AutoResolveAfter(d time.Duration) Arg {
+	return Keyword("auto_resolve_after", d)
+}
+
+// Rollup sets the rollup of data, such as "rate".
+func Rollup(rollup string) Arg {
+	return Keyword("rollup", rollup)
+}
+
+// Extrapolation sets the extrapolation policy of data, such as
+// "last_value".
+func Extrapolation(policy string) Arg {
+	return Keyword("extrapolation", policy)
+}
+
+// MaxExtrapolations limits the number of values extrapolated in a row.
+func MaxExtrapolations(n int) Arg {
+	return Keyword("maxExtrapolations", n)
+}
+
+// Enable sets whether a published stream is displayed.
+func Enable(enable bool) Arg {
+	return Keyword("enable", enable)
+}
+
+// call returns a call of a function, or of a method of the receiver. Args
+// are either keyword arguments or positional ones, which are written first.
+// Keyword arguments given twice take the last value.
+func call(receiver *expr, name string, args []any) expr {
+	var positional, names []string
+	var exprs []expr
+	keywords := map[string]expr{}
+	for _, a := range args {
+		if k, ok := a.(Arg); ok {
+			n, v := k.keyword()
+			if _, ok := keywords[n]; !ok {
+				names = append(names, n)
+			}
+			keywords[n] = v
+			exprs = append(exprs, v)
+			continue
+		}
+		v := value(a)
+		positional = append(positional, v.text)
+		exprs = append(exprs, v)
+	}
+	for _, n := range names {
+		positional = append(positional, n+"="+keywords[n].text)
+	}
+	text := name + "(" + strings.Join(positional, ", ") + ")"
+	if receiver != nil {
+		text = receiver.at(precAtom) + "." + text
+		exprs = append(exprs, *receiver)
+	}
+	return expr{text: text, prec: precAtom, imports: merge(exprs...)}
+}
+
+// value returns the expression of a Go value: a Stream, Condition or
+// *FilterExpr, nil, a duration, or a string, boolean, number or slice of
+// values, of any type.
+func value(v any) expr {
+	switch v := v.(type) {
+	case Stream:
+		return v.e
+	case Condition:
+		return v.e
+	case *FilterExpr:
+		return v.e
+	case nil:
+		return expr{text: "None", prec: precAtom}
+	case time.Duration:
+		return expr{text: quote(Duration(v)), prec: precAtom}
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return expr{text: quote(rv.String()), prec: precAtom}
+	case reflect.Bool:
+		if rv.Bool() {
+			return expr{text: "True", prec: precAtom}
+		}
+		return expr{text: "False", prec: precAtom}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32:
+		return number(strconv.FormatFloat(rv.Float(), 'g', -1, 32))
+	case reflect.Float64:
+		return number(strconv.FormatFloat(rv.Float(), 'g', -1, 64))
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		exprs := make([]expr, rv.Len())
+		for i := range items {
+			exprs[i] = value(rv.Index(i).Interface())
+			items[i] = exprs[i].text
+		}
+		return expr{text: "[" + strings.Join(items, ", ") + "]", prec: precAtom, imports: merge(exprs...)}
+	}
+	panic(fmt.Sprintf("sf: unsupported value of type %T", v))
+}
+
+func number(text string) expr {
+	if strings.HasPrefix(text, "-") {
+		return expr{text: text, prec: precUnary}
+	}
+	return expr{text: text, prec: precAtom}
+}
+
+// quote returns a single quoted string literal.
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return "'" + r.Replace(s) + "'"
+}
+
+// durationUnits are the units of durations, largest first.
+var durationUnits = []struct {
+	unit string
+	d    time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+}
+
+// Duration returns the SignalFlow text of a duration, such as "5m", in the
+// largest unit dividing it. Durations are rounded to milliseconds.
+func Duration(d time.Duration) string {
+	d = d.Round(time.Millisecond)
+	for _, u := range durationUnits {
+		if d%u.d == 0 && d != 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + u.unit
+		}
+	}
+	return "0s"
+}
+
+// Program is a program under construction.
+type Program struct {
+	imports    []string
+	statements []string
+}
+
+// NewProgram returns an empty program.
+func NewProgram() *Program {
+	return &Program{}
+}
+
+// Assign adds a statement assigning a stream to a variable, and returns the
+// variable.
+func (p *Program) Assign(name string, s Stream) Stream {
+	p.add(s.e, name+" = "+s.e.text)
+	return Stream{e: expr{text: name, prec: precAtom}}
+}
+
+// Add adds a stream as a statement, usually a published one.
+func (p *Program) Add(s Stream) {
+	p.add(s.e, s.e.text)
+}
+
+func (p *Program) add(e expr, statement string) {
+	p.imports = merge(expr{imports: p.imports}, e)
+	p.statements = append(p.statements, statement)
+}
+
+// String returns the text of the program: its imports, sorted, then its
+// statements.
+func (p *Program) String() string {
+	var b strings.Builder
+	for _, imp := range slices.Sorted(slices.Values(p.imports)) {
+		b.WriteString(imp + "\n")
+	}
+	for _, s := range p.statements {
+		b.WriteString(s + "\n")
+	}
+	return b.String()
+}
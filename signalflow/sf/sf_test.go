@@ -0,0 +1,115 @@
+package sf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/signalflow/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgram(t *testing.T) {
+	t.Parallel()
+
+	p := NewProgram()
+	cpu := p.Assign("A", Data("cpu.utilization", Filter("env", "prod").And(Filter("host", "db*", "web*").Not())).Mean(By("host")))
+	p.Add(cpu.Publish("A"))
+	p.Add(Detect(When(cpu.Gt(90), Lasting(5*time.Minute), AtLeast(0.8)), Off(When(cpu.Lt(80)))).Publish("CPU high"))
+	p.Add(SuddenChange(cpu, Keyword("fire_num_stddev", 4)).Publish("CPU sudden change"))
+
+	want := `from signalfx.detectors.against_recent import against_recent
+A = data('cpu.utilization', filter=filter('env', 'prod') and not filter('host', 'db*', 'web*')).mean(by=['host'])
+A.publish('A')
+detect(when(A > 90, lasting='5m', at_least=0.8), off=when(A < 80)).publish('CPU high')
+against_recent.detector_mean_std(stream=A, current_window='5m', historical_window='1h', fire_num_stddev=4, clear_num_stddev=2.5, orientation='above').publish('CPU sudden change')
+`
+	require.Equal(t, want, p.String(), "Must write the program")
+
+	prog, err := parse.Parse(p.String())
+	require.NoError(t, err, "Must write a valid program")
+	assert.Empty(t, parse.Lint(prog), "Must write a clean program")
+	assert.Empty(t, detector.Lint(&detector.CreateUpdateDetectorRequest{
+		ProgramText: p.String(),
+		Rules: []*detector.Rule{
+			{DetectLabel: "CPU high", Severity: detector.CRITICAL},
+			{DetectLabel: "CPU sudden change", Severity: detector.WARNING},
+		},
+	}), "Must plug into detectors")
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	a := Data("a")
+	b := Data("b", Rollup("rate"), Extrapolation("zero"), MaxExtrapolations(2))
+	for _, tc := range []struct {
+		name   string
+		stream Stream
+		want   string
+	}{
+		{name: "data", stream: b, want: "data('b', rollup='rate', extrapolation='zero', maxExtrapolations=2)"},
+		{name: "transformation", stream: a.Mean(Over(time.Hour)).Percentile(95, By("host", "region")), want: "data('a').mean(over='1h').percentile(95, by=['host', 'region'])"},
+		{name: "arithmetic", stream: a.Add(b).Mul(100).Div(a.Sub(1)), want: "(data('a') + data('b', rollup='rate', extrapolation='zero', maxExtrapolations=2)) * 100 / (data('a') - 1)"},
+		{name: "method of an operation", stream: a.Div(b).Scale(-1).Sum(), want: "(data('a') / data('b', rollup='rate', extrapolation='zero', maxExtrapolations=2)).scale(-1).sum()"},
+		{name: "comparisons", stream: Detect(When(a.Gt(Threshold(5))).Or(When(a.Le(-1.5)).And(When(b.Ge(0)).Not()))), want: "detect(when(data('a') > threshold(5)) or when(data('a') <= -1.5) and not when(data('b', rollup='rate', extrapolation='zero', maxExtrapolations=2) >= 0))"},
+		{name: "filters", stream: Data("a", Filter("x", "1").Or(Filter("y", "2")).And(Filter("z", "3"))), want: "data('a', filter=(filter('x', '1') or filter('y', '2')) and filter('z', '3'))"},
+		{name: "keywords given twice", stream: a.Top(Keyword("count", 5), Keyword("count", 3)), want: "data('a').top(count=3)"},
+		{name: "escapes", stream: Data("it's\\n"), want: `data('it\'s\\n')`},
+		{name: "unlabeled publish", stream: Const(1).Timeshift(36*time.Hour).Publish("", Enable(false)), want: "const(1).timeshift('36h').publish(enable=False)"},
+		{name: "any method", stream: a.Method("map", Func("lambda_helper", 1, nil, true)), want: "data('a').map(lambda_helper(1, None, True))"},
+		{name: "numbers of any type", stream: a.Method("top", uint(5)).Above(int8(-2)).Below(float32(0.5)).Method("scale", uint64(3)), want: "data('a').top(5).above(-2).below(0.5).scale(3)"},
+		{name: "values of named types", stream: a.Method("f", Keyword("labels", []any{"x", 1}), Keyword("rate", rollup("rate"))), want: "data('a').f(labels=['x', 1], rate='rate')"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.stream.String(), "Must write the stream")
+			_, err := parse.Parse(tc.stream.String())
+			assert.NoError(t, err, "Must write a valid stream")
+		})
+	}
+}
+
+type rollup string
+
+func TestUnsupportedValue(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "sf: unsupported value of type map[string]int", func() {
+		Data("a").Method("f", map[string]int{"x": 1})
+	}, "Must reject the values SignalFlow cannot express")
+}
+
+func TestDuration(t *testing.T) {
+	t.Parallel()
+
+	for d, want := range map[time.Duration]string{
+		0:                       "0s",
+		1500 * time.Millisecond: "1500ms",
+		90 * time.Second:        "90s",
+		2 * time.Hour:           "2h",
+		14 * 24 * time.Hour:     "2w",
+		25 * time.Hour:          "25h",
+	} {
+		assert.Equal(t, want, Duration(d), "Must write %s in its largest unit", d)
+	}
+}
+
+func TestAgainstPeriods(t *testing.T) {
+	t.Parallel()
+
+	p := NewProgram()
+	p.Add(AgainstPeriods(Data("a"), Keyword("num_windows", 4)).Publish("periods"))
+	p.Add(AgainstRecent(Data("b")).Publish("recent"))
+	p.Add(AgainstPeriods(Data("c")).Publish("periods again"))
+
+	assert.Equal(t, `from signalfx.detectors.against_periods import against_periods
+from signalfx.detectors.against_recent import against_recent
+against_periods.detector_mean_std(stream=data('a'), num_windows=4).publish('periods')
+against_recent.detector_mean_std(stream=data('b')).publish('recent')
+against_periods.detector_mean_std(stream=data('c')).publish('periods again')
+`, p.String(), "Must import each library once")
+}
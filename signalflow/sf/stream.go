@@ -0,0 +1,290 @@
+package sf
+
+import "time"
+
+// Stream is a stream of time series, such as the output of data() or of a
+// detect.
+type Stream struct {
+	e expr
+}
+
+// String returns the text of the stream, without the imports it requires.
+func (s Stream) String() string {
+	return s.e.text
+}
+
+// Data returns the stream of a metric, matching a wildcard pattern.
+func Data(metric string, args ...Arg) Stream {
+	return Func("data", prepend(metric, args)...)
+}
+
+// Const returns a stream of a constant value.
+func Const(v float64, args ...Arg) Stream {
+	return Func("const", prepend(v, args)...)
+}
+
+// Threshold returns a stream of a constant value, for comparing streams
+// with.
+func Threshold(v float64) Stream {
+	return Func("threshold", v)
+}
+
+// Func returns the stream of a call of a function, for the functions
+// without a function of their own. Args are Arg keyword arguments, or
+// positional ones.
+func Func(name string, args ...any) Stream {
+	return Stream{e: call(nil, name, args)}
+}
+
+// Method returns the stream of a call of a method of the stream, for the
+// methods without a method of their own. Args are Arg keyword arguments, or
+// positional ones.
+func (s Stream) Method(name string, args ...any) Stream {
+	return Stream{e: call(&s.e, name, args)}
+}
+
+func prepend(v any, args []Arg) []any {
+	all := make([]any, 0, len(args)+1)
+	all = append(all, v)
+	for _, a := range args {
+		all = append(all, a)
+	}
+	return all
+}
+
+func keywords(args []Arg) []any {
+	return prepend(nil, args)[1:]
+}
+
+// Publish publishes the stream, under a label unless it is empty.
+func (s Stream) Publish(label string, args ...Arg) Stream {
+	if label == "" {
+		return s.Method("publish", keywords(args)...)
+	}
+	return s.Method("publish", prepend(label, args)...)
+}
+
+// Mean averages the time series of the stream, By dimensions, or each over
+// a window with Over.
+func (s Stream) Mean(args ...Arg) Stream { return s.Method("mean", keywords(args)...) }
+
+// Sum adds the time series of the stream, By dimensions, or each over a
+// window with Over.
+func (s Stream) Sum(args ...Arg) Stream { return s.Method("sum", keywords(args)...) }
+
+// Min returns the minimum of the time series of the stream, By dimensions,
+// or of each over a window with Over.
+func (s Stream) Min(args ...Arg) Stream { return s.Method("min", keywords(args)...) }
+
+// Max returns the maximum of the time series of the stream, By dimensions,
+// or of each over a window with Over.
+func (s Stream) Max(args ...Arg) Stream { return s.Method("max", keywords(args)...) }
+
+// Count counts the time series of the stream, By dimensions, or the values
+// of each over a window with Over.
+func (s Stream) Count(args ...Arg) Stream { return s.Method("count", keywords(args)...) }
+
+// Median returns the median of the time series of the stream, By
+// dimensions, or of each over a window with Over.
+func (s Stream) Median(args ...Arg) Stream { return s.Method("median", keywords(args)...) }
+
+// Stddev returns the standard deviation of the time series of the stream,
+// By dimensions, or of each over a window with Over.
+func (s Stream) Stddev(args ...Arg) Stream { return s.Method("stddev", keywords(args)...) }
+
+// Variance returns the variance of the time series of the stream, By
+// dimensions, or of each over a window with Over.
+func (s Stream) Variance(args ...Arg) Stream { return s.Method("variance", keywords(args)...) }
+
+// Percentile returns a percentile of the time series of the stream, By
+// dimensions, or of each over a window with Over.
+func (s Stream) Percentile(pct float64, args ...Arg) Stream {
+	return s.Method("percentile", prepend(pct, args)...)
+}
+
+// Top keeps the time series with the largest values, such as
+// Top(Keyword("count", 5)).
+func (s Stream) Top(args ...Arg) Stream { return s.Method("top", keywords(args)...) }
+
+// Bottom keeps the time series with the smallest values, such as
+// Bottom(Keyword("count", 5)).
+func (s Stream) Bottom(args ...Arg) Stream { return s.Method("bottom", keywords(args)...) }
+
+// Abs returns the absolute values of the stream.
+func (s Stream) Abs() Stream { return s.Method("abs") }
+
+// Ceil rounds the values of the stream up.
+func (s Stream) Ceil() Stream { return s.Method("ceil") }
+
+// Floor rounds the values of the stream down.
+func (s Stream) Floor() Stream { return s.Method("floor") }
+
+// Sqrt returns the square roots of the values of the stream.
+func (s Stream) Sqrt() Stream { return s.Method("sqrt") }
+
+// Scale multiplies the values of the stream.
+func (s Stream) Scale(factor float64) Stream { return s.Method("scale", factor) }
+
+// Delta returns the differences between the consecutive values of the
+// stream.
+func (s Stream) Delta() Stream { return s.Method("delta") }
+
+// RateOfChange returns the rates of change of the values of the stream.
+func (s Stream) RateOfChange() Stream { return s.Method("rateofchange") }
+
+// Integrate integrates the values of the stream over time.
+func (s Stream) Integrate() Stream { return s.Method("integrate") }
+
+// Timeshift shifts the stream back in time.
+func (s Stream) Timeshift(offset time.Duration) Stream { return s.Method("timeshift", offset) }
+
+// Above keeps the values above a limit, such as a number or a stream.
+func (s Stream) Above(limit any, args ...Arg) Stream {
+	return s.Method("above", prepend(limit, args)...)
+}
+
+// Below keeps the values below a limit, such as a number or a stream.
+func (s Stream) Below(limit any, args ...Arg) Stream {
+	return s.Method("below", prepend(limit, args)...)
+}
+
+// Fill fills the missing values of the stream, such as with
+// Fill(Keyword("value", 0)).
+func (s Stream) Fill(args ...Arg) Stream { return s.Method("fill", keywords(args)...) }
+
+func (s Stream) binary(op string, x any, prec int) Stream {
+	return Stream{e: binary(s.e, op, value(x), prec)}
+}
+
+// Add adds a number or a stream to the stream.
+func (s Stream) Add(x any) Stream { return s.binary("+", x, precSum) }
+
+// Sub subtracts a number or a stream from the stream.
+func (s Stream) Sub(x any) Stream { return s.binary("-", x, precSum) }
+
+// Mul multiplies the stream by a number or a stream.
+func (s Stream) Mul(x any) Stream { return s.binary("*", x, precProduct) }
+
+// Div divides the stream by a number or a stream.
+func (s Stream) Div(x any) Stream { return s.binary("/", x, precProduct) }
+
+// Gt compares the stream with a number or a stream, for conditions.
+func (s Stream) Gt(x any) Stream { return s.binary(">", x, precCompare) }
+
+// Ge compares the stream with a number or a stream, for conditions.
+func (s Stream) Ge(x any) Stream { return s.binary(">=", x, precCompare) }
+
+// Lt compares the stream with a number or a stream, for conditions.
+func (s Stream) Lt(x any) Stream { return s.binary("<", x, precCompare) }
+
+// Le compares the stream with a number or a stream, for conditions.
+func (s Stream) Le(x any) Stream { return s.binary("<=", x, precCompare) }
+
+// FilterExpr is a filter of the time series of data, combining the values
+// of dimensions and properties.
+type FilterExpr struct {
+	e expr
+}
+
+// Filter matches the time series whose key has one of the values, which
+// may contain wildcards.
+func Filter(key string, values ...string) *FilterExpr {
+	args := []any{key}
+	for _, v := range values {
+		args = append(args, v)
+	}
+	return &FilterExpr{e: call(nil, "filter", args)}
+}
+
+func (f *FilterExpr) keyword() (string, expr) {
+	return "filter", f.e
+}
+
+// And matches the time series matched by both filters.
+func (f *FilterExpr) And(g *FilterExpr) *FilterExpr {
+	return &FilterExpr{e: binary(f.e, "and", g.e, precAnd)}
+}
+
+// Or matches the time series matched by either filter.
+func (f *FilterExpr) Or(g *FilterExpr) *FilterExpr {
+	return &FilterExpr{e: binary(f.e, "or", g.e, precOr)}
+}
+
+// Not matches the time series the filter does not match.
+func (f *FilterExpr) Not() *FilterExpr {
+	return &FilterExpr{e: expr{text: "not " + f.e.at(precNot), prec: precNot}}
+}
+
+// Condition is a condition of a detect.
+type Condition struct {
+	e expr
+}
+
+// When returns the condition of a predicate, such as a comparison, holding,
+// possibly Lasting a duration.
+func When(predicate Stream, args ...Arg) Condition {
+	return Condition{e: call(nil, "when", prepend(predicate, args))}
+}
+
+// And holds when both conditions do.
+func (c Condition) And(d Condition) Condition {
+	return Condition{e: binary(c.e, "and", d.e, precAnd)}
+}
+
+// Or holds when either condition does.
+func (c Condition) Or(d Condition) Condition {
+	return Condition{e: binary(c.e, "or", d.e, precOr)}
+}
+
+// Not holds when the condition does not.
+func (c Condition) Not() Condition {
+	return Condition{e: expr{text: "not " + c.e.at(precNot), prec: precNot, imports: c.e.imports}}
+}
+
+// Detect returns the stream of the events of a condition: an alert is
+// raised when it starts holding, and cleared when it stops or the Off
+// condition holds.
+func Detect(on Condition, args ...Arg) Stream {
+	return Func("detect", prepend(on, args)...)
+}
+
+// The imports of the library functions.
+const (
+	importAgainstRecent  = "from signalfx.detectors.against_recent import against_recent"
+	importAgainstPeriods = "from signalfx.detectors.against_periods import against_periods"
+)
+
+func library(module, imp, function string, stream Stream, args []Arg) Stream {
+	e := call(&expr{text: module, prec: precAtom, imports: []string{imp}}, function, prepend(Keyword("stream", stream), args))
+	return Stream{e: e}
+}
+
+// AgainstRecent detects when the stream deviates from its recent values,
+// by a number of standard deviations, with the detector_mean_std function
+// of the against_recent library. Args set its parameters, such as
+// Keyword("current_window", 5*time.Minute).
+func AgainstRecent(stream Stream, args ...Arg) Stream {
+	return library("against_recent", importAgainstRecent, "detector_mean_std", stream, args)
+}
+
+// AgainstPeriods detects when the stream deviates from its values at the
+// same time in previous periods, by a number of standard deviations, with
+// the detector_mean_std function of the against_periods library. Args set
+// its parameters, such as Keyword("space_between_windows", 7*24*time.Hour).
+func AgainstPeriods(stream Stream, args ...Arg) Stream {
+	return library("against_periods", importAgainstPeriods, "detector_mean_std", stream, args)
+}
+
+// SuddenChange detects when the stream rises sharply above its values of
+// the last hour, as the Sudden Change alert condition does. It is
+// AgainstRecent with the defaults of the condition, which args override.
+func SuddenChange(stream Stream, args ...Arg) Stream {
+	defaults := []Arg{
+		Keyword("current_window", 5*time.Minute),
+		Keyword("historical_window", time.Hour),
+		Keyword("fire_num_stddev", 3),
+		Keyword("clear_num_stddev", 2.5),
+		Keyword("orientation", "above"),
+	}
+	return AgainstRecent(stream, append(defaults, args...)...)
+}
@@ -0,0 +1,419 @@
+// Package signalflow executes SignalFlow programs, such as the programs of
+// detectors and charts, through the streaming API of a realm, and streams
+// their output:
+//
+//	client, _ := signalflow.NewClient("token", signalflow.Realm("us1"))
+//	defer client.Close()
+//
+//	comp, err := client.Execute(ctx, &signalflow.ExecuteRequest{Program: program})
+//	if err != nil {
+//		return err
+//	}
+//	for batch := range comp.Data() {
+//		for _, p := range batch.Points {
+//			fmt.Println(comp.TSIDMetadata(p.TSID).Dimensions(), p.Value)
+//		}
+//	}
+//
+// Only the data must be drained. The metadata and events of a computation
+// are dropped once their buffers are full, unless they are read as well.
+//
+// A client multiplexes its computations over a single WebSocket connection,
+// opened by the first request, and reconnects when the connection is lost.
+package signalflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/signalfx/signalfx-go/realm"
+)
+
+// DefaultStreamURL is the default URL of the stream endpoint.
+const DefaultStreamURL = "wss://stream.signalfx.com/v2/signalflow/connect"
+
+// connectPath is the path of the stream endpoint.
+const connectPath = "/v2/signalflow/connect"
+
+// ErrClosed is returned for the requests made once the client is closed.
+var ErrClosed = errors.New("signalflow: client closed")
+
+// Client executes programs over a connection to the stream endpoint. It is
+// safe for concurrent use.
+type Client struct {
+	streamURL      string
+	token          string
+	userAgent      string
+	reconnectDelay time.Duration
+	dialer         *websocket.Dialer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+
+	// writeMu serializes the writes to the connection.
+	writeMu sync.Mutex
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	// connected is closed once connected, and replaced on disconnection.
+	connected    chan struct{}
+	err          error
+	computations map[string]*Computation
+	channels     int
+}
+
+// ClientParam is an option for NewClient.
+type ClientParam func(*Client) error
+
+// NewClient returns a client authenticating with token. It connects on its
+// first request.
+func NewClient(token string, options ...ClientParam) (*Client, error) {
+	c := &Client{
+		streamURL:      DefaultStreamURL,
+		token:          token,
+		userAgent:      "signalfx-go",
+		reconnectDelay: time.Second,
+		dialer:         &websocket.Dialer{HandshakeTimeout: 30 * time.Second, Proxy: http.ProxyFromEnvironment},
+		connected:      make(chan struct{}),
+		computations:   map[string]*Computation{},
+	}
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	return c, nil
+}
+
+// StreamURL sets the URL of the stream endpoint, or of the stream host of a
+// realm, such as `"https://stream.us1.signalfx.com"`.
+func StreamURL(streamURL string) ClientParam {
+	return func(c *Client) error {
+		u, err := url.Parse(streamURL)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "https":
+			u.Scheme = "wss"
+		case "http":
+			u.Scheme = "ws"
+		case "ws", "wss":
+		default:
+			return fmt.Errorf("unexpected scheme in stream URL %q", streamURL)
+		}
+		if strings.Trim(u.Path, "/") == "" {
+			u.Path = connectPath
+		}
+		c.streamURL = u.String()
+		return nil
+	}
+}
+
+// Realm sets the URL of the stream endpoint to the one of a known realm,
+// such as `"us1"`. It fails for unknown realms.
+func Realm(name string) ClientParam {
+	return func(c *Client) error {
+		endpoints, err := realm.Lookup(name)
+		if err != nil {
+			return err
+		}
+		return StreamURL(endpoints.Stream)(c)
+	}
+}
+
+// UserAgent sets the User-Agent of the connection.
+func UserAgent(userAgent string) ClientParam {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// ReconnectDelay sets the time waited before reconnecting, one second by
+// default.
+func ReconnectDelay(delay time.Duration) ClientParam {
+	return func(c *Client) error {
+		if delay < 0 {
+			return fmt.Errorf("negative reconnect delay %s", delay)
+		}
+		c.reconnectDelay = delay
+		return nil
+	}
+}
+
+// Execute executes a program and streams its output.
+func (c *Client) Execute(ctx context.Context, req *ExecuteRequest) (*Computation, error) {
+	return c.compute(ctx, &request{
+		Type:       "execute",
+		Program:    req.Program,
+		Start:      millis(req.Start),
+		Stop:       millis(req.Stop),
+		Resolution: req.Resolution.Milliseconds(),
+		MaxDelay:   req.MaxDelay.Milliseconds(),
+		Immediate:  req.Immediate,
+		Timezone:   req.Timezone,
+	})
+}
+
+// Preflight computes the events the detector of a program would have
+// generated over a period.
+func (c *Client) Preflight(ctx context.Context, req *PreflightRequest) (*Computation, error) {
+	return c.compute(ctx, &request{
+		Type:    "preflight",
+		Program: req.Program,
+		Start:   millis(req.Start),
+		Stop:    millis(req.Stop),
+	})
+}
+
+// Start starts a program in the background, without streaming its output.
+func (c *Client) Start(ctx context.Context, req *StartRequest) error {
+	return c.send(ctx, &request{
+		Type:       "start",
+		Program:    req.Program,
+		Start:      millis(req.Start),
+		Stop:       millis(req.Stop),
+		Resolution: req.Resolution.Milliseconds(),
+		MaxDelay:   req.MaxDelay.Milliseconds(),
+	})
+}
+
+// Stop stops a computation by its handle.
+func (c *Client) Stop(ctx context.Context, req *StopRequest) error {
+	return c.send(ctx, &request{Type: "stop", Handle: req.Handle, Reason: req.Reason})
+}
+
+// Close closes the connection and ends the computations.
+func (c *Client) Close() {
+	c.cancel()
+	c.mu.Lock()
+	conn := c.conn
+	computations := c.computations
+	c.computations = map[string]*Computation{}
+	c.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+	for _, comp := range computations {
+		comp.end()
+	}
+}
+
+// compute registers a computation for a request, which is sent now if the
+// client is connected, and on connection otherwise.
+func (c *Client) compute(ctx context.Context, req *request) (*Computation, error) {
+	if _, err := c.connection(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.channels++
+	req.Channel = fmt.Sprintf("ch-%d", c.channels)
+	comp := newComputation(c, req.Channel, req)
+	c.computations[req.Channel] = comp
+	conn := c.conn
+	c.mu.Unlock()
+
+	if conn != nil {
+		// A failed write means that the connection is lost, and the
+		// request is sent again on reconnection.
+		_ = c.write(conn, req)
+	}
+	return comp, nil
+}
+
+// forget unregisters a computation, and returns whether it was registered.
+func (c *Client) forget(comp *Computation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.computations[comp.channel] != comp {
+		return false
+	}
+	delete(c.computations, comp.channel)
+	return true
+}
+
+// send sends a request once connected.
+func (c *Client) send(ctx context.Context, req *request) error {
+	conn, err := c.connection(ctx)
+	if err != nil {
+		return err
+	}
+	return c.write(conn, req)
+}
+
+func (c *Client) write(conn *websocket.Conn, req *request) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	return conn.WriteJSON(req)
+}
+
+// connection returns the connection, connecting the client on its first
+// request and waiting for reconnection when the connection is lost.
+func (c *Client) connection(ctx context.Context) (*websocket.Conn, error) {
+	c.once.Do(func() { go c.run() })
+	for {
+		c.mu.Lock()
+		conn, connected, err := c.conn, c.connected, c.err
+		c.mu.Unlock()
+
+		switch {
+		case err != nil:
+			return nil, err
+		case c.ctx.Err() != nil:
+			return nil, ErrClosed
+		case conn != nil:
+			return conn, nil
+		}
+		select {
+		case <-connected:
+		case <-c.ctx.Done():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// run connects the client and reads the messages of the connection, until
+// the client is closed or its token rejected.
+func (c *Client) run() {
+	for {
+		conn, err := c.connect()
+		var streamErr *StreamError
+		switch {
+		case c.ctx.Err() != nil:
+			return
+		case errors.As(err, &streamErr):
+			// The token is rejected, so reconnecting is of no use.
+			c.fail(err)
+			return
+		case err == nil:
+			c.read(conn)
+		}
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(c.reconnectDelay):
+		}
+	}
+}
+
+// connect opens and authenticates a connection, and sends the requests of
+// the computations again.
+func (c *Client) connect() (*websocket.Conn, error) {
+	header := http.Header{}
+	header.Set("User-Agent", c.userAgent)
+	conn, _, err := c.dialer.DialContext(c.ctx, c.streamURL, header)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.WriteJSON(&request{Type: "authenticate", Token: c.token}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	m, err := readMessage(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	switch m.Type {
+	case "authenticated":
+	case "error":
+		conn.Close()
+		return nil, m.streamError()
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("unexpected %q message on authentication", m.Type)
+	}
+
+	c.mu.Lock()
+	if c.ctx.Err() != nil {
+		c.mu.Unlock()
+		conn.Close()
+		return nil, ErrClosed
+	}
+	c.conn = conn
+	close(c.connected)
+	requests := make([]*request, 0, len(c.computations))
+	for _, comp := range c.computations {
+		requests = append(requests, comp.request)
+	}
+	c.mu.Unlock()
+
+	for _, req := range requests {
+		if err := c.write(conn, req); err != nil {
+			break
+		}
+	}
+	return conn, nil
+}
+
+// read dispatches the messages of a connection to their computations, until
+// the connection is lost.
+func (c *Client) read(conn *websocket.Conn) {
+	defer func() {
+		conn.Close()
+		c.mu.Lock()
+		c.conn = nil
+		c.connected = make(chan struct{})
+		c.mu.Unlock()
+	}()
+	for {
+		m, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		c.mu.Lock()
+		comp := c.computations[m.Channel]
+		c.mu.Unlock()
+		if comp != nil {
+			comp.deliver(m)
+		}
+	}
+}
+
+// fail ends the computations with an error that prevents connecting.
+func (c *Client) fail(err error) {
+	c.mu.Lock()
+	c.err = err
+	close(c.connected)
+	computations := c.computations
+	c.computations = map[string]*Computation{}
+	c.mu.Unlock()
+
+	for _, comp := range computations {
+		comp.deliver(&message{Type: "error", err: err})
+	}
+}
+
+// readMessage reads the next message of a connection. Messages that cannot
+// be decoded are skipped.
+func readMessage(conn *websocket.Conn) (*message, error) {
+	for {
+		typ, b, err := conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		var m *message
+		if typ == websocket.BinaryMessage {
+			m, err = decodeBinary(b)
+		} else {
+			m, err = decodeJSON(b)
+		}
+		if err == nil {
+			return m, nil
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package signalflow
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go/idtool"
+	"github.com/signalfx/signalfx-go/signalflow/signalflowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const program = "data('cpu').publish()"
+
+func newClient(t *testing.T, fake *signalflowtest.Server, token string) *Client {
+	client, err := NewClient(token, StreamURL(fake.URL), ReconnectDelay(10*time.Millisecond))
+	require.NoError(t, err, "Must create the client")
+	t.Cleanup(client.Close)
+	return client
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer()
+	defer fake.Close()
+	now := time.UnixMilli(1700000000000)
+	tsid := idtool.ID(42)
+	fake.Program(program,
+		signalflowtest.Metadata(tsid, map[string]any{"sf_key": []string{"host"}, "host": "a", "sf_metric": "cpu"}),
+		signalflowtest.Data(now, map[idtool.ID]any{tsid: 0.5, 43: int64(7)}),
+		signalflowtest.Event(tsid, now, map[string]any{"is": "anomalous"}),
+		signalflowtest.End(),
+	)
+	client := newClient(t, fake, "token")
+	ctx := testContext(t)
+
+	comp, err := client.Execute(ctx, &ExecuteRequest{Program: program, MaxDelay: 2 * time.Second})
+	require.NoError(t, err, "Must execute the program")
+
+	md := <-comp.Metadata()
+	require.NotNil(t, md, "Must stream the metadata")
+	assert.Equal(t, tsid, md.TSID, "Must decode the TSID")
+	assert.Equal(t, map[string]string{"host": "a"}, md.Dimensions(), "Must list the dimensions")
+
+	batch := <-comp.Data()
+	require.NotNil(t, batch, "Must stream the data")
+	assert.Equal(t, now, batch.Timestamp, "Must decode the timestamp")
+	assert.Equal(t, 2*time.Second, batch.MaxDelay, "Must decode the max delay")
+	assert.Equal(t, []DataPoint{{TSID: 42, Value: 0.5}, {TSID: 43, Value: int64(7)}}, batch.Points, "Must decode the points")
+	assert.Equal(t, md, comp.TSIDMetadata(tsid), "Must keep the metadata by TSID")
+
+	event := <-comp.Events()
+	require.NotNil(t, event, "Must stream the event")
+	assert.Equal(t, map[string]any{"is": "anomalous"}, event.Properties, "Must decode the event")
+
+	handle, err := comp.Handle(ctx)
+	require.NoError(t, err, "Must report the handle")
+	assert.NotEmpty(t, handle, "Must report the handle")
+	resolution, err := comp.Resolution(ctx)
+	require.NoError(t, err, "Must report the resolution")
+	assert.Equal(t, signalflowtest.DefaultResolution, resolution, "Must report the resolution")
+
+	<-comp.Done()
+	_, ok := <-comp.Data()
+	assert.False(t, ok, "Must close the channels at the end of the computation")
+	assert.Equal(t, "execute", fake.Requests()[0].Type, "Must send the request")
+	assert.Equal(t, int64(2000), fake.Requests()[0].MaxDelay, "Must send the max delay in milliseconds")
+}
+
+func TestExecuteDataOnly(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer()
+	defer fake.Close()
+	now := time.UnixMilli(1700000000000)
+	var messages []signalflowtest.Message
+	points := map[idtool.ID]any{}
+	for i := range 2 * bufferSize {
+		tsid := idtool.ID(i + 1)
+		messages = append(messages,
+			signalflowtest.Metadata(tsid, map[string]any{"sf_key": []string{"host"}, "host": "a"}),
+			signalflowtest.Event(tsid, now, map[string]any{"is": "anomalous"}),
+		)
+		points[tsid] = 0.5
+	}
+	messages = append(messages, signalflowtest.Data(now, points), signalflowtest.End())
+	fake.Program(program, messages...)
+	client := newClient(t, fake, "token")
+
+	comp, err := client.Execute(testContext(t), &ExecuteRequest{Program: program})
+	require.NoError(t, err, "Must execute the program")
+	var batches []*DataBatch
+	for batch := range comp.Data() {
+		batches = append(batches, batch)
+	}
+	require.Len(t, batches, 1, "Must stream the data without draining the metadata and events")
+	assert.Len(t, batches[0].Points, 2*bufferSize, "Must stream every point")
+	assert.NotNil(t, comp.TSIDMetadata(2*bufferSize), "Must keep the metadata dropped from the channel")
+	assert.Len(t, comp.Metadata(), bufferSize, "Must drop the metadata once the buffer is full")
+}
+
+func TestExecuteError(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer()
+	defer fake.Close()
+	client := newClient(t, fake, "token")
+
+	comp, err := client.Execute(testContext(t), &ExecuteRequest{Program: "unknown"})
+	require.NoError(t, err, "Must execute the program")
+	err = <-comp.Errors()
+	var streamErr *StreamError
+	require.ErrorAs(t, err, &streamErr, "Must report the error of the stream")
+	assert.Equal(t, 400, streamErr.Code, "Must decode the error")
+	assert.Equal(t, "ANALYTICS_PROGRAM_NAME_ERROR", streamErr.Type, "Must decode the error")
+	<-comp.Done()
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer(signalflowtest.Token("right"))
+	defer fake.Close()
+	client := newClient(t, fake, "wrong")
+
+	_, err := client.Execute(testContext(t), &ExecuteRequest{Program: program})
+	var streamErr *StreamError
+	require.ErrorAs(t, err, &streamErr, "Must fail to authenticate")
+	assert.Equal(t, 401, streamErr.Code, "Must report the rejected token")
+	assert.ErrorAs(t, client.Start(testContext(t), &StartRequest{Program: program}), &streamErr, "Must not reconnect")
+}
+
+func TestStopAndDetach(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer()
+	defer fake.Close()
+	fake.Program(program)
+	client := newClient(t, fake, "token")
+	ctx := testContext(t)
+
+	stopped, err := client.Execute(ctx, &ExecuteRequest{Program: program})
+	require.NoError(t, err, "Must execute the program")
+	require.NoError(t, stopped.Stop(ctx, "done"), "Must stop the computation")
+	<-stopped.Done()
+
+	detached, err := client.Execute(ctx, &ExecuteRequest{Program: program})
+	require.NoError(t, err, "Must execute the program")
+	require.NoError(t, detached.Detach(ctx, "done"), "Must detach the computation")
+	<-detached.Done()
+
+	require.NoError(t, client.Start(ctx, &StartRequest{Program: program}), "Must start the program")
+	require.Eventually(t, func() bool { return len(fake.Requests()) == 5 }, time.Second, time.Millisecond, "Must send the requests")
+	requests := fake.Requests()
+	handle, err := stopped.Handle(ctx)
+	require.NoError(t, err, "Must know the handle of the stopped computation")
+	assert.Equal(t, signalflowtest.Request{Type: "stop", Handle: handle, Reason: "done"}, requests[1], "Must stop by handle")
+	assert.Equal(t, signalflowtest.Request{Type: "detach", Channel: requests[2].Channel, Reason: "done"}, requests[3], "Must detach the channel")
+	assert.Equal(t, signalflowtest.Request{Type: "start", Program: program}, requests[4], "Must start the program")
+}
+
+func TestReconnect(t *testing.T) {
+	t.Parallel()
+
+	fake := signalflowtest.NewServer()
+	defer fake.Close()
+	fake.Program(program, signalflowtest.Metadata(1, map[string]any{"host": "a"}))
+	client := newClient(t, fake, "token")
+	ctx := testContext(t)
+
+	comp, err := client.Execute(ctx, &ExecuteRequest{Program: program})
+	require.NoError(t, err, "Must execute the program")
+	<-comp.Metadata()
+
+	fake.Disconnect()
+	md, ok := <-comp.Metadata()
+	require.True(t, ok, "Must go on after reconnecting")
+	assert.Equal(t, idtool.ID(1), md.TSID, "Must execute the program again")
+	requests := fake.Requests()
+	require.Len(t, requests, 2, "Must send the request again")
+	assert.Equal(t, requests[0], requests[1], "Must send the same request")
+
+	preflight, err := client.Preflight(ctx, &PreflightRequest{Program: program})
+	require.NoError(t, err, "Must preflight the program")
+	_, ok = <-preflight.Metadata()
+	assert.True(t, ok, "Must stream the preflight")
+	assert.NotEqual(t, comp.channel, preflight.channel, "Must use another channel")
+}
+
+func TestDecodeBinary(t *testing.T) {
+	t.Parallel()
+
+	header := func(version, flags byte) []byte {
+		h := make([]byte, headerSize)
+		h[0], h[1], h[2] = version, dataType, flags
+		copy(h[4:], "ch-1")
+		return h
+	}
+	v1 := binary.BigEndian.AppendUint64(header(1, 0), 1000)
+	v1 = binary.BigEndian.AppendUint32(v1, 1)
+	v1 = append(v1, doubleValue)
+	v1 = binary.BigEndian.AppendUint64(v1, 7)
+	v1 = binary.BigEndian.AppendUint64(v1, math.Float64bits(1.5))
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(`{"type":"metadata","channel":"ch-1","tsId":"AAAAAAAAAAc"}`))
+	require.NoError(t, zw.Close(), "Must compress the message")
+
+	for _, tc := range []struct {
+		name    string
+		message []byte
+		want    *message
+	}{
+		{
+			name:    "data",
+			message: v1,
+			want: &message{Type: "data", Channel: "ch-1", data: &DataBatch{
+				Timestamp: time.UnixMilli(1000),
+				Points:    []DataPoint{{TSID: 7, Value: 1.5}},
+			}},
+		},
+		{
+			name:    "compressed JSON",
+			message: append(header(1, compressedFlag|jsonFlag), compressed.Bytes()...),
+			want:    &message{Type: "metadata", Channel: "ch-1", TSID: "AAAAAAAAAAc"},
+		},
+		{name: "truncated", message: v1[:len(v1)-1]},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := decodeBinary(tc.message)
+			if tc.want == nil {
+				assert.Error(t, err, "Must reject the message")
+				return
+			}
+			require.NoError(t, err, "Must decode the message")
+			assert.Equal(t, tc.want, m, "Must decode the message")
+		})
+	}
+}
@@ -0,0 +1,334 @@
+// Package signalflowtest provides a fake of the SignalFlow stream endpoint
+// for testing code built on top of the signalflow client.
+//
+// The fake streams the messages registered for a program to the
+// computations executing it:
+//
+//	fake := signalflowtest.NewServer()
+//	defer fake.Close()
+//	fake.Program("data('cpu').publish()",
+//		signalflowtest.Metadata(tsid, map[string]any{"host": "a"}),
+//		signalflowtest.Data(now, map[idtool.ID]any{tsid: 0.5}),
+//		signalflowtest.End(),
+//	)
+//
+//	client, _ := signalflow.NewClient("token", signalflow.StreamURL(fake.URL))
+package signalflowtest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/signalfx/signalfx-go/idtool"
+)
+
+// DefaultResolution is the resolution reported for the computations that
+// do not request one.
+const DefaultResolution = time.Second
+
+// Server is a fake stream endpoint backed by an `httptest.Server`. It is
+// safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	token    string
+	programs map[string][]Message
+	requests []Request
+	conns    map[*conn]bool
+	handles  map[string]channel
+	jobs     int
+}
+
+// Request records a message received by the fake, other than the
+// authentication.
+type Request struct {
+	Type       string `json:"type"`
+	Channel    string `json:"channel,omitempty"`
+	Program    string `json:"program,omitempty"`
+	Start      int64  `json:"start,omitempty"`
+	Stop       int64  `json:"stop,omitempty"`
+	Resolution int64  `json:"resolution,omitempty"`
+	MaxDelay   int64  `json:"maxDelay,omitempty"`
+	Immediate  bool   `json:"immediate,omitempty"`
+	Timezone   string `json:"timezone,omitempty"`
+	Handle     string `json:"handle,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Token      string `json:"token,omitempty"`
+}
+
+// Message is a message streamed to the computations of a program.
+type Message struct {
+	fields map[string]any
+	// data is the payload of a binary data message.
+	data []byte
+	end  bool
+}
+
+// channel is a channel of a connection.
+type channel struct {
+	conn *conn
+	name string
+}
+
+// conn is a connection, whose writes are serialized.
+type conn struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// Token makes the fake reject connections that do not authenticate with the
+// given token. By default any non empty token is accepted.
+func Token(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// NewServer starts a fake stream endpoint. Callers should call Close when
+// done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		programs: map[string][]Message{},
+		conns:    map[*conn]bool{},
+		handles:  map[string]channel{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close disconnects the clients and shuts the fake down.
+func (s *Server) Close() {
+	s.Disconnect()
+	s.Server.Close()
+}
+
+// Program sets the messages streamed to the computations of a program,
+// after the messages starting them. Computations are not ended unless the
+// messages do, with End or Error.
+func (s *Server) Program(program string, messages ...Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.programs[program] = messages
+}
+
+// Requests returns the messages received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// Disconnect closes the connections of the clients, which lose their
+// computations.
+func (s *Server) Disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.conns {
+		c.ws.Close()
+	}
+	s.conns = map[*conn]bool{}
+	s.handles = map[string]channel{}
+}
+
+// Metadata is the metadata of a time series.
+func Metadata(tsid idtool.ID, properties map[string]any) Message {
+	return Message{fields: map[string]any{"type": "metadata", "tsId": tsid.String(), "properties": properties}}
+}
+
+// Data is the data of a computation at a point in time, ordered by TSID.
+// Values must be int64 or float64.
+func Data(timestamp time.Time, points map[idtool.ID]any) Message {
+	// Version 2 data, without the max delay, which is filled in once the
+	// computation is known.
+	b := binary.BigEndian.AppendUint64(nil, uint64(timestamp.UnixMilli()))
+	b = binary.BigEndian.AppendUint64(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(points)))
+	for _, tsid := range slices.Sorted(maps.Keys(points)) {
+		switch v := points[tsid].(type) {
+		case int64:
+			b = append(b, 1)
+			b = binary.BigEndian.AppendUint64(b, uint64(tsid))
+			b = binary.BigEndian.AppendUint64(b, uint64(v))
+		case float64:
+			b = append(b, 2)
+			b = binary.BigEndian.AppendUint64(b, uint64(tsid))
+			b = binary.BigEndian.AppendUint64(b, math.Float64bits(v))
+		default:
+			panic(fmt.Sprintf("unexpected value %T", v))
+		}
+	}
+	return Message{data: b}
+}
+
+// Event is an event of a time series.
+func Event(tsid idtool.ID, timestamp time.Time, properties map[string]any) Message {
+	return Message{fields: map[string]any{
+		"type":        "event",
+		"tsId":        tsid.String(),
+		"timestampMs": timestamp.UnixMilli(),
+		"properties":  properties,
+	}}
+}
+
+// Error fails the computation.
+func Error(code int, errorType, message string) Message {
+	return Message{fields: map[string]any{"type": "error", "error": code, "errorType": errorType, "message": message}, end: true}
+}
+
+// End ends the computation.
+func End() Message {
+	return Message{fields: map[string]any{"type": "control-message", "event": "END_OF_CHANNEL"}, end: true}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v2/signalflow/connect" {
+		http.NotFound(w, r)
+		return
+	}
+	ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &conn{ws: ws}
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	var auth Request
+	if err := ws.ReadJSON(&auth); err != nil {
+		return
+	}
+	if auth.Type != "authenticate" || auth.Token == "" || (s.token != "" && auth.Token != s.token) {
+		c.write(map[string]any{"type": "error", "error": 401, "message": "invalid token"})
+		return
+	}
+	s.mu.Lock()
+	s.conns[c] = true
+	s.mu.Unlock()
+	c.write(map[string]any{"type": "authenticated", "orgId": "ORG", "userId": "USER"})
+
+	for {
+		var req Request
+		if err := ws.ReadJSON(&req); err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		switch req.Type {
+		case "execute", "preflight":
+			s.execute(c, req)
+		case "stop":
+			s.mu.Lock()
+			ch, ok := s.handles[req.Handle]
+			delete(s.handles, req.Handle)
+			s.mu.Unlock()
+			if ok {
+				ch.conn.write(map[string]any{"type": "control-message", "channel": ch.name, "event": "CHANNEL_ABORT"})
+			}
+		case "detach":
+			s.mu.Lock()
+			for handle, ch := range s.handles {
+				if ch.conn == c && ch.name == req.Channel {
+					delete(s.handles, handle)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// execute streams the messages of a program to a channel.
+func (s *Server) execute(c *conn, req Request) {
+	s.mu.Lock()
+	messages, ok := s.programs[req.Program]
+	s.jobs++
+	handle := fmt.Sprintf("JOB%d", s.jobs)
+	if ok {
+		s.handles[handle] = channel{conn: c, name: req.Channel}
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		c.write(map[string]any{
+			"type":      "error",
+			"channel":   req.Channel,
+			"error":     400,
+			"errorType": "ANALYTICS_PROGRAM_NAME_ERROR",
+			"message":   fmt.Sprintf("unknown program %q", req.Program),
+		})
+		return
+	}
+	resolution := req.Resolution
+	if resolution == 0 {
+		resolution = DefaultResolution.Milliseconds()
+	}
+	c.write(map[string]any{"type": "control-message", "channel": req.Channel, "event": "STREAM_START"})
+	c.write(map[string]any{"type": "control-message", "channel": req.Channel, "event": "JOB_START", "handle": handle})
+	c.write(map[string]any{
+		"type":    "message",
+		"channel": req.Channel,
+		"message": map[string]any{"messageCode": "JOB_RUNNING_RESOLUTION", "contents": map[string]any{"resolutionMs": resolution}},
+	})
+	for _, m := range messages {
+		if m.data != nil {
+			c.writeData(req.Channel, req.MaxDelay, m.data)
+			continue
+		}
+		fields := map[string]any{"channel": req.Channel}
+		for k, v := range m.fields {
+			fields[k] = v
+		}
+		c.write(fields)
+		if m.end {
+			s.mu.Lock()
+			delete(s.handles, handle)
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (c *conn) write(v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.ws.WriteJSON(v)
+}
+
+// writeData writes a binary data message, with the max delay of the
+// computation.
+func (c *conn) writeData(name string, maxDelay int64, payload []byte) {
+	header := make([]byte, 20)
+	header[0], header[1] = 2, 5
+	copy(header[4:], name)
+	data := append(header, payload...)
+	binary.BigEndian.PutUint64(data[28:], uint64(maxDelay))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.ws.WriteMessage(websocket.BinaryMessage, data)
+}
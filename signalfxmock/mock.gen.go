@@ -0,0 +1,1727 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package signalfxmock
+
+import (
+	"context"
+	"iter"
+
+	signalfx "github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/apm"
+	automated_archival "github.com/signalfx/signalfx-go/automated-archival"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/emailtemplate"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/signalfx/signalfx-go/navigator"
+	"github.com/signalfx/signalfx-go/organization"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/signalfx/signalfx-go/slo"
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// Client is a mock of signalfx.Client. Each method calls the function of
+// the field named after it, and fails with ErrNotMocked when it is nil.
+type Client struct {
+	AllAlertMutingRulesFunc            func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*alertmuting.AlertMutingRule, error]
+	AllChartsFunc                      func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*chart.Chart, error]
+	AllDashboardGroupsFunc             func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*dashboard_group.DashboardGroup, error]
+	AllDashboardsFunc                  func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*dashboard.Dashboard, error]
+	AllDataLinksFunc                   func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*datalink.DataLink, error]
+	AllDetectorsFunc                   func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*detector.Detector, error]
+	AllDimensionsFunc                  func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Dimension, error]
+	AllEmailTemplatesFunc              func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*emailtemplate.EmailTemplate, error]
+	AllIntegrationsFunc                func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[map[string]interface{}, error]
+	AllMetricRulesetsFunc              func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metric_ruleset.MetricRuleset, error]
+	AllMetricTimeSeriesFunc            func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.MetricTimeSeries, error]
+	AllMetricsFunc                     func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Metric, error]
+	AllNavigatorsFunc                  func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*navigator.GetNavigatorsResult, error]
+	AllOrgTokensFunc                   func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*orgtoken.Token, error]
+	AllOrganizationMembersFunc         func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*organization.Member, error]
+	AllSlosFunc                        func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*slo.SloObject, error]
+	AllTagsFunc                        func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Tag, error]
+	AllTeamsFunc                       func(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*team.Team, error]
+	CreateAWSCloudWatchIntegrationFunc func(ctx context.Context, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error)
+	CreateAlertMutingRuleFunc          func(ctx context.Context, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error)
+	CreateAzureIntegrationFunc         func(ctx context.Context, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error)
+	CreateBigPandaIntegrationFunc      func(ctx context.Context, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error)
+	CreateChartFunc                    func(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error)
+	CreateDashboardFunc                func(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error)
+	CreateDashboardGroupFunc           func(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, skipImplicitDashboard bool) (*dashboard_group.DashboardGroup, error)
+	CreateDataLinkFunc                 func(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error)
+	CreateDetectorFunc                 func(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error)
+	CreateEmailTemplateFunc            func(ctx context.Context, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error)
+	CreateExemptMetricsFunc            func(ctx context.Context, exemptMetrics *[]automated_archival.ExemptMetric) (*[]automated_archival.ExemptMetric, error)
+	CreateGCPIntegrationFunc           func(ctx context.Context, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error)
+	CreateJiraIntegrationFunc          func(ctx context.Context, ji *integration.JiraIntegration) (*integration.JiraIntegration, error)
+	CreateMetricRulesetFunc            func(ctx context.Context, metricRuleset *metric_ruleset.CreateMetricRulesetRequest) (*metric_ruleset.CreateMetricRulesetResponse, error)
+	CreateNavigatorFunc                func(ctx context.Context, navigatorRequest *navigator.CreateNavigatorRequest) (*navigator.Navigator, error)
+	CreateOpsgenieIntegrationFunc      func(ctx context.Context, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error)
+	CreateOrgTokenFunc                 func(ctx context.Context, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error)
+	CreatePagerDutyIntegrationFunc     func(ctx context.Context, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error)
+	CreateServiceNowIntegrationFunc    func(ctx context.Context, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error)
+	CreateSessionTokenFunc             func(ctx context.Context, tokenRequest *sessiontoken.CreateTokenRequest) (*sessiontoken.Token, error)
+	CreateSettingsFunc                 func(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error)
+	CreateSlackIntegrationFunc         func(ctx context.Context, si *integration.SlackIntegration) (*integration.SlackIntegration, error)
+	CreateSloFunc                      func(ctx context.Context, sloRequest *slo.SloObject) (*slo.SloObject, error)
+	CreateSloChartFunc                 func(ctx context.Context, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error)
+	CreateTeamFunc                     func(ctx context.Context, t *team.CreateUpdateTeamRequest) (*team.Team, error)
+	CreateUpdateMetricFunc             func(ctx context.Context, name string, cumr *metrics_metadata.CreateUpdateMetricRequest) (*metrics_metadata.Metric, error)
+	CreateUpdateTagFunc                func(ctx context.Context, name string, cutr *metrics_metadata.CreateUpdateTagRequest) (*metrics_metadata.Tag, error)
+	CreateVictorOpsIntegrationFunc     func(ctx context.Context, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error)
+	CreateWebhookIntegrationFunc       func(ctx context.Context, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error)
+	DeleteAWSCloudWatchIntegrationFunc func(ctx context.Context, id string) error
+	DeleteAlertMutingRuleFunc          func(ctx context.Context, name string) error
+	DeleteAzureIntegrationFunc         func(ctx context.Context, id string) error
+	DeleteBigPandaIntegrationFunc      func(ctx context.Context, id string) error
+	DeleteChartFunc                    func(ctx context.Context, id string) error
+	DeleteDashboardFunc                func(ctx context.Context, id string) error
+	DeleteDashboardGroupFunc           func(ctx context.Context, id string) error
+	DeleteDataLinkFunc                 func(ctx context.Context, id string) error
+	DeleteDetectorFunc                 func(ctx context.Context, id string) error
+	DeleteEmailTemplateFunc            func(ctx context.Context, id string) error
+	DeleteExemptMetricsFunc            func(ctx context.Context, deleteExemptMetricsRequest *automated_archival.ExemptMetricDeleteRequest) error
+	DeleteGCPIntegrationFunc           func(ctx context.Context, id string) error
+	DeleteIntegrationFunc              func(ctx context.Context, id string) error
+	DeleteJiraIntegrationFunc          func(ctx context.Context, id string) error
+	DeleteMemberFunc                   func(ctx context.Context, id string) error
+	DeleteMetricRulesetFunc            func(ctx context.Context, id string) error
+	DeleteNavigatorFunc                func(ctx context.Context, id string) error
+	DeleteOpsgenieIntegrationFunc      func(ctx context.Context, id string) error
+	DeleteOrgTokenFunc                 func(ctx context.Context, name string) error
+	DeletePagerDutyIntegrationFunc     func(ctx context.Context, id string) error
+	DeleteServiceNowIntegrationFunc    func(ctx context.Context, id string) error
+	DeleteSessionTokenFunc             func(ctx context.Context, token string) error
+	DeleteSettingsFunc                 func(ctx context.Context, deleteSettingsRequest *automated_archival.AutomatedArchivalSettingsDeleteRequest) error
+	DeleteSlackIntegrationFunc         func(ctx context.Context, id string) error
+	DeleteSloFunc                      func(ctx context.Context, id string) error
+	DeleteTagFunc                      func(ctx context.Context, id string) error
+	DeleteTeamFunc                     func(ctx context.Context, id string) error
+	DeleteVictorOpsIntegrationFunc     func(ctx context.Context, id string) error
+	DeleteWebhookIntegrationFunc       func(ctx context.Context, id string) error
+	DisableDetectorFunc                func(ctx context.Context, id string, labels []string) error
+	EnableDetectorFunc                 func(ctx context.Context, id string, labels []string) error
+	EnsureChartFunc                    func(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest, key signalfx.EnsureKey) (*chart.Chart, signalfx.EnsureOutcome, error)
+	EnsureDashboardFunc                func(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, key signalfx.EnsureKey) (*dashboard.Dashboard, signalfx.EnsureOutcome, error)
+	EnsureDashboardGroupFunc           func(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, signalfx.EnsureOutcome, error)
+	EnsureDataLinkFunc                 func(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, signalfx.EnsureOutcome, error)
+	EnsureDetectorFunc                 func(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest, key signalfx.EnsureKey) (*detector.Detector, signalfx.EnsureOutcome, error)
+	EnsureTeamFunc                     func(ctx context.Context, teamRequest *team.CreateUpdateTeamRequest) (*team.Team, signalfx.EnsureOutcome, error)
+	GenerateAggregationMetricNameFunc  func(ctx context.Context, generateAggregationNameRequest metric_ruleset.GenerateAggregationNameRequest) (string, error)
+	GetAWSCloudWatchIntegrationFunc    func(ctx context.Context, id string) (*integration.AwsCloudWatchIntegration, error)
+	GetAlertMutingRuleFunc             func(ctx context.Context, id string) (*alertmuting.AlertMutingRule, error)
+	GetAzureIntegrationFunc            func(ctx context.Context, id string) (*integration.AzureIntegration, error)
+	GetBigPandaIntegrationFunc         func(ctx context.Context, id string) (*integration.BigPandaIntegration, error)
+	GetChartFunc                       func(ctx context.Context, id string) (*chart.Chart, error)
+	GetDashboardFunc                   func(ctx context.Context, id string) (*dashboard.Dashboard, error)
+	GetDashboardGroupFunc              func(ctx context.Context, id string) (*dashboard_group.DashboardGroup, error)
+	GetDataLinkFunc                    func(ctx context.Context, id string) (*datalink.DataLink, error)
+	GetDetectorFunc                    func(ctx context.Context, id string) (*detector.Detector, error)
+	GetDetectorEventsFunc              func(ctx context.Context, id string, from int, to int, offset int, limit int) ([]*detector.Event, error)
+	GetDetectorIncidentsFunc           func(ctx context.Context, id string, offset int, limit int) ([]*detector.Incident, error)
+	GetDetectorsFunc                   func(ctx context.Context, limit int, name string, offset int) ([]*detector.Detector, error)
+	GetDimensionFunc                   func(ctx context.Context, key string, value string) (*metrics_metadata.Dimension, error)
+	GetEmailTemplateFunc               func(ctx context.Context, id string) (*emailtemplate.EmailTemplate, error)
+	GetExemptMetricsFunc               func(ctx context.Context) (*[]automated_archival.ExemptMetric, error)
+	GetGCPIntegrationFunc              func(ctx context.Context, id string) (*integration.GCPIntegration, error)
+	GetIncidentFunc                    func(ctx context.Context, id string) (*detector.Incident, error)
+	GetIncidentsFunc                   func(ctx context.Context, includeResolved bool, limit int, query string, offset int) ([]*detector.Incident, error)
+	GetIntegrationFunc                 func(ctx context.Context, id string) (map[string]interface{}, error)
+	GetJiraIntegrationFunc             func(ctx context.Context, id string) (*integration.JiraIntegration, error)
+	GetMemberFunc                      func(ctx context.Context, id string) (*organization.Member, error)
+	GetMetricFunc                      func(ctx context.Context, name string) (*metrics_metadata.Metric, error)
+	GetMetricRulesetFunc               func(ctx context.Context, id string) (*metric_ruleset.GetMetricRulesetResponse, error)
+	GetMetricTimeSeriesFunc            func(ctx context.Context, id string) (*metrics_metadata.MetricTimeSeries, error)
+	GetNavigatorFunc                   func(ctx context.Context, id string) (*navigator.Navigator, error)
+	GetOpsgenieIntegrationFunc         func(ctx context.Context, id string) (*integration.OpsgenieIntegration, error)
+	GetOrgTokenFunc                    func(ctx context.Context, id string) (*orgtoken.Token, error)
+	GetOrganizationFunc                func(ctx context.Context, arg1 string) (*organization.Organization, error)
+	GetOrganizationMembersFunc         func(ctx context.Context, limit int, query string, offset int, orderBy string) (*organization.MemberSearchResults, error)
+	GetPagerDutyIntegrationFunc        func(ctx context.Context, id string) (*integration.PagerDutyIntegration, error)
+	GetPagerDutyIntegrationByNameFunc  func(ctx context.Context, name string) (*integration.PagerDutyIntegration, error)
+	GetServiceNowIntegrationFunc       func(ctx context.Context, id string) (*integration.ServiceNowIntegration, error)
+	GetSettingsFunc                    func(ctx context.Context) (*automated_archival.AutomatedArchivalSettings, error)
+	GetSlackIntegrationFunc            func(ctx context.Context, id string) (*integration.SlackIntegration, error)
+	GetSloFunc                         func(ctx context.Context, id string) (*slo.SloObject, error)
+	GetTagFunc                         func(ctx context.Context, name string) (*metrics_metadata.Tag, error)
+	GetTeamFunc                        func(ctx context.Context, id string) (*team.Team, error)
+	GetVictorOpsIntegrationFunc        func(ctx context.Context, id string) (*integration.VictorOpsIntegration, error)
+	GetWebhookIntegrationFunc          func(ctx context.Context, id string) (*integration.WebhookIntegration, error)
+	InviteMemberFunc                   func(ctx context.Context, inviteRequest *organization.CreateUpdateMemberRequest) (*organization.Member, error)
+	InviteMembersFunc                  func(ctx context.Context, inviteRequest *organization.InviteMembersRequest) (*organization.InviteMembersRequest, error)
+	LinkDashboardGroupToTeamFunc       func(ctx context.Context, id string, dashboardGroupID string) error
+	LinkDetectorToTeamFunc             func(ctx context.Context, id string, detectorID string) error
+	ListBuiltInDashboardGroupsFunc     func(ctx context.Context, limit int, offset int) (*dashboard_group.SearchResult, error)
+	ListTopologyFunc                   func(ctx context.Context, req *apm.RetrieveServiceTopologyRequest) (*apm.RetrieveServiceTopologyResponse, error)
+	ModifyChartFunc                    func(ctx context.Context, id string, mutate func(req *chart.CreateUpdateChartRequest) error) (*chart.Chart, error)
+	ModifyDashboardFunc                func(ctx context.Context, id string, mutate func(req *dashboard.CreateUpdateDashboardRequest) error) (*dashboard.Dashboard, error)
+	ModifyDetectorFunc                 func(ctx context.Context, id string, mutate func(req *detector.CreateUpdateDetectorRequest) error) (*detector.Detector, error)
+	ModifyMetricRulesetFunc            func(ctx context.Context, id string, mutate func(req *metric_ruleset.UpdateMetricRulesetRequest) error) (*metric_ruleset.UpdateMetricRulesetResponse, error)
+	ModifyTeamFunc                     func(ctx context.Context, id string, mutate func(req *team.CreateUpdateTeamRequest) error) (*team.Team, error)
+	SearchAlertMutingRulesFunc         func(ctx context.Context, include string, limit int, query string, offset int) (*alertmuting.SearchResult, error)
+	SearchChartsFunc                   func(ctx context.Context, limit int, name string, offset int, tags string) (*chart.SearchResult, error)
+	SearchDashboardFunc                func(ctx context.Context, limit int, name string, offset int, tags string) (*dashboard.SearchResult, error)
+	SearchDashboardGroupsFunc          func(ctx context.Context, limit int, name string, offset int) (*dashboard_group.SearchResult, error)
+	SearchDataLinksFunc                func(ctx context.Context, limit int, context string, offset int) (*datalink.SearchResults, error)
+	SearchDetectorsFunc                func(ctx context.Context, limit int, name string, offset int, tags string) (*detector.SearchResults, error)
+	SearchDimensionFunc                func(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.DimensionQueryResponseModel, error)
+	SearchEmailTemplatesFunc           func(ctx context.Context, limit int, name string, offset int, orderBy string) (*emailtemplate.SearchResult, error)
+	SearchIntegrationsFunc             func(ctx context.Context, limit int, name string, offset int, integrationType string) (*integration.SearchResults, error)
+	SearchMetricFunc                   func(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.RetrieveMetricMetadataResponseModel, error)
+	SearchMetricRulesetsFunc           func(ctx context.Context, limit int, offset int) (*metric_ruleset.GetMetricRulesetsResponse, error)
+	SearchMetricTimeSeriesFunc         func(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.MetricTimeSeriesRetrieveResponseModel, error)
+	SearchNavigatorsFunc               func(ctx context.Context, limit int, name string, offset int) (*navigator.GetNavigatorsResponse, error)
+	SearchOrgTokensFunc                func(ctx context.Context, limit int, name string, offset int) (*orgtoken.SearchResults, error)
+	SearchSlosFunc                     func(ctx context.Context, limit int, name string, offset int) (*slo.SearchResults, error)
+	SearchTagFunc                      func(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.TagRetrieveResponseModel, error)
+	SearchTeamFunc                     func(ctx context.Context, limit int, name string, offset int, tags string) (*team.SearchResults, error)
+	UnlinkDashboardGroupFromTeamFunc   func(ctx context.Context, id string, dashboardGroupID string) error
+	UnlinkDetectorFromTeamFunc         func(ctx context.Context, id string, detectorID string) error
+	UpdateAWSCloudWatchIntegrationFunc func(ctx context.Context, id string, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error)
+	UpdateAlertMutingRuleFunc          func(ctx context.Context, id string, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error)
+	UpdateAzureIntegrationFunc         func(ctx context.Context, id string, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error)
+	UpdateBigPandaIntegrationFunc      func(ctx context.Context, id string, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error)
+	UpdateChartFunc                    func(ctx context.Context, id string, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error)
+	UpdateDashboardFunc                func(ctx context.Context, id string, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error)
+	UpdateDashboardGroupFunc           func(ctx context.Context, id string, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error)
+	UpdateDataLinkFunc                 func(ctx context.Context, id string, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error)
+	UpdateDetectorFunc                 func(ctx context.Context, id string, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error)
+	UpdateDimensionFunc                func(ctx context.Context, key string, value string, dim *metrics_metadata.Dimension) (*metrics_metadata.Dimension, error)
+	UpdateEmailTemplateFunc            func(ctx context.Context, id string, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error)
+	UpdateGCPIntegrationFunc           func(ctx context.Context, id string, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error)
+	UpdateJiraIntegrationFunc          func(ctx context.Context, id string, ji *integration.JiraIntegration) (*integration.JiraIntegration, error)
+	UpdateMemberFunc                   func(ctx context.Context, id string, updateRequest *organization.UpdateMemberRequest) (*organization.Member, error)
+	UpdateMetricRulesetFunc            func(ctx context.Context, id string, metricRuleset *metric_ruleset.UpdateMetricRulesetRequest) (*metric_ruleset.UpdateMetricRulesetResponse, error)
+	UpdateNavigatorFunc                func(ctx context.Context, id string, navigatorRequest *navigator.UpdateNavigatorRequest) (*navigator.Navigator, error)
+	UpdateOpsgenieIntegrationFunc      func(ctx context.Context, id string, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error)
+	UpdateOrgTokenFunc                 func(ctx context.Context, id string, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error)
+	UpdatePagerDutyIntegrationFunc     func(ctx context.Context, id string, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error)
+	UpdateServiceNowIntegrationFunc    func(ctx context.Context, id string, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error)
+	UpdateSettingsFunc                 func(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error)
+	UpdateSlackIntegrationFunc         func(ctx context.Context, id string, si *integration.SlackIntegration) (*integration.SlackIntegration, error)
+	UpdateSloFunc                      func(ctx context.Context, id string, sloRequest *slo.SloObject) (*slo.SloObject, error)
+	UpdateSloChartFunc                 func(ctx context.Context, id string, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error)
+	UpdateTeamFunc                     func(ctx context.Context, id string, t *team.CreateUpdateTeamRequest) (*team.Team, error)
+	UpdateVictorOpsIntegrationFunc     func(ctx context.Context, id string, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error)
+	UpdateWebhookIntegrationFunc       func(ctx context.Context, id string, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error)
+	ValidateChartFunc                  func(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) error
+	ValidateDashboardFunc              func(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) error
+	ValidateDashboardGroupFunc         func(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) error
+	ValidateDashboardGroupWithModeFunc func(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, validationMode signalfx.VisualizationObjectsValidation) error
+	ValidateDashboardWithModeFunc      func(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, validationMode signalfx.VisualizationObjectsValidation) error
+	ValidateDetectorFunc               func(ctx context.Context, detectorRequest *detector.ValidateDetectorRequestModel) error
+	ValidateSloFunc                    func(ctx context.Context, sloRequest *slo.SloObject) error
+}
+
+var _ signalfx.API = (*Client)(nil)
+
+// AllAlertMutingRules calls AllAlertMutingRulesFunc.
+func (m *Client) AllAlertMutingRules(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*alertmuting.AlertMutingRule, error] {
+	if m.AllAlertMutingRulesFunc == nil {
+		return notMockedSeq[*alertmuting.AlertMutingRule]("AllAlertMutingRules")
+	}
+	return m.AllAlertMutingRulesFunc(ctx, filter)
+}
+
+// AllCharts calls AllChartsFunc.
+func (m *Client) AllCharts(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*chart.Chart, error] {
+	if m.AllChartsFunc == nil {
+		return notMockedSeq[*chart.Chart]("AllCharts")
+	}
+	return m.AllChartsFunc(ctx, filter)
+}
+
+// AllDashboardGroups calls AllDashboardGroupsFunc.
+func (m *Client) AllDashboardGroups(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*dashboard_group.DashboardGroup, error] {
+	if m.AllDashboardGroupsFunc == nil {
+		return notMockedSeq[*dashboard_group.DashboardGroup]("AllDashboardGroups")
+	}
+	return m.AllDashboardGroupsFunc(ctx, filter)
+}
+
+// AllDashboards calls AllDashboardsFunc.
+func (m *Client) AllDashboards(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*dashboard.Dashboard, error] {
+	if m.AllDashboardsFunc == nil {
+		return notMockedSeq[*dashboard.Dashboard]("AllDashboards")
+	}
+	return m.AllDashboardsFunc(ctx, filter)
+}
+
+// AllDataLinks calls AllDataLinksFunc.
+func (m *Client) AllDataLinks(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*datalink.DataLink, error] {
+	if m.AllDataLinksFunc == nil {
+		return notMockedSeq[*datalink.DataLink]("AllDataLinks")
+	}
+	return m.AllDataLinksFunc(ctx, filter)
+}
+
+// AllDetectors calls AllDetectorsFunc.
+func (m *Client) AllDetectors(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*detector.Detector, error] {
+	if m.AllDetectorsFunc == nil {
+		return notMockedSeq[*detector.Detector]("AllDetectors")
+	}
+	return m.AllDetectorsFunc(ctx, filter)
+}
+
+// AllDimensions calls AllDimensionsFunc.
+func (m *Client) AllDimensions(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Dimension, error] {
+	if m.AllDimensionsFunc == nil {
+		return notMockedSeq[*metrics_metadata.Dimension]("AllDimensions")
+	}
+	return m.AllDimensionsFunc(ctx, filter)
+}
+
+// AllEmailTemplates calls AllEmailTemplatesFunc.
+func (m *Client) AllEmailTemplates(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*emailtemplate.EmailTemplate, error] {
+	if m.AllEmailTemplatesFunc == nil {
+		return notMockedSeq[*emailtemplate.EmailTemplate]("AllEmailTemplates")
+	}
+	return m.AllEmailTemplatesFunc(ctx, filter)
+}
+
+// AllIntegrations calls AllIntegrationsFunc.
+func (m *Client) AllIntegrations(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[map[string]interface{}, error] {
+	if m.AllIntegrationsFunc == nil {
+		return notMockedSeq[map[string]interface{}]("AllIntegrations")
+	}
+	return m.AllIntegrationsFunc(ctx, filter)
+}
+
+// AllMetricRulesets calls AllMetricRulesetsFunc.
+func (m *Client) AllMetricRulesets(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metric_ruleset.MetricRuleset, error] {
+	if m.AllMetricRulesetsFunc == nil {
+		return notMockedSeq[*metric_ruleset.MetricRuleset]("AllMetricRulesets")
+	}
+	return m.AllMetricRulesetsFunc(ctx, filter)
+}
+
+// AllMetricTimeSeries calls AllMetricTimeSeriesFunc.
+func (m *Client) AllMetricTimeSeries(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.MetricTimeSeries, error] {
+	if m.AllMetricTimeSeriesFunc == nil {
+		return notMockedSeq[*metrics_metadata.MetricTimeSeries]("AllMetricTimeSeries")
+	}
+	return m.AllMetricTimeSeriesFunc(ctx, filter)
+}
+
+// AllMetrics calls AllMetricsFunc.
+func (m *Client) AllMetrics(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Metric, error] {
+	if m.AllMetricsFunc == nil {
+		return notMockedSeq[*metrics_metadata.Metric]("AllMetrics")
+	}
+	return m.AllMetricsFunc(ctx, filter)
+}
+
+// AllNavigators calls AllNavigatorsFunc.
+func (m *Client) AllNavigators(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*navigator.GetNavigatorsResult, error] {
+	if m.AllNavigatorsFunc == nil {
+		return notMockedSeq[*navigator.GetNavigatorsResult]("AllNavigators")
+	}
+	return m.AllNavigatorsFunc(ctx, filter)
+}
+
+// AllOrgTokens calls AllOrgTokensFunc.
+func (m *Client) AllOrgTokens(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*orgtoken.Token, error] {
+	if m.AllOrgTokensFunc == nil {
+		return notMockedSeq[*orgtoken.Token]("AllOrgTokens")
+	}
+	return m.AllOrgTokensFunc(ctx, filter)
+}
+
+// AllOrganizationMembers calls AllOrganizationMembersFunc.
+func (m *Client) AllOrganizationMembers(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*organization.Member, error] {
+	if m.AllOrganizationMembersFunc == nil {
+		return notMockedSeq[*organization.Member]("AllOrganizationMembers")
+	}
+	return m.AllOrganizationMembersFunc(ctx, filter)
+}
+
+// AllSlos calls AllSlosFunc.
+func (m *Client) AllSlos(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*slo.SloObject, error] {
+	if m.AllSlosFunc == nil {
+		return notMockedSeq[*slo.SloObject]("AllSlos")
+	}
+	return m.AllSlosFunc(ctx, filter)
+}
+
+// AllTags calls AllTagsFunc.
+func (m *Client) AllTags(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*metrics_metadata.Tag, error] {
+	if m.AllTagsFunc == nil {
+		return notMockedSeq[*metrics_metadata.Tag]("AllTags")
+	}
+	return m.AllTagsFunc(ctx, filter)
+}
+
+// AllTeams calls AllTeamsFunc.
+func (m *Client) AllTeams(ctx context.Context, filter signalfx.SearchFilter) iter.Seq2[*team.Team, error] {
+	if m.AllTeamsFunc == nil {
+		return notMockedSeq[*team.Team]("AllTeams")
+	}
+	return m.AllTeamsFunc(ctx, filter)
+}
+
+// CreateAWSCloudWatchIntegration calls CreateAWSCloudWatchIntegrationFunc.
+func (m *Client) CreateAWSCloudWatchIntegration(ctx context.Context, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error) {
+	if m.CreateAWSCloudWatchIntegrationFunc == nil {
+		return nil, notMocked("CreateAWSCloudWatchIntegration")
+	}
+	return m.CreateAWSCloudWatchIntegrationFunc(ctx, acwi)
+}
+
+// CreateAlertMutingRule calls CreateAlertMutingRuleFunc.
+func (m *Client) CreateAlertMutingRule(ctx context.Context, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error) {
+	if m.CreateAlertMutingRuleFunc == nil {
+		return nil, notMocked("CreateAlertMutingRule")
+	}
+	return m.CreateAlertMutingRuleFunc(ctx, muteRequest)
+}
+
+// CreateAzureIntegration calls CreateAzureIntegrationFunc.
+func (m *Client) CreateAzureIntegration(ctx context.Context, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error) {
+	if m.CreateAzureIntegrationFunc == nil {
+		return nil, notMocked("CreateAzureIntegration")
+	}
+	return m.CreateAzureIntegrationFunc(ctx, acwi)
+}
+
+// CreateBigPandaIntegration calls CreateBigPandaIntegrationFunc.
+func (m *Client) CreateBigPandaIntegration(ctx context.Context, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error) {
+	if m.CreateBigPandaIntegrationFunc == nil {
+		return nil, notMocked("CreateBigPandaIntegration")
+	}
+	return m.CreateBigPandaIntegrationFunc(ctx, in)
+}
+
+// CreateChart calls CreateChartFunc.
+func (m *Client) CreateChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error) {
+	if m.CreateChartFunc == nil {
+		return nil, notMocked("CreateChart")
+	}
+	return m.CreateChartFunc(ctx, chartRequest)
+}
+
+// CreateDashboard calls CreateDashboardFunc.
+func (m *Client) CreateDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error) {
+	if m.CreateDashboardFunc == nil {
+		return nil, notMocked("CreateDashboard")
+	}
+	return m.CreateDashboardFunc(ctx, dashboardRequest)
+}
+
+// CreateDashboardGroup calls CreateDashboardGroupFunc.
+func (m *Client) CreateDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, skipImplicitDashboard bool) (*dashboard_group.DashboardGroup, error) {
+	if m.CreateDashboardGroupFunc == nil {
+		return nil, notMocked("CreateDashboardGroup")
+	}
+	return m.CreateDashboardGroupFunc(ctx, dashboardGroupRequest, skipImplicitDashboard)
+}
+
+// CreateDataLink calls CreateDataLinkFunc.
+func (m *Client) CreateDataLink(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error) {
+	if m.CreateDataLinkFunc == nil {
+		return nil, notMocked("CreateDataLink")
+	}
+	return m.CreateDataLinkFunc(ctx, dataLinkRequest)
+}
+
+// CreateDetector calls CreateDetectorFunc.
+func (m *Client) CreateDetector(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error) {
+	if m.CreateDetectorFunc == nil {
+		return nil, notMocked("CreateDetector")
+	}
+	return m.CreateDetectorFunc(ctx, detectorRequest)
+}
+
+// CreateEmailTemplate calls CreateEmailTemplateFunc.
+func (m *Client) CreateEmailTemplate(ctx context.Context, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error) {
+	if m.CreateEmailTemplateFunc == nil {
+		return nil, notMocked("CreateEmailTemplate")
+	}
+	return m.CreateEmailTemplateFunc(ctx, template)
+}
+
+// CreateExemptMetrics calls CreateExemptMetricsFunc.
+func (m *Client) CreateExemptMetrics(ctx context.Context, exemptMetrics *[]automated_archival.ExemptMetric) (*[]automated_archival.ExemptMetric, error) {
+	if m.CreateExemptMetricsFunc == nil {
+		return nil, notMocked("CreateExemptMetrics")
+	}
+	return m.CreateExemptMetricsFunc(ctx, exemptMetrics)
+}
+
+// CreateGCPIntegration calls CreateGCPIntegrationFunc.
+func (m *Client) CreateGCPIntegration(ctx context.Context, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error) {
+	if m.CreateGCPIntegrationFunc == nil {
+		return nil, notMocked("CreateGCPIntegration")
+	}
+	return m.CreateGCPIntegrationFunc(ctx, gcpi)
+}
+
+// CreateJiraIntegration calls CreateJiraIntegrationFunc.
+func (m *Client) CreateJiraIntegration(ctx context.Context, ji *integration.JiraIntegration) (*integration.JiraIntegration, error) {
+	if m.CreateJiraIntegrationFunc == nil {
+		return nil, notMocked("CreateJiraIntegration")
+	}
+	return m.CreateJiraIntegrationFunc(ctx, ji)
+}
+
+// CreateMetricRuleset calls CreateMetricRulesetFunc.
+func (m *Client) CreateMetricRuleset(ctx context.Context, metricRuleset *metric_ruleset.CreateMetricRulesetRequest) (*metric_ruleset.CreateMetricRulesetResponse, error) {
+	if m.CreateMetricRulesetFunc == nil {
+		return nil, notMocked("CreateMetricRuleset")
+	}
+	return m.CreateMetricRulesetFunc(ctx, metricRuleset)
+}
+
+// CreateNavigator calls CreateNavigatorFunc.
+func (m *Client) CreateNavigator(ctx context.Context, navigatorRequest *navigator.CreateNavigatorRequest) (*navigator.Navigator, error) {
+	if m.CreateNavigatorFunc == nil {
+		return nil, notMocked("CreateNavigator")
+	}
+	return m.CreateNavigatorFunc(ctx, navigatorRequest)
+}
+
+// CreateOpsgenieIntegration calls CreateOpsgenieIntegrationFunc.
+func (m *Client) CreateOpsgenieIntegration(ctx context.Context, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error) {
+	if m.CreateOpsgenieIntegrationFunc == nil {
+		return nil, notMocked("CreateOpsgenieIntegration")
+	}
+	return m.CreateOpsgenieIntegrationFunc(ctx, oi)
+}
+
+// CreateOrgToken calls CreateOrgTokenFunc.
+func (m *Client) CreateOrgToken(ctx context.Context, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error) {
+	if m.CreateOrgTokenFunc == nil {
+		return nil, notMocked("CreateOrgToken")
+	}
+	return m.CreateOrgTokenFunc(ctx, tokenRequest)
+}
+
+// CreatePagerDutyIntegration calls CreatePagerDutyIntegrationFunc.
+func (m *Client) CreatePagerDutyIntegration(ctx context.Context, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error) {
+	if m.CreatePagerDutyIntegrationFunc == nil {
+		return nil, notMocked("CreatePagerDutyIntegration")
+	}
+	return m.CreatePagerDutyIntegrationFunc(ctx, pdi)
+}
+
+// CreateServiceNowIntegration calls CreateServiceNowIntegrationFunc.
+func (m *Client) CreateServiceNowIntegration(ctx context.Context, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error) {
+	if m.CreateServiceNowIntegrationFunc == nil {
+		return nil, notMocked("CreateServiceNowIntegration")
+	}
+	return m.CreateServiceNowIntegrationFunc(ctx, in)
+}
+
+// CreateSessionToken calls CreateSessionTokenFunc.
+func (m *Client) CreateSessionToken(ctx context.Context, tokenRequest *sessiontoken.CreateTokenRequest) (*sessiontoken.Token, error) {
+	if m.CreateSessionTokenFunc == nil {
+		return nil, notMocked("CreateSessionToken")
+	}
+	return m.CreateSessionTokenFunc(ctx, tokenRequest)
+}
+
+// CreateSettings calls CreateSettingsFunc.
+func (m *Client) CreateSettings(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error) {
+	if m.CreateSettingsFunc == nil {
+		return nil, notMocked("CreateSettings")
+	}
+	return m.CreateSettingsFunc(ctx, settings)
+}
+
+// CreateSlackIntegration calls CreateSlackIntegrationFunc.
+func (m *Client) CreateSlackIntegration(ctx context.Context, si *integration.SlackIntegration) (*integration.SlackIntegration, error) {
+	if m.CreateSlackIntegrationFunc == nil {
+		return nil, notMocked("CreateSlackIntegration")
+	}
+	return m.CreateSlackIntegrationFunc(ctx, si)
+}
+
+// CreateSlo calls CreateSloFunc.
+func (m *Client) CreateSlo(ctx context.Context, sloRequest *slo.SloObject) (*slo.SloObject, error) {
+	if m.CreateSloFunc == nil {
+		return nil, notMocked("CreateSlo")
+	}
+	return m.CreateSloFunc(ctx, sloRequest)
+}
+
+// CreateSloChart calls CreateSloChartFunc.
+func (m *Client) CreateSloChart(ctx context.Context, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error) {
+	if m.CreateSloChartFunc == nil {
+		return nil, notMocked("CreateSloChart")
+	}
+	return m.CreateSloChartFunc(ctx, chartRequest)
+}
+
+// CreateTeam calls CreateTeamFunc.
+func (m *Client) CreateTeam(ctx context.Context, t *team.CreateUpdateTeamRequest) (*team.Team, error) {
+	if m.CreateTeamFunc == nil {
+		return nil, notMocked("CreateTeam")
+	}
+	return m.CreateTeamFunc(ctx, t)
+}
+
+// CreateUpdateMetric calls CreateUpdateMetricFunc.
+func (m *Client) CreateUpdateMetric(ctx context.Context, name string, cumr *metrics_metadata.CreateUpdateMetricRequest) (*metrics_metadata.Metric, error) {
+	if m.CreateUpdateMetricFunc == nil {
+		return nil, notMocked("CreateUpdateMetric")
+	}
+	return m.CreateUpdateMetricFunc(ctx, name, cumr)
+}
+
+// CreateUpdateTag calls CreateUpdateTagFunc.
+func (m *Client) CreateUpdateTag(ctx context.Context, name string, cutr *metrics_metadata.CreateUpdateTagRequest) (*metrics_metadata.Tag, error) {
+	if m.CreateUpdateTagFunc == nil {
+		return nil, notMocked("CreateUpdateTag")
+	}
+	return m.CreateUpdateTagFunc(ctx, name, cutr)
+}
+
+// CreateVictorOpsIntegration calls CreateVictorOpsIntegrationFunc.
+func (m *Client) CreateVictorOpsIntegration(ctx context.Context, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error) {
+	if m.CreateVictorOpsIntegrationFunc == nil {
+		return nil, notMocked("CreateVictorOpsIntegration")
+	}
+	return m.CreateVictorOpsIntegrationFunc(ctx, oi)
+}
+
+// CreateWebhookIntegration calls CreateWebhookIntegrationFunc.
+func (m *Client) CreateWebhookIntegration(ctx context.Context, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error) {
+	if m.CreateWebhookIntegrationFunc == nil {
+		return nil, notMocked("CreateWebhookIntegration")
+	}
+	return m.CreateWebhookIntegrationFunc(ctx, oi)
+}
+
+// DeleteAWSCloudWatchIntegration calls DeleteAWSCloudWatchIntegrationFunc.
+func (m *Client) DeleteAWSCloudWatchIntegration(ctx context.Context, id string) error {
+	if m.DeleteAWSCloudWatchIntegrationFunc == nil {
+		return notMocked("DeleteAWSCloudWatchIntegration")
+	}
+	return m.DeleteAWSCloudWatchIntegrationFunc(ctx, id)
+}
+
+// DeleteAlertMutingRule calls DeleteAlertMutingRuleFunc.
+func (m *Client) DeleteAlertMutingRule(ctx context.Context, name string) error {
+	if m.DeleteAlertMutingRuleFunc == nil {
+		return notMocked("DeleteAlertMutingRule")
+	}
+	return m.DeleteAlertMutingRuleFunc(ctx, name)
+}
+
+// DeleteAzureIntegration calls DeleteAzureIntegrationFunc.
+func (m *Client) DeleteAzureIntegration(ctx context.Context, id string) error {
+	if m.DeleteAzureIntegrationFunc == nil {
+		return notMocked("DeleteAzureIntegration")
+	}
+	return m.DeleteAzureIntegrationFunc(ctx, id)
+}
+
+// DeleteBigPandaIntegration calls DeleteBigPandaIntegrationFunc.
+func (m *Client) DeleteBigPandaIntegration(ctx context.Context, id string) error {
+	if m.DeleteBigPandaIntegrationFunc == nil {
+		return notMocked("DeleteBigPandaIntegration")
+	}
+	return m.DeleteBigPandaIntegrationFunc(ctx, id)
+}
+
+// DeleteChart calls DeleteChartFunc.
+func (m *Client) DeleteChart(ctx context.Context, id string) error {
+	if m.DeleteChartFunc == nil {
+		return notMocked("DeleteChart")
+	}
+	return m.DeleteChartFunc(ctx, id)
+}
+
+// DeleteDashboard calls DeleteDashboardFunc.
+func (m *Client) DeleteDashboard(ctx context.Context, id string) error {
+	if m.DeleteDashboardFunc == nil {
+		return notMocked("DeleteDashboard")
+	}
+	return m.DeleteDashboardFunc(ctx, id)
+}
+
+// DeleteDashboardGroup calls DeleteDashboardGroupFunc.
+func (m *Client) DeleteDashboardGroup(ctx context.Context, id string) error {
+	if m.DeleteDashboardGroupFunc == nil {
+		return notMocked("DeleteDashboardGroup")
+	}
+	return m.DeleteDashboardGroupFunc(ctx, id)
+}
+
+// DeleteDataLink calls DeleteDataLinkFunc.
+func (m *Client) DeleteDataLink(ctx context.Context, id string) error {
+	if m.DeleteDataLinkFunc == nil {
+		return notMocked("DeleteDataLink")
+	}
+	return m.DeleteDataLinkFunc(ctx, id)
+}
+
+// DeleteDetector calls DeleteDetectorFunc.
+func (m *Client) DeleteDetector(ctx context.Context, id string) error {
+	if m.DeleteDetectorFunc == nil {
+		return notMocked("DeleteDetector")
+	}
+	return m.DeleteDetectorFunc(ctx, id)
+}
+
+// DeleteEmailTemplate calls DeleteEmailTemplateFunc.
+func (m *Client) DeleteEmailTemplate(ctx context.Context, id string) error {
+	if m.DeleteEmailTemplateFunc == nil {
+		return notMocked("DeleteEmailTemplate")
+	}
+	return m.DeleteEmailTemplateFunc(ctx, id)
+}
+
+// DeleteExemptMetrics calls DeleteExemptMetricsFunc.
+func (m *Client) DeleteExemptMetrics(ctx context.Context, deleteExemptMetricsRequest *automated_archival.ExemptMetricDeleteRequest) error {
+	if m.DeleteExemptMetricsFunc == nil {
+		return notMocked("DeleteExemptMetrics")
+	}
+	return m.DeleteExemptMetricsFunc(ctx, deleteExemptMetricsRequest)
+}
+
+// DeleteGCPIntegration calls DeleteGCPIntegrationFunc.
+func (m *Client) DeleteGCPIntegration(ctx context.Context, id string) error {
+	if m.DeleteGCPIntegrationFunc == nil {
+		return notMocked("DeleteGCPIntegration")
+	}
+	return m.DeleteGCPIntegrationFunc(ctx, id)
+}
+
+// DeleteIntegration calls DeleteIntegrationFunc.
+func (m *Client) DeleteIntegration(ctx context.Context, id string) error {
+	if m.DeleteIntegrationFunc == nil {
+		return notMocked("DeleteIntegration")
+	}
+	return m.DeleteIntegrationFunc(ctx, id)
+}
+
+// DeleteJiraIntegration calls DeleteJiraIntegrationFunc.
+func (m *Client) DeleteJiraIntegration(ctx context.Context, id string) error {
+	if m.DeleteJiraIntegrationFunc == nil {
+		return notMocked("DeleteJiraIntegration")
+	}
+	return m.DeleteJiraIntegrationFunc(ctx, id)
+}
+
+// DeleteMember calls DeleteMemberFunc.
+func (m *Client) DeleteMember(ctx context.Context, id string) error {
+	if m.DeleteMemberFunc == nil {
+		return notMocked("DeleteMember")
+	}
+	return m.DeleteMemberFunc(ctx, id)
+}
+
+// DeleteMetricRuleset calls DeleteMetricRulesetFunc.
+func (m *Client) DeleteMetricRuleset(ctx context.Context, id string) error {
+	if m.DeleteMetricRulesetFunc == nil {
+		return notMocked("DeleteMetricRuleset")
+	}
+	return m.DeleteMetricRulesetFunc(ctx, id)
+}
+
+// DeleteNavigator calls DeleteNavigatorFunc.
+func (m *Client) DeleteNavigator(ctx context.Context, id string) error {
+	if m.DeleteNavigatorFunc == nil {
+		return notMocked("DeleteNavigator")
+	}
+	return m.DeleteNavigatorFunc(ctx, id)
+}
+
+// DeleteOpsgenieIntegration calls DeleteOpsgenieIntegrationFunc.
+func (m *Client) DeleteOpsgenieIntegration(ctx context.Context, id string) error {
+	if m.DeleteOpsgenieIntegrationFunc == nil {
+		return notMocked("DeleteOpsgenieIntegration")
+	}
+	return m.DeleteOpsgenieIntegrationFunc(ctx, id)
+}
+
+// DeleteOrgToken calls DeleteOrgTokenFunc.
+func (m *Client) DeleteOrgToken(ctx context.Context, name string) error {
+	if m.DeleteOrgTokenFunc == nil {
+		return notMocked("DeleteOrgToken")
+	}
+	return m.DeleteOrgTokenFunc(ctx, name)
+}
+
+// DeletePagerDutyIntegration calls DeletePagerDutyIntegrationFunc.
+func (m *Client) DeletePagerDutyIntegration(ctx context.Context, id string) error {
+	if m.DeletePagerDutyIntegrationFunc == nil {
+		return notMocked("DeletePagerDutyIntegration")
+	}
+	return m.DeletePagerDutyIntegrationFunc(ctx, id)
+}
+
+// DeleteServiceNowIntegration calls DeleteServiceNowIntegrationFunc.
+func (m *Client) DeleteServiceNowIntegration(ctx context.Context, id string) error {
+	if m.DeleteServiceNowIntegrationFunc == nil {
+		return notMocked("DeleteServiceNowIntegration")
+	}
+	return m.DeleteServiceNowIntegrationFunc(ctx, id)
+}
+
+// DeleteSessionToken calls DeleteSessionTokenFunc.
+func (m *Client) DeleteSessionToken(ctx context.Context, token string) error {
+	if m.DeleteSessionTokenFunc == nil {
+		return notMocked("DeleteSessionToken")
+	}
+	return m.DeleteSessionTokenFunc(ctx, token)
+}
+
+// DeleteSettings calls DeleteSettingsFunc.
+func (m *Client) DeleteSettings(ctx context.Context, deleteSettingsRequest *automated_archival.AutomatedArchivalSettingsDeleteRequest) error {
+	if m.DeleteSettingsFunc == nil {
+		return notMocked("DeleteSettings")
+	}
+	return m.DeleteSettingsFunc(ctx, deleteSettingsRequest)
+}
+
+// DeleteSlackIntegration calls DeleteSlackIntegrationFunc.
+func (m *Client) DeleteSlackIntegration(ctx context.Context, id string) error {
+	if m.DeleteSlackIntegrationFunc == nil {
+		return notMocked("DeleteSlackIntegration")
+	}
+	return m.DeleteSlackIntegrationFunc(ctx, id)
+}
+
+// DeleteSlo calls DeleteSloFunc.
+func (m *Client) DeleteSlo(ctx context.Context, id string) error {
+	if m.DeleteSloFunc == nil {
+		return notMocked("DeleteSlo")
+	}
+	return m.DeleteSloFunc(ctx, id)
+}
+
+// DeleteTag calls DeleteTagFunc.
+func (m *Client) DeleteTag(ctx context.Context, id string) error {
+	if m.DeleteTagFunc == nil {
+		return notMocked("DeleteTag")
+	}
+	return m.DeleteTagFunc(ctx, id)
+}
+
+// DeleteTeam calls DeleteTeamFunc.
+func (m *Client) DeleteTeam(ctx context.Context, id string) error {
+	if m.DeleteTeamFunc == nil {
+		return notMocked("DeleteTeam")
+	}
+	return m.DeleteTeamFunc(ctx, id)
+}
+
+// DeleteVictorOpsIntegration calls DeleteVictorOpsIntegrationFunc.
+func (m *Client) DeleteVictorOpsIntegration(ctx context.Context, id string) error {
+	if m.DeleteVictorOpsIntegrationFunc == nil {
+		return notMocked("DeleteVictorOpsIntegration")
+	}
+	return m.DeleteVictorOpsIntegrationFunc(ctx, id)
+}
+
+// DeleteWebhookIntegration calls DeleteWebhookIntegrationFunc.
+func (m *Client) DeleteWebhookIntegration(ctx context.Context, id string) error {
+	if m.DeleteWebhookIntegrationFunc == nil {
+		return notMocked("DeleteWebhookIntegration")
+	}
+	return m.DeleteWebhookIntegrationFunc(ctx, id)
+}
+
+// DisableDetector calls DisableDetectorFunc.
+func (m *Client) DisableDetector(ctx context.Context, id string, labels []string) error {
+	if m.DisableDetectorFunc == nil {
+		return notMocked("DisableDetector")
+	}
+	return m.DisableDetectorFunc(ctx, id, labels)
+}
+
+// EnableDetector calls EnableDetectorFunc.
+func (m *Client) EnableDetector(ctx context.Context, id string, labels []string) error {
+	if m.EnableDetectorFunc == nil {
+		return notMocked("EnableDetector")
+	}
+	return m.EnableDetectorFunc(ctx, id, labels)
+}
+
+// EnsureChart calls EnsureChartFunc.
+func (m *Client) EnsureChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest, key signalfx.EnsureKey) (*chart.Chart, signalfx.EnsureOutcome, error) {
+	if m.EnsureChartFunc == nil {
+		return nil, 0, notMocked("EnsureChart")
+	}
+	return m.EnsureChartFunc(ctx, chartRequest, key)
+}
+
+// EnsureDashboard calls EnsureDashboardFunc.
+func (m *Client) EnsureDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, key signalfx.EnsureKey) (*dashboard.Dashboard, signalfx.EnsureOutcome, error) {
+	if m.EnsureDashboardFunc == nil {
+		return nil, 0, notMocked("EnsureDashboard")
+	}
+	return m.EnsureDashboardFunc(ctx, dashboardRequest, key)
+}
+
+// EnsureDashboardGroup calls EnsureDashboardGroupFunc.
+func (m *Client) EnsureDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, signalfx.EnsureOutcome, error) {
+	if m.EnsureDashboardGroupFunc == nil {
+		return nil, 0, notMocked("EnsureDashboardGroup")
+	}
+	return m.EnsureDashboardGroupFunc(ctx, dashboardGroupRequest)
+}
+
+// EnsureDataLink calls EnsureDataLinkFunc.
+func (m *Client) EnsureDataLink(ctx context.Context, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, signalfx.EnsureOutcome, error) {
+	if m.EnsureDataLinkFunc == nil {
+		return nil, 0, notMocked("EnsureDataLink")
+	}
+	return m.EnsureDataLinkFunc(ctx, dataLinkRequest)
+}
+
+// EnsureDetector calls EnsureDetectorFunc.
+func (m *Client) EnsureDetector(ctx context.Context, detectorRequest *detector.CreateUpdateDetectorRequest, key signalfx.EnsureKey) (*detector.Detector, signalfx.EnsureOutcome, error) {
+	if m.EnsureDetectorFunc == nil {
+		return nil, 0, notMocked("EnsureDetector")
+	}
+	return m.EnsureDetectorFunc(ctx, detectorRequest, key)
+}
+
+// EnsureTeam calls EnsureTeamFunc.
+func (m *Client) EnsureTeam(ctx context.Context, teamRequest *team.CreateUpdateTeamRequest) (*team.Team, signalfx.EnsureOutcome, error) {
+	if m.EnsureTeamFunc == nil {
+		return nil, 0, notMocked("EnsureTeam")
+	}
+	return m.EnsureTeamFunc(ctx, teamRequest)
+}
+
+// GenerateAggregationMetricName calls GenerateAggregationMetricNameFunc.
+func (m *Client) GenerateAggregationMetricName(ctx context.Context, generateAggregationNameRequest metric_ruleset.GenerateAggregationNameRequest) (string, error) {
+	if m.GenerateAggregationMetricNameFunc == nil {
+		return "", notMocked("GenerateAggregationMetricName")
+	}
+	return m.GenerateAggregationMetricNameFunc(ctx, generateAggregationNameRequest)
+}
+
+// GetAWSCloudWatchIntegration calls GetAWSCloudWatchIntegrationFunc.
+func (m *Client) GetAWSCloudWatchIntegration(ctx context.Context, id string) (*integration.AwsCloudWatchIntegration, error) {
+	if m.GetAWSCloudWatchIntegrationFunc == nil {
+		return nil, notMocked("GetAWSCloudWatchIntegration")
+	}
+	return m.GetAWSCloudWatchIntegrationFunc(ctx, id)
+}
+
+// GetAlertMutingRule calls GetAlertMutingRuleFunc.
+func (m *Client) GetAlertMutingRule(ctx context.Context, id string) (*alertmuting.AlertMutingRule, error) {
+	if m.GetAlertMutingRuleFunc == nil {
+		return nil, notMocked("GetAlertMutingRule")
+	}
+	return m.GetAlertMutingRuleFunc(ctx, id)
+}
+
+// GetAzureIntegration calls GetAzureIntegrationFunc.
+func (m *Client) GetAzureIntegration(ctx context.Context, id string) (*integration.AzureIntegration, error) {
+	if m.GetAzureIntegrationFunc == nil {
+		return nil, notMocked("GetAzureIntegration")
+	}
+	return m.GetAzureIntegrationFunc(ctx, id)
+}
+
+// GetBigPandaIntegration calls GetBigPandaIntegrationFunc.
+func (m *Client) GetBigPandaIntegration(ctx context.Context, id string) (*integration.BigPandaIntegration, error) {
+	if m.GetBigPandaIntegrationFunc == nil {
+		return nil, notMocked("GetBigPandaIntegration")
+	}
+	return m.GetBigPandaIntegrationFunc(ctx, id)
+}
+
+// GetChart calls GetChartFunc.
+func (m *Client) GetChart(ctx context.Context, id string) (*chart.Chart, error) {
+	if m.GetChartFunc == nil {
+		return nil, notMocked("GetChart")
+	}
+	return m.GetChartFunc(ctx, id)
+}
+
+// GetDashboard calls GetDashboardFunc.
+func (m *Client) GetDashboard(ctx context.Context, id string) (*dashboard.Dashboard, error) {
+	if m.GetDashboardFunc == nil {
+		return nil, notMocked("GetDashboard")
+	}
+	return m.GetDashboardFunc(ctx, id)
+}
+
+// GetDashboardGroup calls GetDashboardGroupFunc.
+func (m *Client) GetDashboardGroup(ctx context.Context, id string) (*dashboard_group.DashboardGroup, error) {
+	if m.GetDashboardGroupFunc == nil {
+		return nil, notMocked("GetDashboardGroup")
+	}
+	return m.GetDashboardGroupFunc(ctx, id)
+}
+
+// GetDataLink calls GetDataLinkFunc.
+func (m *Client) GetDataLink(ctx context.Context, id string) (*datalink.DataLink, error) {
+	if m.GetDataLinkFunc == nil {
+		return nil, notMocked("GetDataLink")
+	}
+	return m.GetDataLinkFunc(ctx, id)
+}
+
+// GetDetector calls GetDetectorFunc.
+func (m *Client) GetDetector(ctx context.Context, id string) (*detector.Detector, error) {
+	if m.GetDetectorFunc == nil {
+		return nil, notMocked("GetDetector")
+	}
+	return m.GetDetectorFunc(ctx, id)
+}
+
+// GetDetectorEvents calls GetDetectorEventsFunc.
+func (m *Client) GetDetectorEvents(ctx context.Context, id string, from int, to int, offset int, limit int) ([]*detector.Event, error) {
+	if m.GetDetectorEventsFunc == nil {
+		return nil, notMocked("GetDetectorEvents")
+	}
+	return m.GetDetectorEventsFunc(ctx, id, from, to, offset, limit)
+}
+
+// GetDetectorIncidents calls GetDetectorIncidentsFunc.
+func (m *Client) GetDetectorIncidents(ctx context.Context, id string, offset int, limit int) ([]*detector.Incident, error) {
+	if m.GetDetectorIncidentsFunc == nil {
+		return nil, notMocked("GetDetectorIncidents")
+	}
+	return m.GetDetectorIncidentsFunc(ctx, id, offset, limit)
+}
+
+// GetDetectors calls GetDetectorsFunc.
+func (m *Client) GetDetectors(ctx context.Context, limit int, name string, offset int) ([]*detector.Detector, error) {
+	if m.GetDetectorsFunc == nil {
+		return nil, notMocked("GetDetectors")
+	}
+	return m.GetDetectorsFunc(ctx, limit, name, offset)
+}
+
+// GetDimension calls GetDimensionFunc.
+func (m *Client) GetDimension(ctx context.Context, key string, value string) (*metrics_metadata.Dimension, error) {
+	if m.GetDimensionFunc == nil {
+		return nil, notMocked("GetDimension")
+	}
+	return m.GetDimensionFunc(ctx, key, value)
+}
+
+// GetEmailTemplate calls GetEmailTemplateFunc.
+func (m *Client) GetEmailTemplate(ctx context.Context, id string) (*emailtemplate.EmailTemplate, error) {
+	if m.GetEmailTemplateFunc == nil {
+		return nil, notMocked("GetEmailTemplate")
+	}
+	return m.GetEmailTemplateFunc(ctx, id)
+}
+
+// GetExemptMetrics calls GetExemptMetricsFunc.
+func (m *Client) GetExemptMetrics(ctx context.Context) (*[]automated_archival.ExemptMetric, error) {
+	if m.GetExemptMetricsFunc == nil {
+		return nil, notMocked("GetExemptMetrics")
+	}
+	return m.GetExemptMetricsFunc(ctx)
+}
+
+// GetGCPIntegration calls GetGCPIntegrationFunc.
+func (m *Client) GetGCPIntegration(ctx context.Context, id string) (*integration.GCPIntegration, error) {
+	if m.GetGCPIntegrationFunc == nil {
+		return nil, notMocked("GetGCPIntegration")
+	}
+	return m.GetGCPIntegrationFunc(ctx, id)
+}
+
+// GetIncident calls GetIncidentFunc.
+func (m *Client) GetIncident(ctx context.Context, id string) (*detector.Incident, error) {
+	if m.GetIncidentFunc == nil {
+		return nil, notMocked("GetIncident")
+	}
+	return m.GetIncidentFunc(ctx, id)
+}
+
+// GetIncidents calls GetIncidentsFunc.
+func (m *Client) GetIncidents(ctx context.Context, includeResolved bool, limit int, query string, offset int) ([]*detector.Incident, error) {
+	if m.GetIncidentsFunc == nil {
+		return nil, notMocked("GetIncidents")
+	}
+	return m.GetIncidentsFunc(ctx, includeResolved, limit, query, offset)
+}
+
+// GetIntegration calls GetIntegrationFunc.
+func (m *Client) GetIntegration(ctx context.Context, id string) (map[string]interface{}, error) {
+	if m.GetIntegrationFunc == nil {
+		return nil, notMocked("GetIntegration")
+	}
+	return m.GetIntegrationFunc(ctx, id)
+}
+
+// GetJiraIntegration calls GetJiraIntegrationFunc.
+func (m *Client) GetJiraIntegration(ctx context.Context, id string) (*integration.JiraIntegration, error) {
+	if m.GetJiraIntegrationFunc == nil {
+		return nil, notMocked("GetJiraIntegration")
+	}
+	return m.GetJiraIntegrationFunc(ctx, id)
+}
+
+// GetMember calls GetMemberFunc.
+func (m *Client) GetMember(ctx context.Context, id string) (*organization.Member, error) {
+	if m.GetMemberFunc == nil {
+		return nil, notMocked("GetMember")
+	}
+	return m.GetMemberFunc(ctx, id)
+}
+
+// GetMetric calls GetMetricFunc.
+func (m *Client) GetMetric(ctx context.Context, name string) (*metrics_metadata.Metric, error) {
+	if m.GetMetricFunc == nil {
+		return nil, notMocked("GetMetric")
+	}
+	return m.GetMetricFunc(ctx, name)
+}
+
+// GetMetricRuleset calls GetMetricRulesetFunc.
+func (m *Client) GetMetricRuleset(ctx context.Context, id string) (*metric_ruleset.GetMetricRulesetResponse, error) {
+	if m.GetMetricRulesetFunc == nil {
+		return nil, notMocked("GetMetricRuleset")
+	}
+	return m.GetMetricRulesetFunc(ctx, id)
+}
+
+// GetMetricTimeSeries calls GetMetricTimeSeriesFunc.
+func (m *Client) GetMetricTimeSeries(ctx context.Context, id string) (*metrics_metadata.MetricTimeSeries, error) {
+	if m.GetMetricTimeSeriesFunc == nil {
+		return nil, notMocked("GetMetricTimeSeries")
+	}
+	return m.GetMetricTimeSeriesFunc(ctx, id)
+}
+
+// GetNavigator calls GetNavigatorFunc.
+func (m *Client) GetNavigator(ctx context.Context, id string) (*navigator.Navigator, error) {
+	if m.GetNavigatorFunc == nil {
+		return nil, notMocked("GetNavigator")
+	}
+	return m.GetNavigatorFunc(ctx, id)
+}
+
+// GetOpsgenieIntegration calls GetOpsgenieIntegrationFunc.
+func (m *Client) GetOpsgenieIntegration(ctx context.Context, id string) (*integration.OpsgenieIntegration, error) {
+	if m.GetOpsgenieIntegrationFunc == nil {
+		return nil, notMocked("GetOpsgenieIntegration")
+	}
+	return m.GetOpsgenieIntegrationFunc(ctx, id)
+}
+
+// GetOrgToken calls GetOrgTokenFunc.
+func (m *Client) GetOrgToken(ctx context.Context, id string) (*orgtoken.Token, error) {
+	if m.GetOrgTokenFunc == nil {
+		return nil, notMocked("GetOrgToken")
+	}
+	return m.GetOrgTokenFunc(ctx, id)
+}
+
+// GetOrganization calls GetOrganizationFunc.
+func (m *Client) GetOrganization(ctx context.Context, arg1 string) (*organization.Organization, error) {
+	if m.GetOrganizationFunc == nil {
+		return nil, notMocked("GetOrganization")
+	}
+	return m.GetOrganizationFunc(ctx, arg1)
+}
+
+// GetOrganizationMembers calls GetOrganizationMembersFunc.
+func (m *Client) GetOrganizationMembers(ctx context.Context, limit int, query string, offset int, orderBy string) (*organization.MemberSearchResults, error) {
+	if m.GetOrganizationMembersFunc == nil {
+		return nil, notMocked("GetOrganizationMembers")
+	}
+	return m.GetOrganizationMembersFunc(ctx, limit, query, offset, orderBy)
+}
+
+// GetPagerDutyIntegration calls GetPagerDutyIntegrationFunc.
+func (m *Client) GetPagerDutyIntegration(ctx context.Context, id string) (*integration.PagerDutyIntegration, error) {
+	if m.GetPagerDutyIntegrationFunc == nil {
+		return nil, notMocked("GetPagerDutyIntegration")
+	}
+	return m.GetPagerDutyIntegrationFunc(ctx, id)
+}
+
+// GetPagerDutyIntegrationByName calls GetPagerDutyIntegrationByNameFunc.
+func (m *Client) GetPagerDutyIntegrationByName(ctx context.Context, name string) (*integration.PagerDutyIntegration, error) {
+	if m.GetPagerDutyIntegrationByNameFunc == nil {
+		return nil, notMocked("GetPagerDutyIntegrationByName")
+	}
+	return m.GetPagerDutyIntegrationByNameFunc(ctx, name)
+}
+
+// GetServiceNowIntegration calls GetServiceNowIntegrationFunc.
+func (m *Client) GetServiceNowIntegration(ctx context.Context, id string) (*integration.ServiceNowIntegration, error) {
+	if m.GetServiceNowIntegrationFunc == nil {
+		return nil, notMocked("GetServiceNowIntegration")
+	}
+	return m.GetServiceNowIntegrationFunc(ctx, id)
+}
+
+// GetSettings calls GetSettingsFunc.
+func (m *Client) GetSettings(ctx context.Context) (*automated_archival.AutomatedArchivalSettings, error) {
+	if m.GetSettingsFunc == nil {
+		return nil, notMocked("GetSettings")
+	}
+	return m.GetSettingsFunc(ctx)
+}
+
+// GetSlackIntegration calls GetSlackIntegrationFunc.
+func (m *Client) GetSlackIntegration(ctx context.Context, id string) (*integration.SlackIntegration, error) {
+	if m.GetSlackIntegrationFunc == nil {
+		return nil, notMocked("GetSlackIntegration")
+	}
+	return m.GetSlackIntegrationFunc(ctx, id)
+}
+
+// GetSlo calls GetSloFunc.
+func (m *Client) GetSlo(ctx context.Context, id string) (*slo.SloObject, error) {
+	if m.GetSloFunc == nil {
+		return nil, notMocked("GetSlo")
+	}
+	return m.GetSloFunc(ctx, id)
+}
+
+// GetTag calls GetTagFunc.
+func (m *Client) GetTag(ctx context.Context, name string) (*metrics_metadata.Tag, error) {
+	if m.GetTagFunc == nil {
+		return nil, notMocked("GetTag")
+	}
+	return m.GetTagFunc(ctx, name)
+}
+
+// GetTeam calls GetTeamFunc.
+func (m *Client) GetTeam(ctx context.Context, id string) (*team.Team, error) {
+	if m.GetTeamFunc == nil {
+		return nil, notMocked("GetTeam")
+	}
+	return m.GetTeamFunc(ctx, id)
+}
+
+// GetVictorOpsIntegration calls GetVictorOpsIntegrationFunc.
+func (m *Client) GetVictorOpsIntegration(ctx context.Context, id string) (*integration.VictorOpsIntegration, error) {
+	if m.GetVictorOpsIntegrationFunc == nil {
+		return nil, notMocked("GetVictorOpsIntegration")
+	}
+	return m.GetVictorOpsIntegrationFunc(ctx, id)
+}
+
+// GetWebhookIntegration calls GetWebhookIntegrationFunc.
+func (m *Client) GetWebhookIntegration(ctx context.Context, id string) (*integration.WebhookIntegration, error) {
+	if m.GetWebhookIntegrationFunc == nil {
+		return nil, notMocked("GetWebhookIntegration")
+	}
+	return m.GetWebhookIntegrationFunc(ctx, id)
+}
+
+// InviteMember calls InviteMemberFunc.
+func (m *Client) InviteMember(ctx context.Context, inviteRequest *organization.CreateUpdateMemberRequest) (*organization.Member, error) {
+	if m.InviteMemberFunc == nil {
+		return nil, notMocked("InviteMember")
+	}
+	return m.InviteMemberFunc(ctx, inviteRequest)
+}
+
+// InviteMembers calls InviteMembersFunc.
+func (m *Client) InviteMembers(ctx context.Context, inviteRequest *organization.InviteMembersRequest) (*organization.InviteMembersRequest, error) {
+	if m.InviteMembersFunc == nil {
+		return nil, notMocked("InviteMembers")
+	}
+	return m.InviteMembersFunc(ctx, inviteRequest)
+}
+
+// LinkDashboardGroupToTeam calls LinkDashboardGroupToTeamFunc.
+func (m *Client) LinkDashboardGroupToTeam(ctx context.Context, id string, dashboardGroupID string) error {
+	if m.LinkDashboardGroupToTeamFunc == nil {
+		return notMocked("LinkDashboardGroupToTeam")
+	}
+	return m.LinkDashboardGroupToTeamFunc(ctx, id, dashboardGroupID)
+}
+
+// LinkDetectorToTeam calls LinkDetectorToTeamFunc.
+func (m *Client) LinkDetectorToTeam(ctx context.Context, id string, detectorID string) error {
+	if m.LinkDetectorToTeamFunc == nil {
+		return notMocked("LinkDetectorToTeam")
+	}
+	return m.LinkDetectorToTeamFunc(ctx, id, detectorID)
+}
+
+// ListBuiltInDashboardGroups calls ListBuiltInDashboardGroupsFunc.
+func (m *Client) ListBuiltInDashboardGroups(ctx context.Context, limit int, offset int) (*dashboard_group.SearchResult, error) {
+	if m.ListBuiltInDashboardGroupsFunc == nil {
+		return nil, notMocked("ListBuiltInDashboardGroups")
+	}
+	return m.ListBuiltInDashboardGroupsFunc(ctx, limit, offset)
+}
+
+// ListTopology calls ListTopologyFunc.
+func (m *Client) ListTopology(ctx context.Context, req *apm.RetrieveServiceTopologyRequest) (*apm.RetrieveServiceTopologyResponse, error) {
+	if m.ListTopologyFunc == nil {
+		return nil, notMocked("ListTopology")
+	}
+	return m.ListTopologyFunc(ctx, req)
+}
+
+// ModifyChart calls ModifyChartFunc.
+func (m *Client) ModifyChart(ctx context.Context, id string, mutate func(req *chart.CreateUpdateChartRequest) error) (*chart.Chart, error) {
+	if m.ModifyChartFunc == nil {
+		return nil, notMocked("ModifyChart")
+	}
+	return m.ModifyChartFunc(ctx, id, mutate)
+}
+
+// ModifyDashboard calls ModifyDashboardFunc.
+func (m *Client) ModifyDashboard(ctx context.Context, id string, mutate func(req *dashboard.CreateUpdateDashboardRequest) error) (*dashboard.Dashboard, error) {
+	if m.ModifyDashboardFunc == nil {
+		return nil, notMocked("ModifyDashboard")
+	}
+	return m.ModifyDashboardFunc(ctx, id, mutate)
+}
+
+// ModifyDetector calls ModifyDetectorFunc.
+func (m *Client) ModifyDetector(ctx context.Context, id string, mutate func(req *detector.CreateUpdateDetectorRequest) error) (*detector.Detector, error) {
+	if m.ModifyDetectorFunc == nil {
+		return nil, notMocked("ModifyDetector")
+	}
+	return m.ModifyDetectorFunc(ctx, id, mutate)
+}
+
+// ModifyMetricRuleset calls ModifyMetricRulesetFunc.
+func (m *Client) ModifyMetricRuleset(ctx context.Context, id string, mutate func(req *metric_ruleset.UpdateMetricRulesetRequest) error) (*metric_ruleset.UpdateMetricRulesetResponse, error) {
+	if m.ModifyMetricRulesetFunc == nil {
+		return nil, notMocked("ModifyMetricRuleset")
+	}
+	return m.ModifyMetricRulesetFunc(ctx, id, mutate)
+}
+
+// ModifyTeam calls ModifyTeamFunc.
+func (m *Client) ModifyTeam(ctx context.Context, id string, mutate func(req *team.CreateUpdateTeamRequest) error) (*team.Team, error) {
+	if m.ModifyTeamFunc == nil {
+		return nil, notMocked("ModifyTeam")
+	}
+	return m.ModifyTeamFunc(ctx, id, mutate)
+}
+
+// SearchAlertMutingRules calls SearchAlertMutingRulesFunc.
+func (m *Client) SearchAlertMutingRules(ctx context.Context, include string, limit int, query string, offset int) (*alertmuting.SearchResult, error) {
+	if m.SearchAlertMutingRulesFunc == nil {
+		return nil, notMocked("SearchAlertMutingRules")
+	}
+	return m.SearchAlertMutingRulesFunc(ctx, include, limit, query, offset)
+}
+
+// SearchCharts calls SearchChartsFunc.
+func (m *Client) SearchCharts(ctx context.Context, limit int, name string, offset int, tags string) (*chart.SearchResult, error) {
+	if m.SearchChartsFunc == nil {
+		return nil, notMocked("SearchCharts")
+	}
+	return m.SearchChartsFunc(ctx, limit, name, offset, tags)
+}
+
+// SearchDashboard calls SearchDashboardFunc.
+func (m *Client) SearchDashboard(ctx context.Context, limit int, name string, offset int, tags string) (*dashboard.SearchResult, error) {
+	if m.SearchDashboardFunc == nil {
+		return nil, notMocked("SearchDashboard")
+	}
+	return m.SearchDashboardFunc(ctx, limit, name, offset, tags)
+}
+
+// SearchDashboardGroups calls SearchDashboardGroupsFunc.
+func (m *Client) SearchDashboardGroups(ctx context.Context, limit int, name string, offset int) (*dashboard_group.SearchResult, error) {
+	if m.SearchDashboardGroupsFunc == nil {
+		return nil, notMocked("SearchDashboardGroups")
+	}
+	return m.SearchDashboardGroupsFunc(ctx, limit, name, offset)
+}
+
+// SearchDataLinks calls SearchDataLinksFunc.
+func (m *Client) SearchDataLinks(ctx context.Context, limit int, context string, offset int) (*datalink.SearchResults, error) {
+	if m.SearchDataLinksFunc == nil {
+		return nil, notMocked("SearchDataLinks")
+	}
+	return m.SearchDataLinksFunc(ctx, limit, context, offset)
+}
+
+// SearchDetectors calls SearchDetectorsFunc.
+func (m *Client) SearchDetectors(ctx context.Context, limit int, name string, offset int, tags string) (*detector.SearchResults, error) {
+	if m.SearchDetectorsFunc == nil {
+		return nil, notMocked("SearchDetectors")
+	}
+	return m.SearchDetectorsFunc(ctx, limit, name, offset, tags)
+}
+
+// SearchDimension calls SearchDimensionFunc.
+func (m *Client) SearchDimension(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.DimensionQueryResponseModel, error) {
+	if m.SearchDimensionFunc == nil {
+		return nil, notMocked("SearchDimension")
+	}
+	return m.SearchDimensionFunc(ctx, query, orderBy, limit, offset)
+}
+
+// SearchEmailTemplates calls SearchEmailTemplatesFunc.
+func (m *Client) SearchEmailTemplates(ctx context.Context, limit int, name string, offset int, orderBy string) (*emailtemplate.SearchResult, error) {
+	if m.SearchEmailTemplatesFunc == nil {
+		return nil, notMocked("SearchEmailTemplates")
+	}
+	return m.SearchEmailTemplatesFunc(ctx, limit, name, offset, orderBy)
+}
+
+// SearchIntegrations calls SearchIntegrationsFunc.
+func (m *Client) SearchIntegrations(ctx context.Context, limit int, name string, offset int, integrationType string) (*integration.SearchResults, error) {
+	if m.SearchIntegrationsFunc == nil {
+		return nil, notMocked("SearchIntegrations")
+	}
+	return m.SearchIntegrationsFunc(ctx, limit, name, offset, integrationType)
+}
+
+// SearchMetric calls SearchMetricFunc.
+func (m *Client) SearchMetric(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.RetrieveMetricMetadataResponseModel, error) {
+	if m.SearchMetricFunc == nil {
+		return nil, notMocked("SearchMetric")
+	}
+	return m.SearchMetricFunc(ctx, query, orderBy, limit, offset)
+}
+
+// SearchMetricRulesets calls SearchMetricRulesetsFunc.
+func (m *Client) SearchMetricRulesets(ctx context.Context, limit int, offset int) (*metric_ruleset.GetMetricRulesetsResponse, error) {
+	if m.SearchMetricRulesetsFunc == nil {
+		return nil, notMocked("SearchMetricRulesets")
+	}
+	return m.SearchMetricRulesetsFunc(ctx, limit, offset)
+}
+
+// SearchMetricTimeSeries calls SearchMetricTimeSeriesFunc.
+func (m *Client) SearchMetricTimeSeries(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.MetricTimeSeriesRetrieveResponseModel, error) {
+	if m.SearchMetricTimeSeriesFunc == nil {
+		return nil, notMocked("SearchMetricTimeSeries")
+	}
+	return m.SearchMetricTimeSeriesFunc(ctx, query, orderBy, limit, offset)
+}
+
+// SearchNavigators calls SearchNavigatorsFunc.
+func (m *Client) SearchNavigators(ctx context.Context, limit int, name string, offset int) (*navigator.GetNavigatorsResponse, error) {
+	if m.SearchNavigatorsFunc == nil {
+		return nil, notMocked("SearchNavigators")
+	}
+	return m.SearchNavigatorsFunc(ctx, limit, name, offset)
+}
+
+// SearchOrgTokens calls SearchOrgTokensFunc.
+func (m *Client) SearchOrgTokens(ctx context.Context, limit int, name string, offset int) (*orgtoken.SearchResults, error) {
+	if m.SearchOrgTokensFunc == nil {
+		return nil, notMocked("SearchOrgTokens")
+	}
+	return m.SearchOrgTokensFunc(ctx, limit, name, offset)
+}
+
+// SearchSlos calls SearchSlosFunc.
+func (m *Client) SearchSlos(ctx context.Context, limit int, name string, offset int) (*slo.SearchResults, error) {
+	if m.SearchSlosFunc == nil {
+		return nil, notMocked("SearchSlos")
+	}
+	return m.SearchSlosFunc(ctx, limit, name, offset)
+}
+
+// SearchTag calls SearchTagFunc.
+func (m *Client) SearchTag(ctx context.Context, query string, orderBy string, limit int, offset int) (*metrics_metadata.TagRetrieveResponseModel, error) {
+	if m.SearchTagFunc == nil {
+		return nil, notMocked("SearchTag")
+	}
+	return m.SearchTagFunc(ctx, query, orderBy, limit, offset)
+}
+
+// SearchTeam calls SearchTeamFunc.
+func (m *Client) SearchTeam(ctx context.Context, limit int, name string, offset int, tags string) (*team.SearchResults, error) {
+	if m.SearchTeamFunc == nil {
+		return nil, notMocked("SearchTeam")
+	}
+	return m.SearchTeamFunc(ctx, limit, name, offset, tags)
+}
+
+// UnlinkDashboardGroupFromTeam calls UnlinkDashboardGroupFromTeamFunc.
+func (m *Client) UnlinkDashboardGroupFromTeam(ctx context.Context, id string, dashboardGroupID string) error {
+	if m.UnlinkDashboardGroupFromTeamFunc == nil {
+		return notMocked("UnlinkDashboardGroupFromTeam")
+	}
+	return m.UnlinkDashboardGroupFromTeamFunc(ctx, id, dashboardGroupID)
+}
+
+// UnlinkDetectorFromTeam calls UnlinkDetectorFromTeamFunc.
+func (m *Client) UnlinkDetectorFromTeam(ctx context.Context, id string, detectorID string) error {
+	if m.UnlinkDetectorFromTeamFunc == nil {
+		return notMocked("UnlinkDetectorFromTeam")
+	}
+	return m.UnlinkDetectorFromTeamFunc(ctx, id, detectorID)
+}
+
+// UpdateAWSCloudWatchIntegration calls UpdateAWSCloudWatchIntegrationFunc.
+func (m *Client) UpdateAWSCloudWatchIntegration(ctx context.Context, id string, acwi *integration.AwsCloudWatchIntegration) (*integration.AwsCloudWatchIntegration, error) {
+	if m.UpdateAWSCloudWatchIntegrationFunc == nil {
+		return nil, notMocked("UpdateAWSCloudWatchIntegration")
+	}
+	return m.UpdateAWSCloudWatchIntegrationFunc(ctx, id, acwi)
+}
+
+// UpdateAlertMutingRule calls UpdateAlertMutingRuleFunc.
+func (m *Client) UpdateAlertMutingRule(ctx context.Context, id string, muteRequest *alertmuting.CreateUpdateAlertMutingRuleRequest) (*alertmuting.AlertMutingRule, error) {
+	if m.UpdateAlertMutingRuleFunc == nil {
+		return nil, notMocked("UpdateAlertMutingRule")
+	}
+	return m.UpdateAlertMutingRuleFunc(ctx, id, muteRequest)
+}
+
+// UpdateAzureIntegration calls UpdateAzureIntegrationFunc.
+func (m *Client) UpdateAzureIntegration(ctx context.Context, id string, acwi *integration.AzureIntegration) (*integration.AzureIntegration, error) {
+	if m.UpdateAzureIntegrationFunc == nil {
+		return nil, notMocked("UpdateAzureIntegration")
+	}
+	return m.UpdateAzureIntegrationFunc(ctx, id, acwi)
+}
+
+// UpdateBigPandaIntegration calls UpdateBigPandaIntegrationFunc.
+func (m *Client) UpdateBigPandaIntegration(ctx context.Context, id string, in *integration.BigPandaIntegration) (*integration.BigPandaIntegration, error) {
+	if m.UpdateBigPandaIntegrationFunc == nil {
+		return nil, notMocked("UpdateBigPandaIntegration")
+	}
+	return m.UpdateBigPandaIntegrationFunc(ctx, id, in)
+}
+
+// UpdateChart calls UpdateChartFunc.
+func (m *Client) UpdateChart(ctx context.Context, id string, chartRequest *chart.CreateUpdateChartRequest) (*chart.Chart, error) {
+	if m.UpdateChartFunc == nil {
+		return nil, notMocked("UpdateChart")
+	}
+	return m.UpdateChartFunc(ctx, id, chartRequest)
+}
+
+// UpdateDashboard calls UpdateDashboardFunc.
+func (m *Client) UpdateDashboard(ctx context.Context, id string, dashboardRequest *dashboard.CreateUpdateDashboardRequest) (*dashboard.Dashboard, error) {
+	if m.UpdateDashboardFunc == nil {
+		return nil, notMocked("UpdateDashboard")
+	}
+	return m.UpdateDashboardFunc(ctx, id, dashboardRequest)
+}
+
+// UpdateDashboardGroup calls UpdateDashboardGroupFunc.
+func (m *Client) UpdateDashboardGroup(ctx context.Context, id string, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) (*dashboard_group.DashboardGroup, error) {
+	if m.UpdateDashboardGroupFunc == nil {
+		return nil, notMocked("UpdateDashboardGroup")
+	}
+	return m.UpdateDashboardGroupFunc(ctx, id, dashboardGroupRequest)
+}
+
+// UpdateDataLink calls UpdateDataLinkFunc.
+func (m *Client) UpdateDataLink(ctx context.Context, id string, dataLinkRequest *datalink.CreateUpdateDataLinkRequest) (*datalink.DataLink, error) {
+	if m.UpdateDataLinkFunc == nil {
+		return nil, notMocked("UpdateDataLink")
+	}
+	return m.UpdateDataLinkFunc(ctx, id, dataLinkRequest)
+}
+
+// UpdateDetector calls UpdateDetectorFunc.
+func (m *Client) UpdateDetector(ctx context.Context, id string, detectorRequest *detector.CreateUpdateDetectorRequest) (*detector.Detector, error) {
+	if m.UpdateDetectorFunc == nil {
+		return nil, notMocked("UpdateDetector")
+	}
+	return m.UpdateDetectorFunc(ctx, id, detectorRequest)
+}
+
+// UpdateDimension calls UpdateDimensionFunc.
+func (m *Client) UpdateDimension(ctx context.Context, key string, value string, dim *metrics_metadata.Dimension) (*metrics_metadata.Dimension, error) {
+	if m.UpdateDimensionFunc == nil {
+		return nil, notMocked("UpdateDimension")
+	}
+	return m.UpdateDimensionFunc(ctx, key, value, dim)
+}
+
+// UpdateEmailTemplate calls UpdateEmailTemplateFunc.
+func (m *Client) UpdateEmailTemplate(ctx context.Context, id string, template *emailtemplate.EmailTemplate) (*emailtemplate.EmailTemplate, error) {
+	if m.UpdateEmailTemplateFunc == nil {
+		return nil, notMocked("UpdateEmailTemplate")
+	}
+	return m.UpdateEmailTemplateFunc(ctx, id, template)
+}
+
+// UpdateGCPIntegration calls UpdateGCPIntegrationFunc.
+func (m *Client) UpdateGCPIntegration(ctx context.Context, id string, gcpi *integration.GCPIntegration) (*integration.GCPIntegration, error) {
+	if m.UpdateGCPIntegrationFunc == nil {
+		return nil, notMocked("UpdateGCPIntegration")
+	}
+	return m.UpdateGCPIntegrationFunc(ctx, id, gcpi)
+}
+
+// UpdateJiraIntegration calls UpdateJiraIntegrationFunc.
+func (m *Client) UpdateJiraIntegration(ctx context.Context, id string, ji *integration.JiraIntegration) (*integration.JiraIntegration, error) {
+	if m.UpdateJiraIntegrationFunc == nil {
+		return nil, notMocked("UpdateJiraIntegration")
+	}
+	return m.UpdateJiraIntegrationFunc(ctx, id, ji)
+}
+
+// UpdateMember calls UpdateMemberFunc.
+func (m *Client) UpdateMember(ctx context.Context, id string, updateRequest *organization.UpdateMemberRequest) (*organization.Member, error) {
+	if m.UpdateMemberFunc == nil {
+		return nil, notMocked("UpdateMember")
+	}
+	return m.UpdateMemberFunc(ctx, id, updateRequest)
+}
+
+// UpdateMetricRuleset calls UpdateMetricRulesetFunc.
+func (m *Client) UpdateMetricRuleset(ctx context.Context, id string, metricRuleset *metric_ruleset.UpdateMetricRulesetRequest) (*metric_ruleset.UpdateMetricRulesetResponse, error) {
+	if m.UpdateMetricRulesetFunc == nil {
+		return nil, notMocked("UpdateMetricRuleset")
+	}
+	return m.UpdateMetricRulesetFunc(ctx, id, metricRuleset)
+}
+
+// UpdateNavigator calls UpdateNavigatorFunc.
+func (m *Client) UpdateNavigator(ctx context.Context, id string, navigatorRequest *navigator.UpdateNavigatorRequest) (*navigator.Navigator, error) {
+	if m.UpdateNavigatorFunc == nil {
+		return nil, notMocked("UpdateNavigator")
+	}
+	return m.UpdateNavigatorFunc(ctx, id, navigatorRequest)
+}
+
+// UpdateOpsgenieIntegration calls UpdateOpsgenieIntegrationFunc.
+func (m *Client) UpdateOpsgenieIntegration(ctx context.Context, id string, oi *integration.OpsgenieIntegration) (*integration.OpsgenieIntegration, error) {
+	if m.UpdateOpsgenieIntegrationFunc == nil {
+		return nil, notMocked("UpdateOpsgenieIntegration")
+	}
+	return m.UpdateOpsgenieIntegrationFunc(ctx, id, oi)
+}
+
+// UpdateOrgToken calls UpdateOrgTokenFunc.
+func (m *Client) UpdateOrgToken(ctx context.Context, id string, tokenRequest *orgtoken.CreateUpdateTokenRequest) (*orgtoken.Token, error) {
+	if m.UpdateOrgTokenFunc == nil {
+		return nil, notMocked("UpdateOrgToken")
+	}
+	return m.UpdateOrgTokenFunc(ctx, id, tokenRequest)
+}
+
+// UpdatePagerDutyIntegration calls UpdatePagerDutyIntegrationFunc.
+func (m *Client) UpdatePagerDutyIntegration(ctx context.Context, id string, pdi *integration.PagerDutyIntegration) (*integration.PagerDutyIntegration, error) {
+	if m.UpdatePagerDutyIntegrationFunc == nil {
+		return nil, notMocked("UpdatePagerDutyIntegration")
+	}
+	return m.UpdatePagerDutyIntegrationFunc(ctx, id, pdi)
+}
+
+// UpdateServiceNowIntegration calls UpdateServiceNowIntegrationFunc.
+func (m *Client) UpdateServiceNowIntegration(ctx context.Context, id string, in *integration.ServiceNowIntegration) (*integration.ServiceNowIntegration, error) {
+	if m.UpdateServiceNowIntegrationFunc == nil {
+		return nil, notMocked("UpdateServiceNowIntegration")
+	}
+	return m.UpdateServiceNowIntegrationFunc(ctx, id, in)
+}
+
+// UpdateSettings calls UpdateSettingsFunc.
+func (m *Client) UpdateSettings(ctx context.Context, settings *automated_archival.AutomatedArchivalSettings) (*automated_archival.AutomatedArchivalSettings, error) {
+	if m.UpdateSettingsFunc == nil {
+		return nil, notMocked("UpdateSettings")
+	}
+	return m.UpdateSettingsFunc(ctx, settings)
+}
+
+// UpdateSlackIntegration calls UpdateSlackIntegrationFunc.
+func (m *Client) UpdateSlackIntegration(ctx context.Context, id string, si *integration.SlackIntegration) (*integration.SlackIntegration, error) {
+	if m.UpdateSlackIntegrationFunc == nil {
+		return nil, notMocked("UpdateSlackIntegration")
+	}
+	return m.UpdateSlackIntegrationFunc(ctx, id, si)
+}
+
+// UpdateSlo calls UpdateSloFunc.
+func (m *Client) UpdateSlo(ctx context.Context, id string, sloRequest *slo.SloObject) (*slo.SloObject, error) {
+	if m.UpdateSloFunc == nil {
+		return nil, notMocked("UpdateSlo")
+	}
+	return m.UpdateSloFunc(ctx, id, sloRequest)
+}
+
+// UpdateSloChart calls UpdateSloChartFunc.
+func (m *Client) UpdateSloChart(ctx context.Context, id string, chartRequest *chart.CreateUpdateSloChartRequest) (*chart.Chart, error) {
+	if m.UpdateSloChartFunc == nil {
+		return nil, notMocked("UpdateSloChart")
+	}
+	return m.UpdateSloChartFunc(ctx, id, chartRequest)
+}
+
+// UpdateTeam calls UpdateTeamFunc.
+func (m *Client) UpdateTeam(ctx context.Context, id string, t *team.CreateUpdateTeamRequest) (*team.Team, error) {
+	if m.UpdateTeamFunc == nil {
+		return nil, notMocked("UpdateTeam")
+	}
+	return m.UpdateTeamFunc(ctx, id, t)
+}
+
+// UpdateVictorOpsIntegration calls UpdateVictorOpsIntegrationFunc.
+func (m *Client) UpdateVictorOpsIntegration(ctx context.Context, id string, oi *integration.VictorOpsIntegration) (*integration.VictorOpsIntegration, error) {
+	if m.UpdateVictorOpsIntegrationFunc == nil {
+		return nil, notMocked("UpdateVictorOpsIntegration")
+	}
+	return m.UpdateVictorOpsIntegrationFunc(ctx, id, oi)
+}
+
+// UpdateWebhookIntegration calls UpdateWebhookIntegrationFunc.
+func (m *Client) UpdateWebhookIntegration(ctx context.Context, id string, oi *integration.WebhookIntegration) (*integration.WebhookIntegration, error) {
+	if m.UpdateWebhookIntegrationFunc == nil {
+		return nil, notMocked("UpdateWebhookIntegration")
+	}
+	return m.UpdateWebhookIntegrationFunc(ctx, id, oi)
+}
+
+// ValidateChart calls ValidateChartFunc.
+func (m *Client) ValidateChart(ctx context.Context, chartRequest *chart.CreateUpdateChartRequest) error {
+	if m.ValidateChartFunc == nil {
+		return notMocked("ValidateChart")
+	}
+	return m.ValidateChartFunc(ctx, chartRequest)
+}
+
+// ValidateDashboard calls ValidateDashboardFunc.
+func (m *Client) ValidateDashboard(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest) error {
+	if m.ValidateDashboardFunc == nil {
+		return notMocked("ValidateDashboard")
+	}
+	return m.ValidateDashboardFunc(ctx, dashboardRequest)
+}
+
+// ValidateDashboardGroup calls ValidateDashboardGroupFunc.
+func (m *Client) ValidateDashboardGroup(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest) error {
+	if m.ValidateDashboardGroupFunc == nil {
+		return notMocked("ValidateDashboardGroup")
+	}
+	return m.ValidateDashboardGroupFunc(ctx, dashboardGroupRequest)
+}
+
+// ValidateDashboardGroupWithMode calls ValidateDashboardGroupWithModeFunc.
+func (m *Client) ValidateDashboardGroupWithMode(ctx context.Context, dashboardGroupRequest *dashboard_group.CreateUpdateDashboardGroupRequest, validationMode signalfx.VisualizationObjectsValidation) error {
+	if m.ValidateDashboardGroupWithModeFunc == nil {
+		return notMocked("ValidateDashboardGroupWithMode")
+	}
+	return m.ValidateDashboardGroupWithModeFunc(ctx, dashboardGroupRequest, validationMode)
+}
+
+// ValidateDashboardWithMode calls ValidateDashboardWithModeFunc.
+func (m *Client) ValidateDashboardWithMode(ctx context.Context, dashboardRequest *dashboard.CreateUpdateDashboardRequest, validationMode signalfx.VisualizationObjectsValidation) error {
+	if m.ValidateDashboardWithModeFunc == nil {
+		return notMocked("ValidateDashboardWithMode")
+	}
+	return m.ValidateDashboardWithModeFunc(ctx, dashboardRequest, validationMode)
+}
+
+// ValidateDetector calls ValidateDetectorFunc.
+func (m *Client) ValidateDetector(ctx context.Context, detectorRequest *detector.ValidateDetectorRequestModel) error {
+	if m.ValidateDetectorFunc == nil {
+		return notMocked("ValidateDetector")
+	}
+	return m.ValidateDetectorFunc(ctx, detectorRequest)
+}
+
+// ValidateSlo calls ValidateSloFunc.
+func (m *Client) ValidateSlo(ctx context.Context, sloRequest *slo.SloObject) error {
+	if m.ValidateSloFunc == nil {
+		return notMocked("ValidateSlo")
+	}
+	return m.ValidateSloFunc(ctx, sloRequest)
+}
@@ -0,0 +1,32 @@
+// Package signalfxmock provides a mock of signalfx.Client implementing
+// signalfx.API, and therefore every per resource interface, for unit tests
+// of code depending on those interfaces.
+//
+//	mock := &signalfxmock.Client{
+//		GetDetectorFunc: func(ctx context.Context, id string) (*detector.Detector, error) {
+//			return &detector.Detector{Id: id, Name: "cpu"}, nil
+//		},
+//	}
+//	var api signalfx.DetectorAPI = mock
+package signalfxmock
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrNotMocked is returned by the methods of Client whose function is not
+// set.
+var ErrNotMocked = errors.New("method not mocked")
+
+func notMocked(method string) error {
+	return fmt.Errorf("signalfxmock: %s: %w", method, ErrNotMocked)
+}
+
+func notMockedSeq[T any](method string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, notMocked(method))
+	}
+}
@@ -0,0 +1,33 @@
+package signalfxmock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	var api signalfx.DetectorAPI = &Client{
+		GetDetectorFunc: func(ctx context.Context, id string) (*detector.Detector, error) {
+			return &detector.Detector{Id: id}, nil
+		},
+	}
+	ctx := context.Background()
+
+	d, err := api.GetDetector(ctx, "ABC")
+	require.NoError(t, err, "Must call the function")
+	assert.Equal(t, "ABC", d.Id, "Must return the result of the function")
+
+	_, err = api.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{})
+	assert.ErrorIs(t, err, ErrNotMocked, "Must fail without function")
+
+	for _, err := range api.AllDetectors(ctx, signalfx.SearchFilter{}) {
+		assert.ErrorIs(t, err, ErrNotMocked, "Must fail iterating without function")
+	}
+}
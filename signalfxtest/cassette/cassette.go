@@ -0,0 +1,412 @@
+// Package cassette provides an `http.RoundTripper` that records the
+// interactions of a client with the SignalFx API to a file and replays them
+// later, so that tests built on real interactions can run offline.
+//
+// The recorder is installed with the HTTPClient parameter of the client:
+//
+//	rec, _ := cassette.New("testdata/detectors.json", cassette.Replay)
+//	defer rec.Close()
+//
+//	client, _ := signalfx.NewClient("token", signalfx.HTTPClient(&http.Client{Transport: rec}))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the scrubbed headers and fields in a cassette.
+const Redacted = "REDACTED"
+
+// Mode sets whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// Replay serves responses from the cassette without using the network.
+	Replay Mode = iota
+	// Record sends requests to the API and saves the interactions.
+	Record
+)
+
+// Match sets which parts of a request must be equal to a recorded one for
+// it to be replayed. Values are combined with `|`.
+type Match uint8
+
+const (
+	MatchMethod Match = 1 << iota
+	MatchPath
+	MatchQuery
+	// MatchBody compares JSON bodies regardless of formatting and key order.
+	MatchBody
+
+	MatchAll = MatchMethod | MatchPath | MatchQuery | MatchBody
+)
+
+// DefaultScrubbedHeaders are the headers removed from recorded requests and
+// responses.
+var DefaultScrubbedHeaders = []string{"X-Sf-Token", "Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultScrubbedPaths are the API paths, with their subpaths, whose
+// bodies hold secrets: those of integrations, organization tokens and
+// sessions.
+var DefaultScrubbedPaths = []string{"/v2/integration", "/v2/token", "/v2/session"}
+
+// DefaultScrubbedFields are the JSON fields, at any depth, whose values are
+// redacted from the recorded bodies of the DefaultScrubbedPaths. They cover
+// integration credentials and token secrets, and are left alone elsewhere
+// since fields such as the `key` of dimensions are not secrets.
+var DefaultScrubbedFields = []string{
+	"accessToken",
+	"apiKey",
+	"apiToken",
+	"appKey",
+	"hecToken",
+	"key",
+	"password",
+	"postUrl",
+	"projectKey",
+	"secret",
+	"secretKey",
+	"sharedSecret",
+	"token",
+	"webhookUrl",
+}
+
+// ErrNoInteraction is returned in Replay mode for requests that match no
+// unused interaction of the cassette.
+var ErrNoInteraction = errors.New("no matching interaction")
+
+// Cassette is the file format of recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  url.Values  `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an `http.RoundTripper` recording or replaying a cassette. It
+// is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	match     Match
+	transport http.RoundTripper
+	headers   []string
+	paths     []string
+	secrets   map[string]bool
+	fields    map[string]bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// Transport sets the transport used to send requests in Record mode. It
+// defaults to `http.DefaultTransport`.
+func Transport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// Matching sets the parts of a request compared when replaying. It defaults
+// to MatchAll.
+func Matching(match Match) Option {
+	return func(r *Recorder) {
+		r.match = match
+	}
+}
+
+// ScrubHeaders adds headers to remove from the recorded interactions.
+func ScrubHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.headers = append(r.headers, headers...)
+	}
+}
+
+// ScrubPaths adds API paths, with their subpaths, whose bodies have their
+// DefaultScrubbedFields redacted.
+func ScrubPaths(paths ...string) Option {
+	return func(r *Recorder) {
+		r.paths = append(r.paths, paths...)
+	}
+}
+
+// ScrubFields adds JSON fields to redact from the recorded bodies of every
+// path.
+func ScrubFields(fields ...string) Option {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.fields[field] = true
+		}
+	}
+}
+
+// New creates a Recorder for the cassette at path. In Replay mode the
+// cassette must exist. In Record mode it is written by Close.
+func New(path string, mode Mode, options ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		match:     MatchAll,
+		transport: http.DefaultTransport,
+		headers:   append([]string(nil), DefaultScrubbedHeaders...),
+		paths:     append([]string(nil), DefaultScrubbedPaths...),
+		secrets:   make(map[string]bool),
+		fields:    make(map[string]bool),
+	}
+	for _, field := range DefaultScrubbedFields {
+		r.secrets[field] = true
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	if mode == Replay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// Close writes the cassette in Record mode. It does nothing in Replay mode.
+func (r *Recorder) Close() error {
+	if r.mode != Record {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// RoundTrip implements `http.RoundTripper`.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Header: r.scrubHeader(req.Header),
+		Body:   r.scrubBody(req.URL.Path, body),
+	}
+
+	if r.mode == Replay {
+		return r.replay(req, recorded)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubHeader(resp.Header),
+			Body:       r.scrubBody(req.URL.Path, respBody),
+		},
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// replay serves the first unused interaction matching the request.
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		resp := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        resp.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w in %s for %s %s", ErrNoInteraction, r.path, req.Method, req.URL)
+}
+
+func (r *Recorder) matches(recorded, req Request) bool {
+	if r.match&MatchMethod != 0 && recorded.Method != req.Method {
+		return false
+	}
+	if r.match&MatchPath != 0 && recorded.Path != req.Path {
+		return false
+	}
+	if r.match&MatchQuery != 0 && recorded.Query.Encode() != req.Query.Encode() {
+		return false
+	}
+	if r.match&MatchBody != 0 && normalizeJSON(recorded.Body) != normalizeJSON(req.Body) {
+		return false
+	}
+	return true
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range r.headers {
+		header.Del(name)
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	return header
+}
+
+// scrubBody redacts the scrubbed fields of a JSON body sent to or received
+// from path. Other bodies are kept as is.
+func (r *Recorder) scrubBody(path string, body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	fields := r.fields
+	if r.secretPath(path) {
+		fields = maps.Clone(r.fields)
+		maps.Copy(fields, r.secrets)
+	}
+	if !scrub(v, fields) {
+		return string(body)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+// secretPath returns whether path is one of the scrubbed paths or one of
+// their subpaths.
+func (r *Recorder) secretPath(path string) bool {
+	for _, p := range r.paths {
+		p = strings.TrimSuffix(p, "/")
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// scrub redacts the fields of v in place and reports whether any was found.
+func scrub(v any, fields map[string]bool) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if fields[k] && child != nil {
+				v[k] = Redacted
+				changed = true
+				continue
+			}
+			changed = scrub(child, fields) || changed
+		}
+	case []any:
+		for _, child := range v {
+			changed = scrub(child, fields) || changed
+		}
+	}
+	return changed
+}
+
+// normalizeJSON returns a canonical form of a JSON body, or the body itself
+// if it is not JSON.
+func normalizeJSON(body string) string {
+	if body == "" {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(b)
+}
@@ -0,0 +1,149 @@
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/metrics_metadata"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	fake := signalfxtest.NewServer()
+	rec, err := New(path, Record)
+	require.NoError(t, err, "Must create the recorder")
+
+	client, _ := signalfx.NewClient("my-token", signalfx.APIUrl(fake.URL), signalfx.HTTPClient(&http.Client{Transport: rec}))
+	created, err := client.CreateOrgToken(ctx, &orgtoken.CreateUpdateTokenRequest{Name: "ingest"})
+	require.NoError(t, err, "Must create the token")
+	assert.NotEqual(t, Redacted, created.Secret, "Must return the real secret while recording")
+
+	_, err = client.GetOrgToken(ctx, "ingest")
+	require.NoError(t, err, "Must get the token")
+	require.NoError(t, rec.Close(), "Must write the cassette")
+	fake.Close()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err, "Must read the cassette")
+	assert.NotContains(t, string(b), "my-token", "Must scrub the token header")
+	assert.NotContains(t, string(b), created.Secret, "Must scrub the token secret")
+
+	replay, err := New(path, Replay)
+	require.NoError(t, err, "Must load the cassette")
+
+	// The recorded server is gone, so every response comes from the cassette.
+	client, _ = signalfx.NewClient("other-token", signalfx.APIUrl(fake.URL), signalfx.HTTPClient(&http.Client{Transport: replay}))
+	replayed, err := client.CreateOrgToken(ctx, &orgtoken.CreateUpdateTokenRequest{Name: "ingest"})
+	require.NoError(t, err, "Must replay the creation")
+	assert.Equal(t, created.Name, replayed.Name, "Must replay the response")
+	assert.Equal(t, Redacted, replayed.Secret, "Must replay the scrubbed secret")
+
+	_, err = client.GetOrgToken(ctx, "ingest")
+	require.NoError(t, err, "Must replay the get")
+
+	_, err = client.GetOrgToken(ctx, "ingest")
+	assert.ErrorIs(t, err, ErrNoInteraction, "Must replay each interaction once")
+}
+
+func TestRecordAndReplayDimension(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key":"host","value":"web-1","description":"front"}`))
+	}))
+	rec, err := New(path, Record)
+	require.NoError(t, err, "Must create the recorder")
+	client, _ := signalfx.NewClient("my-token", signalfx.APIUrl(server.URL), signalfx.HTTPClient(&http.Client{Transport: rec}))
+	_, err = client.UpdateDimension(ctx, "host", "web-1", &metrics_metadata.Dimension{Key: "host", Value: "web-1", Description: "front"})
+	require.NoError(t, err, "Must update the dimension")
+	require.NoError(t, rec.Close(), "Must write the cassette")
+	server.Close()
+
+	replay, err := New(path, Replay)
+	require.NoError(t, err, "Must load the cassette")
+	client, _ = signalfx.NewClient("my-token", signalfx.APIUrl(server.URL), signalfx.HTTPClient(&http.Client{Transport: replay}))
+	dim, err := client.UpdateDimension(ctx, "host", "web-1", &metrics_metadata.Dimension{Key: "host", Value: "web-1", Description: "front"})
+	require.NoError(t, err, "Must replay the request with its key")
+	assert.Equal(t, "host", dim.Key, "Must keep the key of dimensions")
+}
+
+func TestMatching(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions": [{
+		"request": {"method": "POST", "path": "/v2/detector", "query": {"a": ["1"]}, "body": "{\"name\":\"cpu\",\"tags\":[\"x\"]}"},
+		"response": {"statusCode": 200, "body": "{}"}
+	}]}`), 0o644))
+
+	cases := []struct {
+		name    string
+		match   Match
+		method  string
+		url     string
+		body    string
+		matches bool
+	}{
+		{"same", MatchAll, "POST", "/v2/detector?a=1", `{"name":"cpu","tags":["x"]}`, true},
+		{"key order and spacing", MatchAll, "POST", "/v2/detector?a=1", `{ "tags": ["x"], "name": "cpu" }`, true},
+		{"other body", MatchAll, "POST", "/v2/detector?a=1", `{"name":"mem"}`, false},
+		{"other query", MatchAll, "POST", "/v2/detector?a=2", `{"name":"cpu","tags":["x"]}`, false},
+		{"other method", MatchAll, "PUT", "/v2/detector?a=1", `{"name":"cpu","tags":["x"]}`, false},
+		{"ignore body and query", MatchMethod | MatchPath, "POST", "/v2/detector", `{}`, true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec, err := New(path, Replay, Matching(tc.match))
+			require.NoError(t, err, "Must load the cassette")
+
+			req, _ := http.NewRequest(tc.method, "http://example.com"+tc.url, strings.NewReader(tc.body))
+			resp, err := rec.RoundTrip(req)
+			if !tc.matches {
+				assert.ErrorIs(t, err, ErrNoInteraction, "Must not match")
+				return
+			}
+			require.NoError(t, err, "Must match")
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "Must replay the status")
+		})
+	}
+}
+
+func TestScrubBody(t *testing.T) {
+	t.Parallel()
+
+	rec, err := New("unused", Record, ScrubFields("custom"), ScrubPaths("/v2/custom/"))
+	require.NoError(t, err, "Must create the recorder")
+
+	assert.Equal(t,
+		`{"custom":"REDACTED","name":"x","projectServiceKeys":[{"projectId":"p","projectKey":"REDACTED"}]}`,
+		rec.scrubBody("/v2/integration/ID", []byte(`{"name":"x","custom":"c","projectServiceKeys":[{"projectId":"p","projectKey":"k"}]}`)),
+		"Must redact nested fields",
+	)
+	assert.Equal(t, `{"custom":"REDACTED","key":"k"}`, rec.scrubBody("/v2/dimension/k/v", []byte(`{"key":"k","custom":"c"}`)), "Must only redact secrets on the scrubbed paths")
+	assert.Equal(t, `{"key":"REDACTED"}`, rec.scrubBody("/v2/custom", []byte(`{"key":"k"}`)), "Must redact secrets on the added paths")
+	assert.Equal(t, `{"key":"k"}`, rec.scrubBody("/v2/integrations", []byte(`{"key":"k"}`)), "Must only match whole path segments")
+	assert.Equal(t, `{"name":"x"}`, rec.scrubBody("/v2/token", []byte(`{"name":"x"}`)), "Must keep bodies without secrets")
+	assert.Equal(t, "not json", rec.scrubBody("/v2/token", []byte("not json")), "Must keep other bodies")
+}
@@ -0,0 +1,508 @@
+package signalfxtest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/signalfx/signalfx-go/idtool"
+)
+
+const sessionPath = "/v2/session"
+
+// resource describes how a collection of the API behaves.
+type resource struct {
+	path string
+	// key is the field used to address a single object, usually `id`.
+	key string
+	// createStatus and deleteStatus are the codes the API responds with.
+	createStatus int
+	deleteStatus int
+	// match reports whether an object matches the search parameters.
+	match func(obj map[string]any, query url.Values) bool
+	// created is called after an object has been stored.
+	created func(s *Server, obj map[string]any, r *http.Request)
+	// deleted is called after an object has been removed.
+	deleted func(s *Server, obj map[string]any)
+	// action serves the sub paths of an object, such as `/enable`.
+	action func(s *Server, w http.ResponseWriter, r *http.Request, obj map[string]any, segments []string) bool
+	// collectionAction serves the sub paths that are not an object, such as
+	// `/validate`.
+	collectionAction func(s *Server, w http.ResponseWriter, r *http.Request, segment string, body []byte) bool
+}
+
+func (s *Server) defaultResources() []*resource {
+	validate := func(s *Server, w http.ResponseWriter, r *http.Request, segment string, body []byte) bool {
+		if segment != "validate" || r.Method != http.MethodPost {
+			return false
+		}
+		if _, err := decodeObject(body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+			return true
+		}
+		writeJSON(w, http.StatusNoContent, nil)
+		return true
+	}
+
+	return []*resource{
+		{
+			path:             "/v2/detector",
+			key:              "id",
+			createStatus:     http.StatusOK,
+			deleteStatus:     http.StatusNoContent,
+			match:            matchNameAndTags,
+			action:           detectorAction,
+			collectionAction: validate,
+		},
+		{
+			path:         "/v2/chart",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusOK,
+			match:        matchNameAndTags,
+			collectionAction: func(s *Server, w http.ResponseWriter, r *http.Request, segment string, body []byte) bool {
+				if segment == "createSloChart" && r.Method == http.MethodPost {
+					s.create(w, r, s.resource("/v2/chart"), body)
+					return true
+				}
+				return validate(s, w, r, segment, body)
+			},
+		},
+		{
+			path:             "/v2/dashboard",
+			key:              "id",
+			createStatus:     http.StatusOK,
+			deleteStatus:     http.StatusOK,
+			match:            matchNameAndTags,
+			created:          dashboardCreated,
+			deleted:          dashboardDeleted,
+			collectionAction: validate,
+		},
+		{
+			path:             "/v2/dashboardgroup",
+			key:              "id",
+			createStatus:     http.StatusOK,
+			deleteStatus:     http.StatusNoContent,
+			match:            matchNameAndTags,
+			created:          dashboardGroupCreated,
+			deleted:          dashboardGroupDeleted,
+			collectionAction: validate,
+		},
+		{
+			path:         "/v2/team",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusNoContent,
+			match:        matchNameAndTags,
+			action:       teamAction,
+		},
+		{
+			path:         "/v2/token",
+			key:          "name",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusNoContent,
+			match:        matchNameAndTags,
+			created: func(s *Server, obj map[string]any, r *http.Request) {
+				obj["secret"] = idtool.ID(rand.Int64()).String() + idtool.ID(rand.Int64()).String()
+				obj["latestRotation"] = obj["created"]
+			},
+		},
+		{
+			path:         "/v2/alertmuting",
+			key:          "id",
+			createStatus: http.StatusCreated,
+			deleteStatus: http.StatusNoContent,
+			match: func(obj map[string]any, query url.Values) bool {
+				return containsFold(obj["description"], query.Get("query"))
+			},
+		},
+		{
+			path:         "/v2/crosslink",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusNoContent,
+			match: func(obj map[string]any, query url.Values) bool {
+				context := query.Get("context")
+				return context == "" || obj["propertyName"] == context || obj["contextId"] == context
+			},
+		},
+		{
+			path:             "/v2/slo",
+			key:              "id",
+			createStatus:     http.StatusOK,
+			deleteStatus:     http.StatusNoContent,
+			match:            matchNameAndTags,
+			collectionAction: validate,
+		},
+		{
+			path:         "/v2/navigator",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusOK,
+			match: func(obj map[string]any, query url.Values) bool {
+				return containsFold(obj["displayName"], query.Get("name"))
+			},
+		},
+		{
+			path:         "/v2/metricruleset",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusNoContent,
+			match: func(obj map[string]any, query url.Values) bool {
+				return true
+			},
+		},
+		{
+			path:         "/v2/integration",
+			key:          "id",
+			createStatus: http.StatusOK,
+			deleteStatus: http.StatusNoContent,
+			match: func(obj map[string]any, query url.Values) bool {
+				if typ := query.Get("type"); typ != "" && obj["type"] != typ {
+					return false
+				}
+				return containsFold(obj["name"], query.Get("name"))
+			},
+		},
+	}
+}
+
+func (s *Server) resource(path string) *resource {
+	for _, res := range s.resources {
+		if res.path == path {
+			return res
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveResource(w http.ResponseWriter, r *http.Request, res *resource, segments []string, body []byte) {
+	if len(segments) == 0 {
+		switch r.Method {
+		case http.MethodPost:
+			s.create(w, r, res, body)
+		case http.MethodGet:
+			s.search(w, r, res)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
+	if res.collectionAction != nil && len(segments) == 1 && res.collectionAction(s, w, r, segments[0], body) {
+		return
+	}
+
+	// Update of SLO charts is addressed as `/v2/chart/updateSloChart/{id}`.
+	if res.path == "/v2/chart" && segments[0] == "updateSloChart" && len(segments) == 2 {
+		segments = segments[1:]
+	}
+
+	key, err := url.PathUnescape(segments[0])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid key")
+		return
+	}
+	obj, ok := s.objects[res.path][key]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %q not found", strings.TrimPrefix(res.path, "/v2/"), key))
+		return
+	}
+
+	if len(segments) > 1 {
+		if res.action == nil || !res.action(s, w, r, obj, segments[1:]) {
+			writeError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, obj)
+	case http.MethodPut:
+		s.update(w, res, obj, body)
+	case http.MethodDelete:
+		delete(s.objects[res.path], key)
+		if res.deleted != nil {
+			res.deleted(s, obj)
+		}
+		writeJSON(w, res.deleteStatus, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, res *resource, body []byte) {
+	obj, err := decodeObject(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if _, ok := s.insert(res, obj); !ok {
+		writeError(w, http.StatusConflict, fmt.Sprintf("an object named %q already exists", obj["name"]))
+		return
+	}
+	if res.created != nil {
+		res.created(s, obj, r)
+	}
+	writeJSON(w, res.createStatus, obj)
+}
+
+// insert stamps and stores a new object, returning false when its key is
+// already used.
+func (s *Server) insert(res *resource, obj map[string]any) (string, bool) {
+	now := s.stamp()
+	obj["id"] = s.newID(res.path)
+	obj["created"] = now
+	obj["creator"] = UserID
+	obj["lastUpdated"] = now
+	obj["lastUpdatedBy"] = UserID
+
+	key := fmt.Sprint(obj[res.key])
+	if _, exists := s.objects[res.path][key]; exists {
+		return "", false
+	}
+	s.objects[res.path][key] = obj
+	return key, true
+}
+
+func (s *Server) update(w http.ResponseWriter, res *resource, obj map[string]any, body []byte) {
+	changes, err := decodeObject(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+
+	// Updates replace the object but keep the fields managed by the API and
+	// the key it is addressed by.
+	key := obj[res.key]
+	for k := range obj {
+		if !serverManaged(k) {
+			delete(obj, k)
+		}
+	}
+	for k, v := range changes {
+		if !serverManaged(k) {
+			obj[k] = v
+		}
+	}
+	obj[res.key] = key
+	obj["lastUpdated"] = s.stamp()
+	obj["lastUpdatedBy"] = UserID
+
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func serverManaged(field string) bool {
+	switch field {
+	case "id", "created", "creator", "lastUpdated", "lastUpdatedBy", "secret", "latestRotation":
+		return true
+	}
+	return false
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request, res *resource) {
+	query := r.URL.Query()
+	var matches []map[string]any
+	for _, obj := range s.sorted(res.path) {
+		if res.match(obj, query) {
+			matches = append(matches, obj)
+		}
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 || limit+offset > 10000 {
+		writeError(w, http.StatusBadRequest, "offset and limit must not exceed 10000")
+		return
+	}
+
+	page := []map[string]any{}
+	if offset < len(matches) {
+		page = matches[offset:min(offset+limit, len(matches))]
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(matches), "results": page})
+}
+
+func (s *Server) authorized(token string) bool {
+	if token == "" {
+		return false
+	}
+	if expiry, ok := s.sessions[token]; ok {
+		return expiry > s.now().UnixMilli()
+	}
+	return s.token == "" || token == s.token
+}
+
+// serveSession creates and deletes session tokens. Session tokens last an
+// hour and are accepted by the fake alongside its configured token.
+func (s *Server) serveSession(w http.ResponseWriter, r *http.Request, body []byte) {
+	switch r.Method {
+	case http.MethodPost:
+		req, err := decodeObject(body)
+		if err != nil || req["email"] == nil || req["password"] == nil {
+			writeError(w, http.StatusBadRequest, "email and password are required")
+			return
+		}
+		now := s.stamp()
+		token := idtool.ID(rand.Int64()).String() + idtool.ID(rand.Int64()).String()
+		expiry := s.now().Add(time.Hour).UnixMilli()
+		s.sessions[token] = expiry
+		writeJSON(w, http.StatusOK, map[string]any{
+			"accessToken":    token,
+			"authMethod":     "UNDEFINED",
+			"createdMs":      now,
+			"email":          req["email"],
+			"expiryMs":       expiry,
+			"id":             idtool.ID(rand.Int64()).String(),
+			"organizationId": req["organizationId"],
+			"sessionType":    "ORG_USER",
+			"userId":         UserID,
+		})
+	case http.MethodDelete:
+		token := r.Header.Get(AuthHeaderKey)
+		if _, ok := s.sessions[token]; !ok {
+			writeError(w, http.StatusUnauthorized, "invalid token")
+			return
+		}
+		delete(s.sessions, token)
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func matchNameAndTags(obj map[string]any, query url.Values) bool {
+	if !containsFold(obj["name"], query.Get("name")) {
+		return false
+	}
+	if tags := query.Get("tags"); tags != "" {
+		list, _ := obj["tags"].([]any)
+		for _, tag := range strings.Split(tags, ",") {
+			if !slices.Contains(list, any(tag)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(value any, substr string) bool {
+	if substr == "" {
+		return true
+	}
+	str, _ := value.(string)
+	return strings.Contains(strings.ToLower(str), strings.ToLower(substr))
+}
+
+func detectorAction(s *Server, w http.ResponseWriter, r *http.Request, obj map[string]any, segments []string) bool {
+	if len(segments) != 1 {
+		return false
+	}
+	switch {
+	case segments[0] == "enable" && r.Method == http.MethodPut,
+		segments[0] == "disable" && r.Method == http.MethodPut:
+		disabled := segments[0] == "disable"
+		if rules, ok := obj["rules"].([]any); ok {
+			for _, rule := range rules {
+				if m, ok := rule.(map[string]any); ok {
+					m["disabled"] = disabled
+				}
+			}
+		}
+		obj["lastUpdated"] = s.stamp()
+		writeJSON(w, http.StatusNoContent, nil)
+	case (segments[0] == "events" || segments[0] == "incidents") && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, []any{})
+	default:
+		return false
+	}
+	return true
+}
+
+// teamAction links detectors and dashboard groups to a team, as
+// `/v2/team/{id}/detector/{detectorId}`.
+func teamAction(s *Server, w http.ResponseWriter, r *http.Request, obj map[string]any, segments []string) bool {
+	if len(segments) != 2 {
+		return false
+	}
+	var field string
+	switch segments[0] {
+	case "detector":
+		field = "detectors"
+	case "dashboardgroup":
+		field = "dashboardGroups"
+	default:
+		return false
+	}
+
+	list, _ := obj[field].([]any)
+	switch r.Method {
+	case http.MethodPost:
+		if !slices.Contains(list, any(segments[1])) {
+			list = append(list, segments[1])
+		}
+	case http.MethodDelete:
+		list = slices.DeleteFunc(list, func(v any) bool { return v == segments[1] })
+	default:
+		return false
+	}
+	obj[field] = list
+	obj["lastUpdated"] = s.stamp()
+	writeJSON(w, http.StatusNoContent, nil)
+	return true
+}
+
+// dashboardCreated adds the dashboard to its group, creating a group when
+// none was given as the API does.
+func dashboardCreated(s *Server, obj map[string]any, r *http.Request) {
+	groups := s.objects["/v2/dashboardgroup"]
+	id, _ := obj["groupId"].(string)
+	group, ok := groups[id]
+	if !ok {
+		group = map[string]any{"name": obj["name"]}
+		id, _ = s.insert(s.resource("/v2/dashboardgroup"), group)
+		obj["groupId"] = id
+	}
+	list, _ := group["dashboards"].([]any)
+	group["dashboards"] = append(list, obj["id"])
+}
+
+func dashboardDeleted(s *Server, obj map[string]any) {
+	group, ok := s.objects["/v2/dashboardgroup"][fmt.Sprint(obj["groupId"])]
+	if !ok {
+		return
+	}
+	list, _ := group["dashboards"].([]any)
+	group["dashboards"] = slices.DeleteFunc(list, func(v any) bool { return v == obj["id"] })
+}
+
+// dashboardGroupCreated adds the default dashboard the API creates unless
+// the `empty` parameter is set.
+func dashboardGroupCreated(s *Server, obj map[string]any, r *http.Request) {
+	if r.URL.Query().Get("empty") == "true" {
+		return
+	}
+	dash := map[string]any{
+		"name":    "Default Dashboard",
+		"groupId": obj["id"],
+	}
+	if _, ok := s.insert(s.resource("/v2/dashboard"), dash); ok {
+		obj["dashboards"] = []any{dash["id"]}
+	}
+}
+
+func dashboardGroupDeleted(s *Server, obj map[string]any) {
+	list, _ := obj["dashboards"].([]any)
+	for _, id := range list {
+		delete(s.objects["/v2/dashboard"], fmt.Sprint(id))
+	}
+}
@@ -0,0 +1,253 @@
+// Package signalfxtest provides an in-memory fake of the SignalFx API for
+// testing code built on top of the signalfx client.
+//
+// The fake keeps the objects created through it and serves them back, so a
+// client pointed at it behaves as it would against a real organization:
+//
+//	fake := signalfxtest.NewServer()
+//	defer fake.Close()
+//
+//	client, _ := signalfx.NewClient("token", signalfx.APIUrl(fake.URL))
+package signalfxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/signalfx/signalfx-go/idtool"
+)
+
+// UserID is the user recorded as creator and last updater of every object.
+const UserID = "AAAAAAAAAAA"
+
+// AuthHeaderKey is the header carrying the token of a request.
+const AuthHeaderKey = "X-Sf-Token"
+
+// Server is a fake SignalFx API backed by an `httptest.Server`. It is safe
+// for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	token     string
+	now       func() time.Time
+	lastStamp int64
+	resources []*resource
+	objects   map[string]map[string]map[string]any
+	failures  []failure
+	requests  []Request
+	sessions  map[string]int64
+}
+
+// Request records a request received by the fake.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+type failure struct {
+	method string
+	path   string
+	status int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// Token makes the fake reject requests that do not use the given token. By
+// default any non empty token is accepted.
+func Token(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// Clock sets the function used to stamp `created` and `lastUpdated`.
+func Clock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a fake API server. Callers should call Close when done.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		now:      time.Now,
+		objects:  make(map[string]map[string]map[string]any),
+		sessions: make(map[string]int64),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.resources = s.defaultResources()
+	for _, res := range s.resources {
+		s.objects[res.path] = make(map[string]map[string]any)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// FailNext makes the next request with the given method and path, such as
+// `/v2/detector/ABC`, fail with status.
+func (s *Server) FailNext(method, path string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{method: method, path: path, status: status})
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// Objects returns the stored objects of the collection at path, such as
+// `/v2/detector`, as JSON documents ordered by creation.
+func (s *Server) Objects(path string) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []json.RawMessage
+	for _, obj := range s.sorted(path) {
+		b, _ := json.Marshal(obj)
+		out = append(out, b)
+	}
+	return out
+}
+
+// Reset removes every stored object, recorded request and pending failure.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for path := range s.objects {
+		s.objects[path] = make(map[string]map[string]any)
+	}
+	s.failures = nil
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+
+	for i, f := range s.failures {
+		if f.method == r.Method && f.path == r.URL.Path {
+			s.failures = slices.Delete(s.failures, i, i+1)
+			writeError(w, f.status, "injected failure")
+			return
+		}
+	}
+
+	if r.URL.Path == sessionPath {
+		s.serveSession(w, r, body)
+		return
+	}
+
+	if !s.authorized(r.Header.Get(AuthHeaderKey)) {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	for _, res := range s.resources {
+		if r.URL.Path == res.path || strings.HasPrefix(r.URL.Path, res.path+"/") {
+			rest := strings.Trim(strings.TrimPrefix(r.URL.Path, res.path), "/")
+			var segments []string
+			if rest != "" {
+				segments = strings.Split(rest, "/")
+			}
+			s.serveResource(w, r, res, segments, body)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "unknown endpoint "+r.URL.Path)
+}
+
+// stamp returns a strictly increasing time in milliseconds so that every
+// update changes `lastUpdated`.
+func (s *Server) stamp() int64 {
+	now := max(s.now().UnixMilli(), s.lastStamp+1)
+	s.lastStamp = now
+	return now
+}
+
+func (s *Server) newID(path string) string {
+	for {
+		id := idtool.ID(rand.Int64()).String()
+		if _, exists := s.objects[path][id]; !exists {
+			return id
+		}
+	}
+}
+
+// sorted returns the objects of a collection ordered by creation.
+func (s *Server) sorted(path string) []map[string]any {
+	objs := make([]map[string]any, 0, len(s.objects[path]))
+	for _, obj := range s.objects[path] {
+		objs = append(objs, obj)
+	}
+	slices.SortFunc(objs, func(a, b map[string]any) int {
+		if c := compareNumber(a["created"], b["created"]); c != 0 {
+			return c
+		}
+		return strings.Compare(fmt.Sprint(a["id"]), fmt.Sprint(b["id"]))
+	})
+	return objs
+}
+
+func compareNumber(a, b any) int {
+	x, _ := a.(int64)
+	y, _ := b.(int64)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func decodeObject(body []byte) (map[string]any, error) {
+	obj := map[string]any{}
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", strconv.FormatUint(rand.Uint64(), 16))
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"code": status, "message": message})
+}
@@ -0,0 +1,196 @@
+package signalfxtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/signalfx/signalfx-go"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/sessiontoken"
+	"github.com/signalfx/signalfx-go/signalfxtest"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, options ...signalfxtest.Option) (*signalfxtest.Server, *signalfx.Client) {
+	t.Helper()
+
+	fake := signalfxtest.NewServer(options...)
+	t.Cleanup(fake.Close)
+
+	client, err := signalfx.NewClient("token", signalfx.APIUrl(fake.URL))
+	require.NoError(t, err, "Must create the client")
+	return fake, client
+}
+
+func TestDetectorLifecycle(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{
+		Name:        "cpu high",
+		ProgramText: "detect(when(data('cpu.utilization') > 90)).publish('CPU')",
+		Tags:        []string{"team:payments"},
+	})
+	require.NoError(t, err, "Must create the detector")
+	assert.Len(t, created.Id, 11, "Must assign a SignalFx style id")
+	assert.NotZero(t, created.LastUpdated, "Must stamp lastUpdated")
+	assert.Equal(t, signalfxtest.UserID, created.Creator, "Must set the creator")
+
+	fetched, err := client.GetDetector(ctx, created.Id)
+	require.NoError(t, err, "Must get the detector")
+	assert.Equal(t, created, fetched, "Must return the stored detector")
+
+	updated, err := client.UpdateDetector(ctx, created.Id, &detector.CreateUpdateDetectorRequest{Name: "cpu very high"})
+	require.NoError(t, err, "Must update the detector")
+	assert.Equal(t, "cpu very high", updated.Name, "Must replace the name")
+	assert.Greater(t, updated.LastUpdated, created.LastUpdated, "Must bump lastUpdated")
+	assert.Equal(t, created.Created, updated.Created, "Must keep the creation time")
+	assert.Empty(t, updated.Tags, "Must replace the whole object")
+
+	results, err := client.SearchDetectors(ctx, 10, "VERY", 0, "")
+	require.NoError(t, err, "Must search detectors")
+	assert.EqualValues(t, 1, results.Count, "Must find the detector by name")
+
+	require.NoError(t, client.DeleteDetector(ctx, created.Id), "Must delete the detector")
+
+	_, err = client.GetDetector(ctx, created.Id)
+	assert.True(t, errors.Is(err, signalfx.ErrNotFound), "Must return not found after delete, got %v", err)
+}
+
+func TestSearchFiltersAndPaging(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	for i, tags := range [][]string{{"prod"}, {"prod", "db"}, {"dev"}} {
+		_, err := client.CreateDetector(ctx, &detector.CreateUpdateDetectorRequest{
+			Name: "detector " + string(rune('a'+i)),
+			Tags: tags,
+		})
+		require.NoError(t, err, "Must create the detector")
+	}
+
+	results, err := client.SearchDetectors(ctx, 10, "", 0, "prod")
+	require.NoError(t, err, "Must search detectors")
+	assert.EqualValues(t, 2, results.Count, "Must filter on tags")
+
+	var names []string
+	for d, err := range client.AllDetectors(ctx, signalfx.SearchFilter{PageSize: 1}) {
+		require.NoError(t, err, "Must iterate detectors")
+		names = append(names, d.Name)
+	}
+	assert.Equal(t, []string{"detector a", "detector b", "detector c"}, names, "Must page in creation order")
+}
+
+func TestDashboardGroups(t *testing.T) {
+	t.Parallel()
+
+	fake, client := newClient(t)
+	ctx := context.Background()
+
+	group, err := client.CreateDashboardGroup(ctx, &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "payments"}, false)
+	require.NoError(t, err, "Must create the group")
+	require.Len(t, group.Dashboards, 1, "Must create the default dashboard")
+
+	empty, err := client.CreateDashboardGroup(ctx, &dashboard_group.CreateUpdateDashboardGroupRequest{Name: "empty"}, true)
+	require.NoError(t, err, "Must create the group")
+	assert.Empty(t, empty.Dashboards, "Must not create a dashboard")
+
+	dash, err := client.CreateDashboard(ctx, &dashboard.CreateUpdateDashboardRequest{Name: "latency", GroupId: empty.Id})
+	require.NoError(t, err, "Must create the dashboard")
+
+	empty, err = client.GetDashboardGroup(ctx, empty.Id)
+	require.NoError(t, err, "Must get the group")
+	assert.Equal(t, []string{dash.Id}, empty.Dashboards, "Must link the dashboard to its group")
+
+	require.NoError(t, client.DeleteDashboardGroup(ctx, group.Id), "Must delete the group")
+	assert.Len(t, fake.Objects("/v2/dashboard"), 1, "Must delete the dashboards of the group")
+}
+
+func TestTeamsAndTokens(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	tm, err := client.CreateTeam(ctx, &team.CreateUpdateTeamRequest{Name: "payments"})
+	require.NoError(t, err, "Must create the team")
+	require.NoError(t, client.LinkDetectorToTeam(ctx, tm.Id, "DETECTOR"), "Must link the detector")
+
+	tm, err = client.GetTeam(ctx, tm.Id)
+	require.NoError(t, err, "Must get the team")
+	assert.Equal(t, []string{"DETECTOR"}, tm.Detectors, "Must record the linked detector")
+
+	token, err := client.CreateOrgToken(ctx, &orgtoken.CreateUpdateTokenRequest{Name: "ingest token"})
+	require.NoError(t, err, "Must create the token")
+	assert.NotEmpty(t, token.Secret, "Must generate a secret")
+
+	_, err = client.CreateOrgToken(ctx, &orgtoken.CreateUpdateTokenRequest{Name: "ingest token"})
+	assert.True(t, errors.Is(err, signalfx.ErrConflict), "Must reject duplicated names, got %v", err)
+
+	fetched, err := client.GetOrgToken(ctx, "ingest token")
+	require.NoError(t, err, "Must get the token by name")
+	assert.Equal(t, token.Secret, fetched.Secret, "Must keep the secret")
+}
+
+func TestIntegrations(t *testing.T) {
+	t.Parallel()
+
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreatePagerDutyIntegration(ctx, &integration.PagerDutyIntegration{
+		Name:    "on call",
+		Type:    "PagerDuty",
+		Enabled: true,
+		ApiKey:  "secret",
+	})
+	require.NoError(t, err, "Must create the integration")
+
+	found, err := client.GetPagerDutyIntegrationByName(ctx, "on call")
+	require.NoError(t, err, "Must search the integration")
+	require.NotNil(t, found, "Must find the integration")
+	assert.Equal(t, created.Id, found.Id, "Must find the created integration")
+}
+
+func TestAuthenticationAndFailures(t *testing.T) {
+	t.Parallel()
+
+	fake, client := newClient(t, signalfxtest.Token("token"))
+	ctx := context.Background()
+
+	other, _ := signalfx.NewClient("wrong", signalfx.APIUrl(fake.URL))
+	_, err := other.GetDetector(ctx, "ABC")
+	assert.True(t, errors.Is(err, signalfx.ErrUnauthorized), "Must reject other tokens, got %v", err)
+
+	session, err := other.CreateSessionToken(ctx, &sessiontoken.CreateTokenRequest{Email: "a@b.c", Password: "pw"})
+	require.NoError(t, err, "Must create a session")
+	assert.Greater(t, session.ExpiryMs, time.Now().UnixMilli(), "Must expire in the future")
+
+	withSession, _ := signalfx.NewClient(session.AccessToken, signalfx.APIUrl(fake.URL))
+	_, err = withSession.SearchDetectors(ctx, 10, "", 0, "")
+	assert.NoError(t, err, "Must accept session tokens")
+
+	fake.FailNext(http.MethodGet, "/v2/detector", http.StatusServiceUnavailable)
+	_, err = client.SearchDetectors(ctx, 10, "", 0, "")
+	re, ok := signalfx.AsResponseError(err)
+	require.True(t, ok, "Must return a response error")
+	assert.Equal(t, http.StatusServiceUnavailable, re.Code(), "Must use the injected status")
+	assert.NotEmpty(t, re.RequestID(), "Must send a request id")
+
+	_, err = client.SearchDetectors(ctx, 10, "", 0, "")
+	assert.NoError(t, err, "Must only fail once")
+	assert.Len(t, fake.Requests(), 5, "Must record every request")
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/signalfx/signalfx-go/slo"
 )
@@ -34,6 +36,29 @@ func (c *Client) DeleteSlo(ctx context.Context, id string) error {
 	return err
 }
 
+// SearchSlos searches for SLOs given a query string in `name`.
+func (c *Client) SearchSlos(ctx context.Context, limit int, name string, offset int) (*slo.SearchResults, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(limit))
+	params.Add("name", name)
+	params.Add("offset", strconv.Itoa(offset))
+
+	resp, err := c.doRequest(ctx, http.MethodGet, SloAPIURL, params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err = newResponseError(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	results := &slo.SearchResults{}
+	err = json.NewDecoder(resp.Body).Decode(results)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return results, err
+}
+
 func (c *Client) executeSloRequest(ctx context.Context, url string, method string, expectedValidStatus int, sloRequest *slo.SloObject) (*slo.SloObject, error) {
 	var body io.Reader
 
@@ -0,0 +1,8 @@
+package slo
+
+type SearchResults struct {
+	// Number of SLOs that match the search query.
+	Count int32 `json:"count,omitempty"`
+	// The SLOs that match the request criteria.
+	Results []SloObject `json:"results,omitempty"`
+}
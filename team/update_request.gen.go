@@ -0,0 +1,20 @@
+// Code generated by requestgen. DO NOT EDIT.
+
+package team
+
+// ToUpdateRequest returns the request updating the Team to its current state,
+// without the fields the request cannot set: Id. The request shares the slices,
+// maps and pointers of o.
+func (o *Team) ToUpdateRequest() *CreateUpdateTeamRequest {
+	if o == nil {
+		return nil
+	}
+	return &CreateUpdateTeamRequest{
+		Name:              o.Name,
+		Description:       o.Description,
+		Members:           o.Members,
+		NotificationLists: o.NotificationLists,
+		DashboardGroups:   o.DashboardGroups,
+		Detectors:         o.Detectors,
+	}
+}
@@ -0,0 +1,231 @@
+package terraform
+
+import (
+	"fmt"
+
+	"github.com/signalfx/signalfx-go/chart"
+)
+
+// fullPaletteColors are the colors of the color scales of the provider,
+// indexed by the palette index of the API.
+var fullPaletteColors = []string{
+	"gray", "blue", "light_blue", "navy", "dark_orange", "orange", "dark_yellow",
+	"magenta", "cerise", "pink", "violet", "purple", "gray_blue", "dark_green",
+	"green", "aquamarine", "red", "yellow", "vivid_yellow", "light_green",
+	"lime_green",
+}
+
+// chartTypes are the resource types of the types of chart.
+var chartTypes = map[string]string{
+	"TimeSeriesChart": "signalfx_time_chart",
+	"SingleValue":     "signalfx_single_value_chart",
+	"List":            "signalfx_list_chart",
+	"Heatmap":         "signalfx_heatmap_chart",
+	"TableChart":      "signalfx_table_chart",
+	"Text":            "signalfx_text_chart",
+	"Event":           "signalfx_event_feed_chart",
+	"LogsChart":       "signalfx_log_view",
+}
+
+// AddChart adds a chart, as the resource of its type. Charts of an SLO are
+// signalfx_slo_chart resources.
+func (g *Generator) AddChart(c *chart.Chart) error {
+	if c.SloId != "" {
+		r := newResource("signalfx_slo_chart", c.Id)
+		r.str("slo_id", c.SloId)
+		g.add(r, c.Name, "slo_chart")
+		return nil
+	}
+	o := c.Options
+	if o == nil {
+		o = &chart.Options{}
+	}
+	typ, ok := chartTypes[o.Type]
+	if !ok {
+		return fmt.Errorf("chart %s of type %q: %w", c.Id, o.Type, ErrUnsupported)
+	}
+	r := newResource(typ, c.Id)
+	r.str("name", c.Name)
+	r.str("description", c.Description)
+	if o.Type == "Text" {
+		r.str("markdown", o.Markdown)
+		g.add(r, c.Name, "chart")
+		return nil
+	}
+	r.str("program_text", c.ProgramText)
+
+	switch o.Type {
+	case "TimeSeriesChart":
+		r.str("plot_type", o.DefaultPlotType)
+		r.str("unit_prefix", o.UnitPrefix)
+		r.str("color_by", o.ColorBy)
+		setProgramOptions(&r.body, o.ProgramOptions)
+		setChartTime(&r.body, o.Time)
+		if o.AxisPrecision != nil {
+			r.int("axes_precision", int64(*o.AxisPrecision))
+		}
+		r.bool("axes_include_zero", o.IncludeZero)
+		r.bool("stacked", o.Stacked)
+		r.bool("show_event_lines", o.ShowEventLines)
+		r.bool("show_data_markers", (o.LineChartOptions != nil && o.LineChartOptions.ShowDataMarkers) ||
+			(o.AreaChartOptions != nil && o.AreaChartOptions.ShowDataMarkers))
+		if l := o.OnChartLegendOptions; l != nil && l.ShowLegend {
+			r.str("on_chart_legend_dimension", l.DimensionInLegend)
+		}
+		setLegendFields(&r.body, o.LegendOptions)
+		for i, name := range []string{"axis_left", "axis_right"} {
+			if i < len(o.Axes) && o.Axes[i] != nil {
+				a := o.Axes[i]
+				axis := r.block(name)
+				axis.str("label", a.Label)
+				axis.float("min_value", a.Min)
+				axis.float("max_value", a.Max)
+				axis.float("high_watermark", a.HighWatermark)
+				axis.str("high_watermark_label", a.HighWatermarkLabel)
+				axis.float("low_watermark", a.LowWatermark)
+				axis.str("low_watermark_label", a.LowWatermarkLabel)
+			}
+		}
+		for _, p := range o.PublishLabelOptions {
+			viz := setVizOptions(&r.body, p)
+			if p.YAxis == 1 {
+				viz.str("axis", "right")
+			}
+			viz.str("plot_type", p.PlotType)
+		}
+		for _, e := range o.EventPublishLabelOptions {
+			event := r.block("event_options")
+			event.str("label", e.Label)
+			event.str("display_name", e.DisplayName)
+			event.str("color", vizColor(e.PaletteIndex))
+		}
+	case "SingleValue", "List":
+		r.str("unit_prefix", o.UnitPrefix)
+		r.str("color_by", o.ColorBy)
+		setProgramOptions(&r.body, o.ProgramOptions)
+		if o.MaximumPrecision != nil {
+			r.int("max_precision", int64(*o.MaximumPrecision))
+		}
+		setRefreshInterval(&r.body, o.RefreshInterval)
+		r.str("secondary_visualization", o.SecondaryVisualization)
+		if o.Type == "SingleValue" {
+			r.bool("show_spark_line", o.ShowSparkLine)
+			r.bool("is_timestamp_hidden", o.TimestampHidden)
+		} else {
+			r.str("sort_by", o.SortBy)
+			r.bool("hide_missing_values", o.HideMissingValues)
+			setChartTime(&r.body, o.Time)
+			setLegendFields(&r.body, o.LegendOptions)
+		}
+		for _, p := range o.PublishLabelOptions {
+			setVizOptions(&r.body, p)
+		}
+		setColorScale(&r.body, o.ColorScale2)
+	case "Heatmap":
+		r.str("unit_prefix", o.UnitPrefix)
+		setProgramOptions(&r.body, o.ProgramOptions)
+		setRefreshInterval(&r.body, o.RefreshInterval)
+		r.strs("group_by", o.GroupBy)
+		r.str("sort_by", o.SortBy)
+		r.bool("hide_timestamp", o.TimestampHidden)
+		if c := o.ColorRange; c != nil {
+			colorRange := r.block("color_range")
+			colorRange.float("min_value", &c.Min)
+			colorRange.float("max_value", &c.Max)
+			colorRange.str("color", c.Color)
+		}
+		setColorScale(&r.body, o.ColorScale2)
+	case "TableChart":
+		r.str("unit_prefix", o.UnitPrefix)
+		setProgramOptions(&r.body, o.ProgramOptions)
+		setRefreshInterval(&r.body, o.RefreshInterval)
+		if o.MaximumPrecision != nil {
+			r.int("max_precision", int64(*o.MaximumPrecision))
+		}
+		r.strs("group_by", o.GroupBy)
+		r.bool("hide_timestamp", o.TimestampHidden)
+		for _, p := range o.PublishLabelOptions {
+			setVizOptions(&r.body, p)
+		}
+	case "Event":
+		setChartTime(&r.body, o.Time)
+	case "LogsChart":
+		setChartTime(&r.body, o.Time)
+		r.str("default_connection", o.DefaultConnection)
+		for _, c := range o.Columns {
+			r.block("columns").str("name", c.Name)
+		}
+		for _, s := range o.SortOptions {
+			sort := r.block("sort_options")
+			sort.str("field", s.Field)
+			sort.set("descending", literal(fmt.Sprint(s.Descending)))
+		}
+	}
+	g.add(r, c.Name, "chart")
+	return nil
+}
+
+// setProgramOptions sets the options of the program of a chart, whose
+// durations are in seconds rather than the milliseconds of the API.
+func setProgramOptions(b *body, o *chart.GeneralOptions) {
+	if o == nil {
+		return
+	}
+	if o.MinimumResolution != nil {
+		b.int("minimum_resolution", int64(*o.MinimumResolution)/1000)
+	}
+	if o.MaxDelay != nil {
+		b.int("max_delay", int64(*o.MaxDelay)/1000)
+	}
+	b.str("timezone", o.Timezone)
+	b.bool("disable_sampling", o.DisableSampling)
+}
+
+func setChartTime(b *body, t *chart.TimeDisplayOptions) {
+	if t != nil {
+		setTime(b, t.Type, t.Range, t.Start, t.End)
+	}
+}
+
+func setRefreshInterval(b *body, interval *int32) {
+	if interval != nil {
+		b.int("refresh_interval", int64(*interval)/1000)
+	}
+}
+
+func setLegendFields(b *body, o *chart.DataTableOptions) {
+	if o == nil {
+		return
+	}
+	for _, f := range o.Fields {
+		field := b.block("legend_options_fields")
+		field.str("property", f.Property)
+		field.set("enabled", literal(fmt.Sprint(f.Enabled)))
+	}
+}
+
+// setVizOptions adds the visualization options of a published label.
+func setVizOptions(b *body, p *chart.PublishLabelOptions) *block {
+	viz := b.block("viz_options")
+	viz.str("label", p.Label)
+	viz.str("display_name", p.DisplayName)
+	viz.str("color", vizColor(p.PaletteIndex))
+	viz.str("value_unit", p.ValueUnit)
+	viz.str("value_prefix", p.ValuePrefix)
+	viz.str("value_suffix", p.ValueSuffix)
+	return viz
+}
+
+// setColorScale adds the color scale of a chart colored by its values.
+func setColorScale(b *body, scale []*chart.SecondaryVisualization) {
+	for _, s := range scale {
+		color := b.block("color_scale")
+		color.float("gt", s.Gt)
+		color.float("gte", s.Gte)
+		color.float("lt", s.Lt)
+		color.float("lte", s.Lte)
+		if p := s.PaletteIndex; p != nil && *p >= 0 && int(*p) < len(fullPaletteColors) {
+			color.str("color", fullPaletteColors[*p])
+		}
+	}
+}
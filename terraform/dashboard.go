@@ -0,0 +1,123 @@
+package terraform
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+)
+
+// AddDashboard adds a dashboard, whose charts and group refer to the charts
+// and dashboard groups added to the generator.
+func (g *Generator) AddDashboard(d *dashboard.Dashboard) error {
+	r := newResource("signalfx_dashboard", d.Id)
+	r.str("name", d.Name)
+	r.str("description", d.Description)
+	if d.GroupId != "" {
+		r.set("dashboard_group", ref(d.GroupId))
+	}
+	if d.ChartDensity != nil {
+		r.str("charts_resolution", strings.ToLower(string(*d.ChartDensity)))
+	}
+	if d.MaxDelayOverride != nil {
+		r.int("max_delay_override", int64(*d.MaxDelayOverride)/1000)
+	}
+	r.strs("tags", d.Tags)
+	if w := d.AuthorizedWriters; w != nil {
+		r.refs("authorized_writer_teams", w.Teams)
+		r.strs("authorized_writer_users", w.Users)
+	}
+	if f := d.Filters; f != nil {
+		if t := f.Time; t != nil {
+			setDashboardTime(&r.body, string(t.Start), string(t.End))
+		}
+	}
+	for _, c := range d.Charts {
+		b := r.block("chart")
+		b.set("chart_id", ref(c.ChartId))
+		b.set("row", literal(strconv.Itoa(int(c.Row))))
+		b.set("column", literal(strconv.Itoa(int(c.Column))))
+		b.set("width", literal(strconv.Itoa(int(c.Width))))
+		b.set("height", literal(strconv.Itoa(int(c.Height))))
+	}
+	if f := d.Filters; f != nil {
+		for _, v := range f.Variables {
+			b := r.block("variable")
+			b.str("property", v.Property)
+			b.str("alias", v.Alias)
+			b.str("description", v.Description)
+			b.strs("values", v.Value)
+			b.bool("value_required", v.Required)
+			b.strs("values_suggested", v.PreferredSuggestions)
+			b.bool("restricted_suggestions", v.Restricted)
+			b.bool("replace_only", v.ReplaceOnly)
+			b.bool("apply_if_exist", v.ApplyIfExists)
+		}
+		for _, s := range f.Sources {
+			b := r.block("filter")
+			b.str("property", s.Property)
+			b.strs("values", s.Value)
+			b.bool("negated", s.NOT)
+			b.bool("apply_if_exist", s.ApplyIfExists)
+		}
+	}
+	for _, o := range d.EventOverlays {
+		b := r.block("event_overlay")
+		b.str("label", o.Label)
+		b.bool("line", o.EventLine)
+		b.str("color", vizColor(o.EventColorIndex))
+		setEventSignal(&b.body, o)
+	}
+	for _, o := range d.SelectedEventOverlays {
+		setEventSignal(&r.block("selected_event_overlay").body, o)
+	}
+	g.add(r, d.Name, "dashboard")
+	return nil
+}
+
+// setDashboardTime sets the time range of a dashboard, which the API holds
+// either as a relative start, such as "-1h", or as timestamps in
+// milliseconds.
+func setDashboardTime(b *body, start, end string) {
+	if strings.HasPrefix(start, "-") {
+		b.str("time_range", start)
+		return
+	}
+	if ms, err := strconv.ParseInt(start, 10, 64); err == nil {
+		b.int("start_time", ms/1000)
+	}
+	if ms, err := strconv.ParseInt(end, 10, 64); err == nil {
+		b.int("end_time", ms/1000)
+	}
+}
+
+// setEventSignal sets the signal of an event overlay and its filters.
+func setEventSignal(b *body, o *dashboard.ChartEventOverlay) {
+	if s := o.EventSignal; s != nil {
+		b.str("signal", s.EventSearchText)
+		b.str("type", s.EventType)
+	}
+	for _, f := range o.Sources {
+		source := b.block("source")
+		source.str("property", f.Property)
+		source.strs("values", f.Value)
+		source.bool("negated", f.NOT)
+	}
+}
+
+// AddDashboardGroup adds a dashboard group, whose teams refer to the teams
+// added to the generator. Its dashboards are added with AddDashboard;
+// mirrored dashboards are left out.
+func (g *Generator) AddDashboardGroup(dg *dashboard_group.DashboardGroup) error {
+	r := newResource("signalfx_dashboard_group", dg.Id)
+	r.str("name", dg.Name)
+	r.str("description", dg.Description)
+	r.refs("teams", dg.Teams)
+	if w := dg.AuthorizedWriters; w != nil {
+		r.refs("authorized_writer_teams", w.Teams)
+		r.strs("authorized_writer_users", w.Users)
+	}
+	g.add(r, dg.Name, "dashboard_group")
+	return nil
+}
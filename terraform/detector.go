@@ -0,0 +1,175 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/notification"
+)
+
+// vizColors are the colors of the provider, indexed by the palette index
+// of the API.
+var vizColors = []string{
+	"gray", "blue", "azure", "navy", "brown", "orange", "yellow", "magenta",
+	"purple", "pink", "violet", "lilac", "iris", "emerald", "green", "aquamarine",
+}
+
+// vizColor returns the color of a palette index, or "" if it has none.
+func vizColor(index *int32) string {
+	if index == nil || *index < 0 || int(*index) >= len(vizColors) {
+		return ""
+	}
+	return vizColors[*index]
+}
+
+// AddDetector adds a detector, whose teams and notifications refer to the
+// teams and integrations added to the generator.
+func (g *Generator) AddDetector(d *detector.Detector) error {
+	r := newResource("signalfx_detector", d.Id)
+	r.str("name", d.Name)
+	r.str("description", d.Description)
+	r.str("program_text", d.ProgramText)
+	if d.MaxDelay != nil {
+		r.int("max_delay", int64(*d.MaxDelay)/1000)
+	}
+	if d.MinDelay != nil {
+		r.int("min_delay", int64(*d.MinDelay)/1000)
+	}
+	r.str("timezone", d.TimeZone)
+	r.strs("tags", d.Tags)
+	r.refs("teams", d.Teams)
+	if w := d.AuthorizedWriters; w != nil {
+		r.refs("authorized_writer_teams", w.Teams)
+		r.strs("authorized_writer_users", w.Users)
+	}
+	if v := d.VisualizationOptions; v != nil {
+		r.bool("show_data_markers", v.ShowDataMarkers)
+		r.bool("show_event_lines", v.ShowEventLines)
+		r.bool("disable_sampling", v.DisableSampling)
+		if t := v.Time; t != nil {
+			setTime(&r.body, t.Type, t.Range, t.Start, t.End)
+		}
+		for _, o := range v.PublishLabelOptions {
+			viz := r.block("viz_options")
+			viz.str("label", o.Label)
+			viz.str("display_name", o.DisplayName)
+			viz.str("color", vizColor(o.PaletteIndex))
+			viz.str("value_unit", o.ValueUnit)
+			viz.str("value_prefix", o.ValuePrefix)
+			viz.str("value_suffix", o.ValueSuffix)
+		}
+	}
+	for _, rule := range d.Rules {
+		b := r.block("rule")
+		b.str("detect_label", rule.DetectLabel)
+		b.str("severity", string(rule.Severity))
+		b.str("description", rule.Description)
+		b.bool("disabled", rule.Disabled)
+		if err := notificationList(rule.Notifications).set(&b.body, "notifications"); err != nil {
+			return fmt.Errorf("detector %s: %w", d.Id, err)
+		}
+		b.str("parameterized_subject", rule.ParameterizedSubject)
+		b.str("parameterized_body", rule.ParameterizedBody)
+		b.str("runbook_url", rule.RunbookUrl)
+		b.str("tip", rule.Tip)
+		if rn := rule.ReminderNotification; rn != nil {
+			reminder := b.block("reminder_notification")
+			reminder.int("interval_ms", rn.IntervalMs)
+			reminder.int("timeout_ms", rn.TimeoutMs)
+			reminder.str("type", rn.Type)
+		}
+	}
+	g.add(r, d.Name, "detector")
+	return nil
+}
+
+// setTime sets the time range of a chart or detector, relative or
+// absolute, in seconds rather than the milliseconds of the API.
+func setTime(b *body, typ string, rng, start, end *int64) {
+	switch typ {
+	case "relative":
+		if rng != nil {
+			b.int("time_range", *rng/1000)
+		}
+	case "absolute":
+		if start != nil {
+			b.int("start_time", *start/1000)
+		}
+		if end != nil {
+			b.int("end_time", *end/1000)
+		}
+	}
+}
+
+// notificationList is a list of notifications of a rule or a team.
+type notificationList []*notification.Notification
+
+// set sets an attribute to the notifications, written as strings.
+func (l notificationList) set(b *body, name string) error {
+	if len(l) == 0 {
+		return nil
+	}
+	values := make(list, len(l))
+	for i, n := range l {
+		v, err := notificationString(n)
+		if err != nil {
+			return err
+		}
+		values[i] = v
+	}
+	b.set(name, values)
+	return nil
+}
+
+// notificationFields holds the fields of all the types of notification.
+type notificationFields struct {
+	Type          string `json:"type"`
+	CredentialId  string `json:"credentialId"`
+	Channel       string `json:"channel"`
+	Email         string `json:"email"`
+	Team          string `json:"team"`
+	ResponderName string `json:"responderName"`
+	ResponderId   string `json:"responderId"`
+	ResponderType string `json:"responderType"`
+	RoutingKey    string `json:"routingKey"`
+	Secret        string `json:"secret"`
+	Url           string `json:"url"`
+}
+
+// notificationString returns a notification as the string of the provider,
+// such as "Slack,<credential>,<channel>".
+func notificationString(n *notification.Notification) (joined, error) {
+	// The value of a notification may be a pointer or not, so it is read
+	// through JSON.
+	data, err := json.Marshal(n.Value)
+	if err != nil {
+		return nil, err
+	}
+	var f notificationFields
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	typ := n.Type
+	if typ == "" {
+		typ = f.Type
+	}
+	s := joined{str(typ)}
+	switch typ {
+	case "Email":
+		return append(s, str(f.Email)), nil
+	case "Team", "TeamEmail":
+		return append(s, ref(f.Team)), nil
+	case "Slack":
+		return append(s, ref(f.CredentialId), str(f.Channel)), nil
+	case "Opsgenie":
+		return append(s, ref(f.CredentialId), str(f.ResponderName), str(f.ResponderId), str(f.ResponderType)), nil
+	case "VictorOps":
+		return append(s, ref(f.CredentialId), str(f.RoutingKey)), nil
+	case "Webhook":
+		return append(s, ref(f.CredentialId), str(f.Secret), str(f.Url)), nil
+	case "AmazonEventBridge", "BigPanda", "Jira", "Office365", "PagerDuty", "ServiceNow", "SplunkPlatform", "XMatters":
+		return append(s, ref(f.CredentialId)), nil
+	}
+	return nil, fmt.Errorf("notification type %q: %w", typ, ErrUnsupported)
+}
@@ -0,0 +1,222 @@
+package terraform
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expr is an HCL expression. Expressions are written once all the objects
+// are added, so that references resolve to the resources of the
+// configuration whatever the order the objects were added in.
+type expr interface {
+	hcl(g *Generator) string
+}
+
+// literal is an expression written as is, such as a number or a variable.
+type literal string
+
+func (l literal) hcl(*Generator) string { return string(l) }
+
+// str is a string, written as a heredoc when it spans several lines.
+type str string
+
+func (s str) hcl(*Generator) string {
+	v := string(s)
+	if !strings.Contains(v, "\n") || strings.Contains(v, "\r") {
+		return `"` + escape(v) + `"`
+	}
+	delim := "EOT"
+	for lines := strings.Split(v, "\n"); slices.Contains(lines, delim); {
+		delim += "_"
+	}
+	text := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(v)
+	// Heredocs end with a newline, which chomp removes from the strings
+	// that do not.
+	if strings.HasSuffix(text, "\n") {
+		return "<<" + delim + "\n" + text + delim
+	}
+	return "chomp(<<" + delim + "\n" + text + "\n" + delim + "\n)"
+}
+
+// ref is the ID of an object, written as a reference to its resource when
+// it is part of the configuration.
+type ref string
+
+func (r ref) hcl(g *Generator) string {
+	if addr, ok := g.address(string(r)); ok {
+		return addr + ".id"
+	}
+	return `"` + escape(string(r)) + `"`
+}
+
+// joined is a string joining strings and references with commas, the way
+// the provider writes notifications.
+type joined []expr
+
+func (j joined) hcl(g *Generator) string {
+	parts := make([]string, len(j))
+	for i, e := range j {
+		switch e := e.(type) {
+		case ref:
+			if addr, ok := g.address(string(e)); ok {
+				parts[i] = "${" + addr + ".id}"
+			} else {
+				parts[i] = escape(string(e))
+			}
+		case str:
+			parts[i] = escape(string(e))
+		default:
+			panic(fmt.Sprintf("unexpected %T in a joined string", e))
+		}
+	}
+	return `"` + strings.Join(parts, ",") + `"`
+}
+
+// list is a list of expressions.
+type list []expr
+
+func (l list) hcl(g *Generator) string {
+	items := make([]string, len(l))
+	for i, e := range l {
+		items[i] = e.hcl(g)
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// escape escapes a string for a quoted HCL template.
+func escape(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			// Doubled, so that it is not the start of an interpolation.
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// body is the content of a block: attributes and nested blocks, written in
+// the order they are added. Its methods skip zero values, which are the
+// defaults of the provider.
+type body struct {
+	items []item
+}
+
+// item is an attribute, when value is set, or a nested block.
+type item struct {
+	name  string
+	value expr
+	block *block
+}
+
+// block is a block, such as a resource.
+type block struct {
+	typ    string
+	labels []string
+	body
+}
+
+func (b *body) set(name string, value expr) {
+	b.items = append(b.items, item{name: name, value: value})
+}
+
+func (b *body) str(name, value string) {
+	if value != "" {
+		b.set(name, str(value))
+	}
+}
+
+func (b *body) bool(name string, value bool) {
+	if value {
+		b.set(name, literal("true"))
+	}
+}
+
+func (b *body) int(name string, value int64) {
+	if value != 0 {
+		b.set(name, literal(strconv.FormatInt(value, 10)))
+	}
+}
+
+func (b *body) float(name string, value *float64) {
+	if value != nil {
+		b.set(name, number(*value))
+	}
+}
+
+func (b *body) strs(name string, values []string) {
+	if len(values) > 0 {
+		l := make(list, len(values))
+		for i, v := range values {
+			l[i] = str(v)
+		}
+		b.set(name, l)
+	}
+}
+
+func (b *body) refs(name string, ids []string) {
+	if len(ids) > 0 {
+		l := make(list, len(ids))
+		for i, id := range ids {
+			l[i] = ref(id)
+		}
+		b.set(name, l)
+	}
+}
+
+// block adds a nested block.
+func (b *body) block(typ string, labels ...string) *block {
+	nested := &block{typ: typ, labels: labels}
+	b.items = append(b.items, item{block: nested})
+	return nested
+}
+
+// number is a float written the shortest way.
+func number(f float64) literal {
+	return literal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// write writes the block at the indentation level of depth, aligning the
+// equal signs of consecutive attributes like terraform fmt.
+func (b *block) write(sb *strings.Builder, g *Generator, depth int) {
+	indent := strings.Repeat("  ", depth)
+	sb.WriteString(indent + b.typ)
+	for _, l := range b.labels {
+		sb.WriteString(` "` + escape(l) + `"`)
+	}
+	sb.WriteString(" {\n")
+	for i := 0; i < len(b.items); {
+		if b.items[i].block != nil {
+			b.items[i].block.write(sb, g, depth+1)
+			i++
+			continue
+		}
+		end, width := i, 0
+		for ; end < len(b.items) && b.items[end].block == nil; end++ {
+			width = max(width, len(b.items[end].name))
+		}
+		for _, it := range b.items[i:end] {
+			fmt.Fprintf(sb, "%s  %-*s = %s\n", indent, width, it.name, it.value.hcl(g))
+		}
+		i = end
+	}
+	sb.WriteString(indent + "}\n")
+}
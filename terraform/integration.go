@@ -0,0 +1,227 @@
+package terraform
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/signalfx/signalfx-go/integration"
+)
+
+// AddIntegration adds an integration, as returned by the getter of its
+// type, such as GetPagerDutyIntegration. The secrets of the integration
+// become sensitive variables.
+//
+// AWS integrations are two resources: signalfx_aws_external_integration or
+// signalfx_aws_token_integration, depending on their authentication, and
+// the signalfx_aws_integration configuring them.
+func (g *Generator) AddIntegration(in any) error {
+	switch in := in.(type) {
+	case *integration.PagerDutyIntegration:
+		r := g.integration("signalfx_pagerduty_integration", in.Id, in.Name, in.Enabled)
+		r.set("api_key", g.secret(r, "api_key"))
+	case *integration.SlackIntegration:
+		r := g.integration("signalfx_slack_integration", in.Id, in.Name, in.Enabled)
+		r.set("webhook_url", g.secret(r, "webhook_url"))
+	case *integration.OpsgenieIntegration:
+		r := g.integration("signalfx_opsgenie_integration", in.Id, in.Name, in.Enabled)
+		r.set("api_key", g.secret(r, "api_key"))
+		r.str("api_url", in.ApiUrl)
+	case *integration.VictorOpsIntegration:
+		r := g.integration("signalfx_victor_ops_integration", in.Id, in.Name, in.Enabled)
+		// The URL holds the key of the integration.
+		r.set("post_url", g.secret(r, "post_url"))
+	case *integration.WebhookIntegration:
+		r := g.integration("signalfx_webhook_integration", in.Id, in.Name, in.Enabled)
+		r.str("url", in.Url)
+		r.set("shared_secret", g.secret(r, "shared_secret"))
+		r.str("method", in.Method)
+		r.str("payload_template", in.PayloadTemplate)
+		keys := make([]string, 0, len(in.Headers))
+		for k := range in.Headers {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			header := r.block("headers")
+			header.str("header_key", k)
+			header.str("header_value", fmt.Sprint(in.Headers[k]))
+		}
+	case *integration.JiraIntegration:
+		r := g.integration("signalfx_jira_integration", in.Id, in.Name, in.Enabled)
+		r.str("auth_method", in.AuthMethod)
+		if in.AuthMethod == "UsernameAndPassword" {
+			r.str("username", in.Username)
+			r.set("password", g.secret(r, "password"))
+		} else {
+			r.str("user_email", in.UserEmail)
+			r.set("api_token", g.secret(r, "api_token"))
+		}
+		r.str("base_url", in.BaseURL)
+		r.str("issue_type", in.IssueType)
+		r.str("project_key", in.ProjectKey)
+		if a := in.Assignee; a != nil {
+			r.str("assignee_name", a.Name)
+			r.str("assignee_display_name", a.DisplayName)
+		}
+	case *integration.ServiceNowIntegration:
+		r := g.integration("signalfx_service_now_integration", in.Id, in.Name, in.Enabled)
+		r.str("instance_name", in.InstanceName)
+		r.str("issue_type", in.IssueType)
+		r.str("username", in.Username)
+		r.set("password", g.secret(r, "password"))
+		r.str("alert_triggered_payload_template", in.AlertTriggeredPayloadTemplate)
+		r.str("alert_resolved_payload_template", in.AlertResolvedPayloadTemplate)
+	case *integration.GCPIntegration:
+		r := g.integration("signalfx_gcp_integration", in.Id, in.Name, in.Enabled)
+		r.str("auth_method", string(in.AuthMethod))
+		r.int("poll_rate", in.PollRateMs/1000)
+		r.strs("services", stringsOf(in.Services))
+		r.strs("custom_metric_type_domains", in.CustomMetricTypeDomains)
+		r.strs("include_list", in.IncludeList)
+		r.strs("exclude_gce_instances_with_labels", in.ExcludeGCEInstancesWithLabels)
+		r.bool("use_metric_source_project_for_quota", in.UseMetricSourceProjectForQuota)
+		if in.ImportGCPMetrics != nil {
+			r.set("import_gcp_metrics", literal(fmt.Sprint(*in.ImportGCPMetrics)))
+		}
+		r.str("named_token", in.NamedToken)
+		r.str("workload_identity_federation_config", in.WorkloadIdentityFederationConfig)
+		for _, p := range in.ProjectServiceKeys {
+			key := r.block("project_service_keys")
+			key.str("project_id", p.ProjectId)
+			key.set("project_key", g.secret(r, "project_key_"+identifier(p.ProjectId, "project")))
+		}
+		for _, p := range in.WifConfigs {
+			config := r.block("project_wif_configs")
+			config.str("project_id", p.ProjectId)
+			config.str("wif_config", p.WIFConfig)
+		}
+	case *integration.AzureIntegration:
+		r := g.integration("signalfx_azure_integration", in.Id, in.Name, in.Enabled)
+		r.str("environment", strings.ToLower(string(in.AzureEnvironment)))
+		r.str("tenant_id", in.TenantId)
+		r.str("app_id", in.AppId)
+		r.set("secret_key", g.secret(r, "secret_key"))
+		r.int("poll_rate", in.PollRateMs/1000)
+		r.strs("services", stringsOf(in.Services))
+		r.strs("additional_services", in.AdditionalServices)
+		r.strs("subscriptions", in.Subscriptions)
+		r.bool("sync_guest_os_namespaces", in.SyncGuestOsNamespaces)
+		if in.ImportAzureMonitor != nil {
+			r.set("import_azure_monitor", literal(fmt.Sprint(*in.ImportAzureMonitor)))
+		}
+		if in.UseBatchApi != nil {
+			r.set("use_batch_api", literal(fmt.Sprint(*in.UseBatchApi)))
+		}
+		r.str("named_token", in.NamedToken)
+		services := make([]string, 0, len(in.CustomNamespacesPerService))
+		for s := range in.CustomNamespacesPerService {
+			services = append(services, s)
+		}
+		slices.Sort(services)
+		for _, s := range services {
+			custom := r.block("custom_namespaces_per_service")
+			custom.str("service", s)
+			custom.strs("namespaces", in.CustomNamespacesPerService[s])
+		}
+		for _, f := range in.ResourceFilterRules {
+			r.block("resource_filter_rules").str("filter_source", f.Filter.Source)
+		}
+	case *integration.AwsCloudWatchIntegration:
+		g.addAWS(in)
+	default:
+		return fmt.Errorf("integration %T: %w", in, ErrUnsupported)
+	}
+	return nil
+}
+
+// integration adds the resource of an integration, with the attributes all
+// the integrations have.
+func (g *Generator) integration(typ, id, name string, enabled bool) *resource {
+	r := newResource(typ, id)
+	g.add(r, name, "integration")
+	r.str("name", name)
+	r.set("enabled", literal(fmt.Sprint(enabled)))
+	return r
+}
+
+// addAWS adds the resources of an AWS integration.
+func (g *Generator) addAWS(in *integration.AwsCloudWatchIntegration) {
+	auth := newResource("signalfx_aws_external_integration", in.Id)
+	if in.AuthMethod == integration.SECURITY_TOKEN {
+		auth.typ = "signalfx_aws_token_integration"
+	}
+	g.add(auth, in.Name, "integration")
+	auth.str("name", in.Name)
+
+	r := newResource("signalfx_aws_integration", in.Id)
+	g.add(r, in.Name, "integration")
+	r.set("integration_id", literal(auth.address()+".id"))
+	r.set("enabled", literal(fmt.Sprint(in.Enabled)))
+	if in.AuthMethod == integration.SECURITY_TOKEN {
+		r.set("token", g.secret(r, "token"))
+		r.set("key", g.secret(r, "key"))
+	} else {
+		r.set("external_id", literal(auth.address()+".external_id"))
+		r.str("role_arn", in.RoleArn)
+	}
+	r.strs("regions", in.Regions)
+	r.int("poll_rate", in.PollRate/1000)
+	r.int("inactive_metrics_poll_rate", in.InactiveMetricsPollRate/1000)
+	r.strs("services", stringsOf(in.Services))
+	r.bool("import_cloud_watch", in.ImportCloudWatch)
+	r.bool("enable_aws_usage", in.EnableAwsUsage)
+	r.bool("enable_check_large_volume", in.EnableCheckLargeVolume)
+	r.bool("use_get_metric_data_method", in.UseGetMetricDataMethod)
+	r.bool("sync_custom_namespaces_only", in.SyncCustomNamespacesOnly)
+	r.bool("collect_only_recommended_stats", in.CollectOnlyRecommendedStats)
+	r.bool("metric_streams_managed_externally", in.MetricStreamsManagedExternally)
+	r.bool("use_metric_streams_sync", in.MetricStreamsSyncState == "ENABLED")
+	r.str("named_token", in.NamedToken)
+	if in.CustomCloudWatchNamespaces != "" {
+		r.strs("custom_cloudwatch_namespaces", strings.Split(in.CustomCloudWatchNamespaces, ","))
+	}
+	for _, rule := range in.NamespaceSyncRules {
+		setSyncRule(r.block("namespace_sync_rule"), string(rule.Namespace), rule.DefaultAction, rule.Filter)
+	}
+	for _, rule := range in.CustomNamespaceSyncRules {
+		setSyncRule(r.block("custom_namespace_sync_rule"), rule.Namespace, rule.DefaultAction, rule.Filter)
+	}
+	namespaces := make([]string, 0, len(in.MetricStatsToSync))
+	for ns := range in.MetricStatsToSync {
+		namespaces = append(namespaces, ns)
+	}
+	slices.Sort(namespaces)
+	for _, ns := range namespaces {
+		metrics := make([]string, 0, len(in.MetricStatsToSync[ns]))
+		for m := range in.MetricStatsToSync[ns] {
+			metrics = append(metrics, m)
+		}
+		slices.Sort(metrics)
+		for _, m := range metrics {
+			stats := r.block("metric_stats_to_sync")
+			stats.str("namespace", ns)
+			stats.str("metric", m)
+			stats.strs("stats", in.MetricStatsToSync[ns][m])
+		}
+	}
+}
+
+// setSyncRule sets a rule filtering the metrics of an AWS namespace.
+func setSyncRule(b *block, namespace string, defaultAction integration.AwsSyncRuleFilterAction, filter *integration.AwsSyncRuleFilter) {
+	b.str("namespace", namespace)
+	b.str("default_action", string(defaultAction))
+	if filter != nil {
+		b.str("filter_action", string(filter.Action))
+		b.str("filter_source", filter.Source)
+	}
+}
+
+// stringsOf converts a list of named strings, such as services.
+func stringsOf[S ~string](values []S) []string {
+	converted := make([]string, len(values))
+	for i, v := range values {
+		converted[i] = string(v)
+	}
+	return converted
+}
@@ -0,0 +1,179 @@
+// Package terraform generates the Terraform configuration of existing
+// SignalFx objects, as resources of the signalfx provider, along with the
+// import blocks bringing the objects under the management of Terraform:
+//
+//	g := terraform.New()
+//	if err := g.AddDetector(d); err != nil {
+//		return err
+//	}
+//	_, err = g.WriteTo(os.Stdout)
+//
+// The IDs the objects hold, such as the charts of a dashboard or the teams
+// of a detector, become references to the resources of the objects added
+// to the generator, whatever their order, and are kept as is otherwise.
+//
+// Secrets, which the API does not return, become sensitive variables.
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/signalfx/signalfx-go/team"
+)
+
+// ErrUnsupported is returned for the objects, or their parts, that the
+// provider has no resource for.
+var ErrUnsupported = errors.New("not supported by the Terraform provider")
+
+// Generator accumulates objects and writes their configuration. The zero
+// value is not usable, use New.
+type Generator struct {
+	resources []*resource
+	// ids maps the IDs of the objects to their resources.
+	ids map[string]*resource
+	// names holds the addresses of the resources.
+	names     map[string]bool
+	variables []string
+}
+
+// resource is the resource of an object.
+type resource struct {
+	typ  string
+	name string
+	id   string
+	body
+}
+
+// New returns a generator without objects.
+func New() *Generator {
+	return &Generator{
+		ids:   map[string]*resource{},
+		names: map[string]bool{},
+	}
+}
+
+// newResource returns the resource of an object, to be added once its body
+// is complete.
+func newResource(typ, id string) *resource {
+	return &resource{typ: typ, id: id}
+}
+
+// add names the resource after the object, unless another resource of the
+// same type has that name, and adds it to the configuration. The kind of
+// the object names the resources of objects without name.
+func (g *Generator) add(r *resource, name, kind string) {
+	base := identifier(name, kind)
+	r.name = base
+	for i := 2; g.names[r.address()]; i++ {
+		r.name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[r.address()] = true
+	if _, ok := g.ids[r.id]; !ok && r.id != "" {
+		g.ids[r.id] = r
+	}
+	g.resources = append(g.resources, r)
+}
+
+func (r *resource) address() string {
+	return r.typ + "." + r.name
+}
+
+// address returns the address of the resource of the object id.
+func (g *Generator) address(id string) (string, bool) {
+	r, ok := g.ids[id]
+	if !ok {
+		return "", false
+	}
+	return r.address(), true
+}
+
+// secret adds a sensitive variable for an attribute of a resource and
+// returns the expression of its value.
+func (g *Generator) secret(r *resource, attribute string) expr {
+	name := strings.TrimPrefix(r.typ, "signalfx_") + "_" + r.name + "_" + attribute
+	g.variables = append(g.variables, name)
+	return literal("var." + name)
+}
+
+// identifier turns a name into a Terraform identifier, in snake case.
+func identifier(name, kind string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	id := b.String()
+	switch {
+	case id == "":
+		return kind
+	case !unicode.IsLetter(rune(id[0])):
+		return kind + "_" + id
+	}
+	return id
+}
+
+// AddTeam adds a team, whose notifications refer to the integrations added
+// to the generator.
+func (g *Generator) AddTeam(t *team.Team) error {
+	r := newResource("signalfx_team", t.Id)
+	r.str("name", t.Name)
+	r.str("description", t.Description)
+	r.strs("members", t.Members)
+	lists := []struct {
+		severity      string
+		notifications notificationList
+	}{
+		{"critical", t.NotificationLists.Critical},
+		{"default", t.NotificationLists.Default},
+		{"info", t.NotificationLists.Info},
+		{"major", t.NotificationLists.Major},
+		{"minor", t.NotificationLists.Minor},
+		{"warning", t.NotificationLists.Warning},
+	}
+	for _, l := range lists {
+		if err := l.notifications.set(&r.body, "notifications_"+l.severity); err != nil {
+			return fmt.Errorf("team %s: %w", t.Id, err)
+		}
+	}
+	g.add(r, t.Name, "team")
+	return nil
+}
+
+// WriteTo writes the configuration of the objects: the variables of their
+// secrets, then their resources, each followed by its import block.
+func (g *Generator) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	for _, v := range g.variables {
+		b := &block{typ: "variable", labels: []string{v}}
+		b.set("type", literal("string"))
+		b.set("sensitive", literal("true"))
+		b.write(&sb, g, 0)
+		sb.WriteString("\n")
+	}
+	for i, r := range g.resources {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		b := &block{typ: "resource", labels: []string{r.typ, r.name}, body: r.body}
+		b.write(&sb, g, 0)
+		sb.WriteString("\n")
+		imp := &block{typ: "import"}
+		imp.set("to", literal(r.address()))
+		imp.set("id", str(r.id))
+		imp.write(&sb, g, 0)
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
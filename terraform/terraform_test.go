@@ -0,0 +1,314 @@
+package terraform
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/integration"
+	"github.com/signalfx/signalfx-go/notification"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fetchedDetector = `{
+	"id": "D1",
+	"name": "CPU high",
+	"programText": "detect(when(data('cpu') > 90)).publish('CPU')",
+	"maxDelay": 30000,
+	"tags": ["prod"],
+	"teams": ["T1"],
+	"visualizationOptions": {
+		"showDataMarkers": true,
+		"time": {"type": "relative", "range": 3600000},
+		"publishLabelOptions": [{"label": "CPU", "paletteIndex": 1}]
+	},
+	"rules": [{
+		"detectLabel": "CPU",
+		"severity": "Critical",
+		"notifications": [
+			{"type": "Slack", "credentialId": "I1", "channel": "alerts"},
+			{"type": "Team", "team": "T1"},
+			{"type": "Email", "email": "ops@example.com"}
+		]
+	}]
+}`
+
+func generate(t *testing.T, g *Generator) string {
+	var sb strings.Builder
+	_, err := g.WriteTo(&sb)
+	require.NoError(t, err, "Must write the configuration")
+	return sb.String()
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	var d detector.Detector
+	require.NoError(t, json.Unmarshal([]byte(fetchedDetector), &d), "Must decode the detector")
+
+	g := New()
+	// The detector is added first, but refers to the resources added after.
+	require.NoError(t, g.AddDetector(&d), "Must add the detector")
+	require.NoError(t, g.AddIntegration(&integration.SlackIntegration{Id: "I1", Name: "Slack", Enabled: true}), "Must add the integration")
+	require.NoError(t, g.AddTeam(&team.Team{
+		Id:   "T1",
+		Name: "SRE",
+		NotificationLists: team.NotificationLists{
+			Critical: []*notification.Notification{
+				{Type: "PagerDuty", Value: &notification.PagerDutyNotification{Type: "PagerDuty", CredentialId: "I2"}},
+			},
+		},
+	}), "Must add the team")
+	require.NoError(t, g.AddChart(&chart.Chart{
+		Id:          "C1",
+		Name:        "CPU",
+		ProgramText: "A = data('cpu').publish('A')\nB = data('mem').publish('B')",
+		Options: &chart.Options{
+			Type:            "TimeSeriesChart",
+			DefaultPlotType: "LineChart",
+			Time:            &chart.TimeDisplayOptions{Type: "relative", Range: ptr[int64](900000)},
+		},
+	}), "Must add the chart")
+	require.NoError(t, g.AddDashboardGroup(&dashboard_group.DashboardGroup{Id: "G1", Name: "Hosts", Teams: []string{"T1"}}), "Must add the dashboard group")
+	require.NoError(t, g.AddDashboard(&dashboard.Dashboard{
+		Id:      "B1",
+		Name:    "Hosts",
+		GroupId: "G1",
+		Charts:  []*dashboard.DashboardChart{{ChartId: "C1", Width: 6, Height: 1}},
+	}), "Must add the dashboard")
+
+	assert.Equal(t, `variable "slack_integration_slack_webhook_url" {
+  type      = string
+  sensitive = true
+}
+
+resource "signalfx_detector" "cpu_high" {
+  name              = "CPU high"
+  program_text      = "detect(when(data('cpu') > 90)).publish('CPU')"
+  max_delay         = 30
+  tags              = ["prod"]
+  teams             = [signalfx_team.sre.id]
+  show_data_markers = true
+  time_range        = 3600
+  viz_options {
+    label = "CPU"
+    color = "blue"
+  }
+  rule {
+    detect_label  = "CPU"
+    severity      = "Critical"
+    notifications = ["Slack,${signalfx_slack_integration.slack.id},alerts", "Team,${signalfx_team.sre.id}", "Email,ops@example.com"]
+  }
+}
+
+import {
+  to = signalfx_detector.cpu_high
+  id = "D1"
+}
+
+resource "signalfx_slack_integration" "slack" {
+  name        = "Slack"
+  enabled     = true
+  webhook_url = var.slack_integration_slack_webhook_url
+}
+
+import {
+  to = signalfx_slack_integration.slack
+  id = "I1"
+}
+
+resource "signalfx_team" "sre" {
+  name                   = "SRE"
+  notifications_critical = ["PagerDuty,I2"]
+}
+
+import {
+  to = signalfx_team.sre
+  id = "T1"
+}
+
+resource "signalfx_time_chart" "cpu" {
+  name         = "CPU"
+  program_text = chomp(<<EOT
+A = data('cpu').publish('A')
+B = data('mem').publish('B')
+EOT
+)
+  plot_type    = "LineChart"
+  time_range   = 900
+}
+
+import {
+  to = signalfx_time_chart.cpu
+  id = "C1"
+}
+
+resource "signalfx_dashboard_group" "hosts" {
+  name  = "Hosts"
+  teams = [signalfx_team.sre.id]
+}
+
+import {
+  to = signalfx_dashboard_group.hosts
+  id = "G1"
+}
+
+resource "signalfx_dashboard" "hosts" {
+  name            = "Hosts"
+  dashboard_group = signalfx_dashboard_group.hosts.id
+  chart {
+    chart_id = signalfx_time_chart.cpu.id
+    row      = 0
+    column   = 0
+    width    = 6
+    height   = 1
+  }
+}
+
+import {
+  to = signalfx_dashboard.hosts
+  id = "B1"
+}
+`, generate(t, g), "Must generate the configuration")
+}
+
+func TestAddChart(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		chart    chart.Chart
+		resource string
+	}{
+		{"time", chart.Chart{Options: &chart.Options{Type: "TimeSeriesChart"}}, "signalfx_time_chart"},
+		{"single value", chart.Chart{Options: &chart.Options{Type: "SingleValue"}}, "signalfx_single_value_chart"},
+		{"list", chart.Chart{Options: &chart.Options{Type: "List"}}, "signalfx_list_chart"},
+		{"heatmap", chart.Chart{Options: &chart.Options{Type: "Heatmap"}}, "signalfx_heatmap_chart"},
+		{"table", chart.Chart{Options: &chart.Options{Type: "TableChart"}}, "signalfx_table_chart"},
+		{"text", chart.Chart{Options: &chart.Options{Type: "Text"}}, "signalfx_text_chart"},
+		{"event", chart.Chart{Options: &chart.Options{Type: "Event"}}, "signalfx_event_feed_chart"},
+		{"logs", chart.Chart{Options: &chart.Options{Type: "LogsChart"}}, "signalfx_log_view"},
+		{"slo", chart.Chart{SloId: "S1"}, "signalfx_slo_chart"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := New()
+			tc.chart.Id = "C1"
+			require.NoError(t, g.AddChart(&tc.chart), "Must add the chart")
+			assert.Contains(t, generate(t, g), `resource "`+tc.resource+`" "`, "Must map the chart to its resource")
+		})
+	}
+
+	err := New().AddChart(&chart.Chart{Id: "C1", Options: &chart.Options{Type: "Unknown"}})
+	assert.ErrorIs(t, err, ErrUnsupported, "Must not map unknown charts")
+}
+
+func TestColorScale(t *testing.T) {
+	t.Parallel()
+
+	g := New()
+	require.NoError(t, g.AddChart(&chart.Chart{
+		Id:   "C1",
+		Name: "Errors",
+		Options: &chart.Options{
+			Type:        "SingleValue",
+			ColorScale2: []*chart.SecondaryVisualization{{Gt: ptr(10.5), PaletteIndex: ptr[int32](16)}},
+		},
+	}), "Must add the chart")
+	assert.Contains(t, generate(t, g), `  color_scale {
+    gt    = 10.5
+    color = "red"
+  }
+`, "Must map the palette index to the color scale")
+}
+
+func TestAddIntegration(t *testing.T) {
+	t.Parallel()
+
+	g := New()
+	require.NoError(t, g.AddIntegration(&integration.AwsCloudWatchIntegration{
+		Id:         "A1",
+		Name:       "prod",
+		Enabled:    true,
+		AuthMethod: integration.EXTERNAL_ID,
+		RoleArn:    "arn:aws:iam::1:role/sfx",
+		Regions:    []string{"us-east-1"},
+		PollRate:   300000,
+	}), "Must add the integration")
+	out := generate(t, g)
+	assert.Contains(t, out, `resource "signalfx_aws_external_integration" "prod" {
+  name = "prod"
+}`, "Must add the external integration")
+	assert.Contains(t, out, `resource "signalfx_aws_integration" "prod" {
+  integration_id = signalfx_aws_external_integration.prod.id
+  enabled        = true
+  external_id    = signalfx_aws_external_integration.prod.external_id
+  role_arn       = "arn:aws:iam::1:role/sfx"
+  regions        = ["us-east-1"]
+  poll_rate      = 300
+}`, "Must configure the integration")
+	assert.Equal(t, 2, strings.Count(out, `id = "A1"`), "Must import both resources")
+
+	err := g.AddIntegration(&integration.BigPandaIntegration{Id: "B1"})
+	assert.ErrorIs(t, err, ErrUnsupported, "Must not map integrations without resource")
+}
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+
+	g := New()
+	for _, name := range []string{"Hosts", "hosts!", "", "1 host"} {
+		require.NoError(t, g.AddDashboardGroup(&dashboard_group.DashboardGroup{Id: name, Name: name}), "Must add the dashboard group")
+	}
+	var names []string
+	for _, r := range g.resources {
+		names = append(names, r.name)
+	}
+	assert.Equal(t, []string{"hosts", "hosts_2", "dashboard_group", "dashboard_group_1_host"}, names, "Must name resources uniquely")
+}
+
+func TestStrings(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		value string
+		hcl   string
+	}{
+		{"quoted", `say "hi"`, `"say \"hi\""`},
+		{"interpolation", "${x} %{y} $z", `"$${x} %%{y} $z"`},
+		{"heredoc", "a\n${b}\n", "<<EOT\na\n$${b}\nEOT"},
+		{"delimiter", "EOT\n", "<<EOT_\nEOT\nEOT_"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.hcl, str(tc.value).hcl(New()), "Must write the string")
+		})
+	}
+}
+
+func TestUnsupportedNotification(t *testing.T) {
+	t.Parallel()
+
+	err := New().AddDetector(&detector.Detector{
+		Id: "D1",
+		Rules: []*detector.Rule{{Notifications: []*notification.Notification{
+			{Type: "EmailTemplate", Value: &notification.EmailTemplateNotification{Type: "EmailTemplate", TemplateId: "E1"}},
+		}}},
+	})
+	assert.ErrorIs(t, err, ErrUnsupported, "Must not map notifications without string")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
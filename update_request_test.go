@@ -0,0 +1,126 @@
+package signalfx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/signalfx/signalfx-go/alertmuting"
+	"github.com/signalfx/signalfx-go/chart"
+	"github.com/signalfx/signalfx-go/dashboard"
+	"github.com/signalfx/signalfx-go/dashboard_group"
+	"github.com/signalfx/signalfx-go/datalink"
+	"github.com/signalfx/signalfx-go/detector"
+	"github.com/signalfx/signalfx-go/metric_ruleset"
+	"github.com/signalfx/signalfx-go/organization"
+	"github.com/signalfx/signalfx-go/orgtoken"
+	"github.com/signalfx/signalfx-go/team"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// toUpdateRequest decodes an object of type M and returns it with its
+// update request.
+func toUpdateRequest[M, R any](convert func(*M) *R) func(data []byte) (any, any, error) {
+	return func(data []byte) (any, any, error) {
+		var obj M
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return nil, nil, err
+		}
+		return &obj, convert(&obj), nil
+	}
+}
+
+func TestToUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	managed := []string{"id", "created", "creator", "lastUpdated", "lastUpdatedBy"}
+	for _, tc := range []struct {
+		fixture string
+		convert func(data []byte) (any, any, error)
+		// readOnly are the fields of the object the request cannot set.
+		readOnly []string
+	}{
+		{fixture: "alertmuting/get_success.json", convert: toUpdateRequest((*alertmuting.AlertMutingRule).ToUpdateRequest)},
+		{fixture: "chart/get_success.json", convert: toUpdateRequest((*chart.Chart).ToUpdateRequest)},
+		{fixture: "dashboard/get_success.json", convert: toUpdateRequest((*dashboard.Dashboard).ToUpdateRequest), readOnly: []string{"locked"}},
+		{fixture: "dashboardgroup/get_success.json", convert: toUpdateRequest((*dashboard_group.DashboardGroup).ToUpdateRequest)},
+		{fixture: "datalink/get_success.json", convert: toUpdateRequest((*datalink.DataLink).ToUpdateRequest)},
+		{fixture: "detector/get_success.json", convert: toUpdateRequest((*detector.Detector).ToUpdateRequest), readOnly: []string{"labelResolutions", "locked", "overMTSLimit"}},
+		{fixture: "metric_ruleset/get_ruleset_success.json", convert: toUpdateRequest((*metric_ruleset.GetMetricRulesetResponse).ToUpdateRequest), readOnly: []string{"creatorName", "lastUpdatedByName"}},
+		{fixture: "organization/get_member_success.json", convert: toUpdateRequest((*organization.Member).ToUpdateRequest), readOnly: []string{"userId", "organizationId", "email", "fullName", "phone", "title"}},
+		{fixture: "orgtoken/get_success.json", convert: toUpdateRequest((*orgtoken.Token).ToUpdateRequest), readOnly: []string{"secret", "latestRotation", "expiry"}},
+		{fixture: "team/get_success.json", convert: toUpdateRequest((*team.Team).ToUpdateRequest)},
+	} {
+		tc := tc
+		t.Run(tc.fixture, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := os.ReadFile(filepath.Join("testdata", "fixtures", tc.fixture))
+			require.NoError(t, err, "Must read the fixture")
+			obj, req, err := tc.convert(data)
+			require.NoError(t, err, "Must decode the fixture")
+
+			expected := encodeMap(t, obj)
+			for _, field := range append(managed, tc.readOnly...) {
+				delete(expected, field)
+			}
+			actual := encodeMap(t, req)
+
+			assert.Equal(t, withoutZeros(expected), withoutZeros(actual), "Must keep every field the request can set")
+		})
+	}
+}
+
+func encodeMap(t *testing.T, v any) map[string]any {
+	encoded, err := json.Marshal(v)
+	require.NoError(t, err, "Must encode %T", v)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &m), "Must decode %T", v)
+	return m
+}
+
+// withoutZeros removes the zero values from decoded JSON, since the models
+// and requests do not agree on which fields omit them.
+func withoutZeros(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := map[string]any{}
+		for k, e := range v {
+			if e = withoutZeros(e); e != nil {
+				m[k] = e
+			}
+		}
+		if len(m) == 0 {
+			return nil
+		}
+		return m
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		s := make([]any, len(v))
+		for i, e := range v {
+			s[i] = withoutZeros(e)
+		}
+		return s
+	case string, float64, bool:
+		if v == "" || v == float64(0) || v == false {
+			return nil
+		}
+	}
+	return v
+}
+
+func TestToUpdateRequestCustomProperties(t *testing.T) {
+	t.Parallel()
+
+	var d detector.Detector
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"cpu","customProperties":{"owner":"pipeline"}}`), &d), "Must decode the detector")
+	assert.JSONEq(t, `{"owner":"pipeline"}`, d.ToUpdateRequest().CustomProperties, "Must encode object custom properties")
+
+	d.CustomProperties = nil
+	assert.Empty(t, d.ToUpdateRequest().CustomProperties, "Must leave missing custom properties empty")
+	assert.Nil(t, (*detector.Detector)(nil).ToUpdateRequest(), "Must convert nil to nil")
+}